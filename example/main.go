@@ -54,32 +54,6 @@ var users = []User{
 	{Name: "Wendy", Age: 22, Email: "Wendy@myemail.com"},
 }
 
-// actionReturn describes what should be done with the row upon which a user action (via messagebox) is performed
-type actionReturn int
-
-const (
-	ACTION_NONE actionReturn = iota
-	ACTION_DELETE
-	ACTION_DELETE_ALL
-)
-
-// userAction describes a messagebox and subsequent action to be performed
-// on the selected row when the key(s) identified by the key binding is pressed.
-type userAction struct {
-	// Key(s) to launch the action
-	Launch key.Binding
-
-	// Type of message box to show
-	MessageBoxType messagebox.Type
-
-	// Message to display
-	Message string
-
-	// Action to perform when message box is dismissed.
-	// Interface argument contains row metadata
-	Action func(messagebox.Button, interface{}) actionReturn
-}
-
 // Assert interface implementation
 var _ xtable.Metadata = (*User)(nil)
 
@@ -94,12 +68,8 @@ var baseStyle = lipgloss.NewStyle().
 	BorderForeground(lipgloss.Color("240"))
 
 type model struct {
-	table         xtable.Model
-	msgBox        messagebox.Model
-	help          help.Model
-	actions       []userAction
-	currentAction *userAction
-	keymap        KeyMap
+	table xtable.Model
+	help  help.Model
 }
 
 func (m model) Init() tea.Cmd { return nil }
@@ -107,43 +77,36 @@ func (m model) Init() tea.Cmd { return nil }
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
-	if m.msgBox.IsActive() {
-		// Send all messages to message box
-		m1, cmd1 := m.msgBox.Update(msg)
-		m.msgBox = m1.(messagebox.Model)
-		return m, cmd1
+	if m.table.ModalActive() {
+		// Send all messages to table while its delete confirmation is showing
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
 	}
 
 	switch msg := msg.(type) {
 
-	case messagebox.Button:
-		// Act on message box button
-		if m.currentAction != nil && m.currentAction.Action != nil {
-			switch m.currentAction.Action(msg, m.table.SelectedRow().Metadata) {
-			case ACTION_DELETE_ALL:
-
-				// There would be no data left to display, so
-				return m, tea.Quit
+	case xtable.RowDeletedMsg:
+		// Perform actions to delete user identified by the row metadata
+		toDelete := msg.Metadata.(User)
+		_ = toDelete
 
-			case ACTION_DELETE:
-
-				if stillHaveRows := m.table.RemoveSelectedRow(); !stillHaveRows {
-					// Deleted last row
-					return m, tea.Quit
-				}
-			}
+		if msg.Remaining == 0 {
+			// Deleted last row
+			return m, tea.Quit
 		}
 
 	case tea.KeyMsg:
 
-		// If key matches what's in the keymap, perform that action.
-		for _, v := range m.keymap {
-			if key.Matches(msg, v.binding) {
-
-				m, cmd := v.action(m, msg)
-				return m, cmd
-			}
+		if key.Matches(msg, quitKey) {
+			return m, tea.Quit
 		}
+
+		// All other keys are handled by the table
+		m.table, cmd = m.table.Update(msg)
+
+	default:
+		// Messages for the table's internal use
+		m.table, cmd = m.table.Update(msg)
 	}
 	return m, cmd
 }
@@ -151,10 +114,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m model) View() string {
 	sb := strings.Builder{}
 	sb.WriteString(baseStyle.Render(m.table.View()) + "\n")
-	sb.WriteString(m.help.View(m.keymap))
+	sb.WriteString(m.help.View(m.helpKeyMap()))
 
-	// Overlay active messagebox if any
-	return m.msgBox.Render(sb.String())
+	return sb.String()
 }
 
 func main() {
@@ -163,7 +125,14 @@ func main() {
 		xtable.WithStructData(users), // Auto-populate table from slice of struct
 		xtable.WithRowNumbers(),      // Add row number column
 		xtable.WithFocused(true),
-		xtable.WithKeyMap(defaultKeyMap.toTableMap()),
+		xtable.WithQuickSortKeys(), // Sort by column number keys
+		xtable.WithDeleteConfirmation( // Confirm deletion of selected row with DEL key
+			func(r xtable.Row) string {
+				return "Delete " + r.Metadata.(User).Name + "?"
+			},
+			messagebox.WithStyle(messageBoxStyle),
+		),
+		xtable.WithKeyMap(tableKeyMap()),
 	)
 
 	s := xtable.DefaultStyles()
@@ -182,44 +151,9 @@ func main() {
 	helpMdl.ShowAll = true
 	helpMdl.Styles.FullKey = lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
 
-	// Additional actions to process in model's Update method.
-	actions := []userAction{
-		{
-			Launch:         key.NewBinding(key.WithKeys("delete"), key.WithHelp("DEL", "Delete")), // Key to launch action
-			MessageBoxType: messagebox.YES_NO_ALL,                                                 // Type of message box to display (0 = no message box, just do it)
-			Message:        "Delete selected?",                                                    // Message to display in box
-			Action: func(b messagebox.Button, rowData interface{}) actionReturn {
-				// Action to perform when message box is dismissed
-				switch b {
-
-				case messagebox.MB_YES:
-
-					toDelete := rowData.(User)
-					// Perform actions to delete user identified by rowData
-					_ = toDelete
-
-					return ACTION_DELETE // Remove row (handled by your model)
-
-				case messagebox.MB_ALL:
-
-					// Peform actions to delete all users that were in the table.
-					// It is assumed this function has access to the []User that
-					// was used to create the table.
-					// Here it does, since var users is in this file.
-
-					return ACTION_DELETE_ALL // Remove all rows and quit BubbleTea program (handled by your model)
-				}
-
-				return ACTION_NONE
-			},
-		},
-	}
-
 	m := model{
-		table:   t,
-		help:    helpMdl,
-		actions: actions,
-		keymap:  buildKeyMap(actions),
+		table: t,
+		help:  helpMdl,
 	}
 
 	if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
@@ -0,0 +1,541 @@
+package form
+
+// Package form implements a modal form for bubbletea, generated from the exported fields of a struct.
+//
+// Activate by calling New from the Update method of the owning control.
+// When the form is submitted, a SubmittedMsg carrying a copy of the struct updated with the
+// entered values is returned wrapped in a tea.Cmd; when it is cancelled, a CancelledMsg is returned.
+//
+// The control owning the form should call form.Render as the last step in that control's View method
+// to overlay the form.
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fireflycons/bubbles/messagebox"
+	"github.com/mattn/go-runewidth"
+)
+
+// SubmittedMsg is sent when the form is submitted with valid values.
+type SubmittedMsg struct {
+	// ID of the form that was submitted
+	ID int
+
+	// Copy of the struct passed to New, of the same type, updated with the entered values
+	Value interface{}
+}
+
+// CancelledMsg is sent when the form is dismissed without being submitted.
+type CancelledMsg struct {
+	// ID of the form that was cancelled
+	ID int
+}
+
+// KeyMap defines the keybindings of the form.
+type KeyMap struct {
+	Next   key.Binding
+	Prev   key.Binding
+	Submit key.Binding
+	Cancel key.Binding
+}
+
+// DefaultKeyMap returns a default set of keybindings.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Next: key.NewBinding(
+			key.WithKeys("tab", "down"),
+			key.WithHelp("tab", "next field"),
+		),
+		Prev: key.NewBinding(
+			key.WithKeys("shift+tab", "up"),
+			key.WithHelp("shift+tab", "previous field"),
+		),
+		Submit: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "save"),
+		),
+		Cancel: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel"),
+		),
+	}
+}
+
+// Styles contains style definitions for the form. By default, these
+// values are generated by DefaultStyles.
+type Styles struct {
+	Border       lipgloss.Style
+	Title        lipgloss.Style
+	Label        lipgloss.Style
+	FocusedLabel lipgloss.Style
+	Error        lipgloss.Style
+	Help         lipgloss.Style
+	Dim          lipgloss.Style // Content behind the form, if dimmed by WithDimBackground
+}
+
+// DefaultStyles returns a set of default style definitions for the form.
+func DefaultStyles() Styles {
+	return Styles{
+		Border: lipgloss.NewStyle().
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderForeground(lipgloss.Color("63")).
+			Padding(0, 1),
+		Title:        lipgloss.NewStyle().Bold(true),
+		Label:        lipgloss.NewStyle().Foreground(lipgloss.Color("244")),
+		FocusedLabel: lipgloss.NewStyle().Foreground(lipgloss.Color("212")),
+		Error:        lipgloss.NewStyle().Foreground(lipgloss.Color("196")),
+		Help:         lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
+		Dim:          messagebox.DefaultDimStyle(),
+	}
+}
+
+type options struct {
+	xpos      int
+	ypos      int
+	centered  bool
+	dim       bool
+	width     int
+	style     *Styles
+	keyMap    *KeyMap
+	validator func(interface{}) error
+}
+
+// Option sets options in New.
+type Option func(*options)
+
+// WithPosition sets the position of the top left of the form in
+// columns from the left (x), and rows from the top (y).
+// By default, the form is centered over the content passed to Render.
+func WithPosition(x, y int) Option {
+	return func(o *options) {
+		o.xpos = x
+		o.ypos = y
+		o.centered = false
+	}
+}
+
+// WithDimBackground dims the content behind the form, so that the form visually dominates.
+// The content is rendered in the Dim style.
+func WithDimBackground() Option {
+	return func(o *options) {
+		o.dim = true
+	}
+}
+
+// WithWidth sets the width of the input fields.
+func WithWidth(w int) Option {
+	return func(o *options) {
+		o.width = w
+	}
+}
+
+// WithStyle overrides the default style for the form.
+func WithStyle(s Styles) Option {
+	return func(o *options) {
+		o.style = &s
+	}
+}
+
+// WithKeyMap overrides the default keybindings for the form.
+func WithKeyMap(km KeyMap) Option {
+	return func(o *options) {
+		o.keyMap = &km
+	}
+}
+
+// WithValidator sets a function to validate the updated struct before the form is submitted.
+// If it returns an error, the error is displayed and the form remains open.
+func WithValidator(v func(interface{}) error) Option {
+	return func(o *options) {
+		o.validator = v
+	}
+}
+
+const defaultInputWidth = 30
+
+// field is an input field bound to a struct field.
+type field struct {
+	label string
+	index []int
+	kind  reflect.Kind
+	input textinput.Model
+}
+
+// Model is the bubbletea model for a form.
+type Model struct {
+	// Unique ID of the form, set by New
+	id int
+
+	// Title displayed at the top of the form
+	title string
+
+	// Struct being edited
+	value reflect.Value
+
+	// Input fields, in struct field order
+	fields []field
+
+	// Index of the focused field
+	focused int
+
+	// Error from the last attempt to submit the form
+	err error
+
+	// Width of labels, so inputs are aligned
+	labelWidth int
+
+	// Position of the form, unless centered
+	xpos     int
+	ypos     int
+	centered bool
+
+	// Whether the content behind the form is dimmed
+	dim bool
+
+	validator func(interface{}) error
+	styles    Styles
+	keyMap    KeyMap
+	active    bool
+}
+
+var lastID int64
+
+// New creates an active form for editing the exported fields of value, which must be a struct or pointer to struct.
+// Fields of kind string, bool, int, uint and float (and those of embedded structs) are included.
+// Labels are taken from the "form" struct tag, or the title given in the "xtable" struct tag, or the field name.
+//
+// While the form is active, you should direct all UI messages to its Update method.
+func New(title string, value interface{}, opts ...Option) (Model, error) {
+	o := &options{
+		centered: true,
+		width:    defaultInputWidth,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return Model{}, errors.New("form value must be a struct")
+	}
+
+	m := Model{
+		id:        int(atomic.AddInt64(&lastID, 1)),
+		title:     title,
+		value:     v,
+		xpos:      o.xpos,
+		ypos:      o.ypos,
+		centered:  o.centered,
+		dim:       o.dim,
+		validator: o.validator,
+		styles:    DefaultStyles(),
+		keyMap:    DefaultKeyMap(),
+		active:    true,
+	}
+
+	if o.style != nil {
+		m.styles = *o.style
+	}
+
+	if o.keyMap != nil {
+		m.keyMap = *o.keyMap
+	}
+
+	m.fields = buildFields(v, nil, o.width)
+
+	if len(m.fields) == 0 {
+		return Model{}, errors.New("form value has no editable fields")
+	}
+
+	for _, f := range m.fields {
+		m.labelWidth = max(m.labelWidth, runewidth.StringWidth(f.label))
+	}
+
+	m.fields[0].input.Focus()
+
+	return m, nil
+}
+
+// buildFields creates input fields for the supported exported fields of v.
+func buildFields(v reflect.Value, parent []int, width int) []field {
+	fields := []field{}
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+
+		if !sf.IsExported() {
+			continue
+		}
+
+		index := append(append([]int{}, parent...), i)
+
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			fields = append(fields, buildFields(v.Field(i), index, width)...)
+			continue
+		}
+
+		kind := normalizeKind(sf.Type.Kind())
+		if kind == reflect.Invalid {
+			continue
+		}
+
+		input := textinput.New()
+		input.Prompt = ""
+		input.Width = width
+		input.SetValue(fmt.Sprintf("%v", v.Field(i).Interface()))
+
+		fields = append(fields, field{
+			label: label(sf),
+			index: index,
+			kind:  kind,
+			input: input,
+		})
+	}
+
+	return fields
+}
+
+// normalizeKind groups kinds into those handled alike. Unsupported kinds return reflect.Invalid.
+func normalizeKind(k reflect.Kind) reflect.Kind {
+	switch k {
+	case reflect.String, reflect.Bool:
+		return k
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.Int
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return reflect.Uint
+	case reflect.Float32, reflect.Float64:
+		return reflect.Float64
+	}
+
+	return reflect.Invalid
+}
+
+// label determines the label for a struct field.
+func label(sf reflect.StructField) string {
+	if tag := sf.Tag.Get("form"); tag != "" {
+		return tag
+	}
+
+	if tag, _, _ := strings.Cut(sf.Tag.Get("xtable"), ","); tag != "" && tag != "-" {
+		return tag
+	}
+
+	return sf.Name
+}
+
+// ID returns the unique ID of the form, which identifies it in the messages it sends.
+func (m Model) ID() int {
+	return m.id
+}
+
+// IsActive returns true if the form is currently being displayed.
+func (m Model) IsActive() bool {
+	return m.active
+}
+
+// Err returns the reason the last attempt to submit the form failed, or nil.
+func (m Model) Err() error {
+	return m.err
+}
+
+// Init satisfies the BubbleTea Model interface.
+func (m Model) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update processes messages while the form is active.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.active {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.keyMap.Cancel):
+			m.active = false
+			id := m.id
+
+			return m, func() tea.Msg {
+				return CancelledMsg{ID: id}
+			}
+
+		case key.Matches(msg, m.keyMap.Submit):
+			return m.submit()
+
+		case key.Matches(msg, m.keyMap.Next):
+			return m, m.focus((m.focused + 1) % len(m.fields))
+
+		case key.Matches(msg, m.keyMap.Prev):
+			return m, m.focus((m.focused + len(m.fields) - 1) % len(m.fields))
+		}
+	}
+
+	var cmd tea.Cmd
+
+	m.fields[m.focused].input, cmd = m.fields[m.focused].input.Update(msg)
+
+	return m, cmd
+}
+
+// focus moves the focus to the given field.
+func (m *Model) focus(i int) tea.Cmd {
+	m.fields[m.focused].input.Blur()
+	m.focused = i
+
+	return m.fields[m.focused].input.Focus()
+}
+
+// submit parses and validates the entered values, sending SubmittedMsg if they are valid.
+func (m Model) submit() (Model, tea.Cmd) {
+	result := reflect.New(m.value.Type()).Elem()
+	result.Set(m.value)
+
+	for i, f := range m.fields {
+		if err := setValue(result.FieldByIndex(f.index), f.kind, f.input.Value()); err != nil {
+			m.err = fmt.Errorf("%s: %w", f.label, err)
+			return m, m.focus(i)
+		}
+	}
+
+	value := result.Interface()
+
+	if m.validator != nil {
+		if err := m.validator(value); err != nil {
+			m.err = err
+			return m, nil
+		}
+	}
+
+	m.err = nil
+	m.active = false
+	id := m.id
+
+	return m, func() tea.Msg {
+		return SubmittedMsg{ID: id, Value: value}
+	}
+}
+
+// setValue parses s into the struct field v.
+func setValue(v reflect.Value, kind reflect.Kind, s string) error {
+	s = strings.TrimSpace(s)
+
+	switch kind {
+	case reflect.String:
+		v.SetString(s)
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return errors.New("must be true or false")
+		}
+		v.SetBool(b)
+
+	case reflect.Int:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return errors.New("must be a whole number")
+		}
+		v.SetInt(n)
+
+	case reflect.Uint:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return errors.New("must be a positive whole number")
+		}
+		v.SetUint(n)
+
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return errors.New("must be a number")
+		}
+		v.SetFloat(f)
+	}
+
+	return nil
+}
+
+// View doesn't do anything, and it should never be called directly.
+// Implemented as part of BubbleTea Model interface.
+func (m Model) View() string {
+	return ""
+}
+
+// Render takes in the main view content and overlays the form if it is active.
+// It's recommended for this to be the final call of your model's View().
+func (m Model) Render(content string) string {
+	if !m.active {
+		return content
+	}
+
+	box := m.styles.Border.Render(m.render())
+
+	x, y := m.xpos, m.ypos
+	if m.centered {
+		bgWidth := lipgloss.Width(content)
+		bgHeight := lipgloss.Height(content)
+		x = max((bgWidth-lipgloss.Width(box))/2, 0)
+		y = max((bgHeight-lipgloss.Height(box))/2, 0)
+	}
+
+	if m.dim {
+		content = messagebox.Dim(content, m.styles.Dim)
+	}
+
+	return messagebox.PlaceOverlay(x, y, box, content)
+}
+
+// render renders the content of the form.
+func (m Model) render() string {
+	lines := []string{}
+
+	if m.title != "" {
+		lines = append(lines, m.styles.Title.Render(m.title), "")
+	}
+
+	for i, f := range m.fields {
+		labelStyle := m.styles.Label
+		if i == m.focused {
+			labelStyle = m.styles.FocusedLabel
+		}
+
+		padding := strings.Repeat(" ", m.labelWidth-runewidth.StringWidth(f.label))
+		lines = append(lines, padding+labelStyle.Render(f.label)+" "+f.input.View())
+	}
+
+	if m.err != nil {
+		lines = append(lines, "", m.styles.Error.Render(m.err.Error()))
+	}
+
+	lines = append(lines, "", m.styles.Help.Render(fmt.Sprintf(
+		"%s: %s • %s: %s",
+		m.keyMap.Submit.Help().Key, m.keyMap.Submit.Help().Desc,
+		m.keyMap.Cancel.Help().Key, m.keyMap.Cancel.Help().Desc,
+	)))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,148 @@
+package form
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+)
+
+type biscuit struct {
+	Name    string `form:"Biscuit"`
+	Count   int
+	Weight  uint
+	Price   float64
+	Vegan   bool
+	private string
+}
+
+func TestNew(t *testing.T) {
+	f, err := New("Edit", &biscuit{Name: "Hobnobs", Count: 10, private: "x"})
+	require.NoError(t, err)
+	require.True(t, f.IsActive())
+
+	labels := []string{}
+	for _, field := range f.fields {
+		labels = append(labels, field.label)
+	}
+
+	require.Equal(t, []string{"Biscuit", "Count", "Weight", "Price", "Vegan"}, labels)
+	require.Equal(t, "Hobnobs", f.fields[0].input.Value())
+	require.Equal(t, "10", f.fields[1].input.Value())
+
+	_, err = New("Edit", "Hobnobs")
+	require.Error(t, err)
+
+	_, err = New("Edit", struct{ private int }{})
+	require.Error(t, err)
+}
+
+func TestSubmitValidation(t *testing.T) {
+	original := biscuit{Name: "Hobnobs", Count: 10, Weight: 300, Price: 1.5, Vegan: true}
+
+	noEmptyName := func(v interface{}) error {
+		if v.(biscuit).Name == "" {
+			return errors.New("name is required")
+		}
+
+		return nil
+	}
+
+	tests := []struct {
+		name      string
+		values    map[int]string
+		validator func(interface{}) error
+		err       string
+		focused   int
+		expected  biscuit
+	}{
+		{
+			name:     "unchanged",
+			expected: original,
+		},
+		{
+			name:     "all kinds",
+			values:   map[int]string{0: " Tim Tams ", 1: "-8", 2: "200", 3: "3.25", 4: "false"},
+			expected: biscuit{Name: "Tim Tams", Count: -8, Weight: 200, Price: 3.25},
+		},
+		{
+			name:    "int",
+			values:  map[int]string{1: "ten"},
+			err:     "Count: must be a whole number",
+			focused: 1,
+		},
+		{
+			name:    "uint",
+			values:  map[int]string{2: "-1"},
+			err:     "Weight: must be a positive whole number",
+			focused: 2,
+		},
+		{
+			name:    "float",
+			values:  map[int]string{3: "cheap"},
+			err:     "Price: must be a number",
+			focused: 3,
+		},
+		{
+			name:    "bool",
+			values:  map[int]string{4: "maybe"},
+			err:     "Vegan: must be true or false",
+			focused: 4,
+		},
+		{
+			name:    "first invalid field is focused",
+			values:  map[int]string{1: "ten", 3: "cheap"},
+			err:     "Count: must be a whole number",
+			focused: 1,
+		},
+		{
+			name:      "validator",
+			values:    map[int]string{0: ""},
+			validator: noEmptyName,
+			err:       "name is required",
+		},
+		{
+			name:      "validator passes",
+			values:    map[int]string{1: "12"},
+			validator: noEmptyName,
+			expected:  biscuit{Name: "Hobnobs", Count: 12, Weight: 300, Price: 1.5, Vegan: true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := New("Edit", original, WithValidator(test.validator))
+			require.NoError(t, err)
+
+			for i, v := range test.values {
+				f.fields[i].input.SetValue(v)
+			}
+
+			f, cmd := f.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+			if test.err != "" {
+				require.EqualError(t, f.Err(), test.err)
+				require.True(t, f.IsActive())
+				require.Equal(t, test.focused, f.focused)
+				return
+			}
+
+			require.NoError(t, f.Err())
+			require.False(t, f.IsActive())
+			require.Equal(t, SubmittedMsg{ID: f.ID(), Value: test.expected}, cmd())
+		})
+	}
+}
+
+func TestCancel(t *testing.T) {
+	f, err := New("Edit", biscuit{})
+	require.NoError(t, err)
+
+	f, cmd := f.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	require.False(t, f.IsActive())
+	require.Equal(t, CancelledMsg{ID: f.ID()}, cmd())
+
+	f, cmd = f.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Nil(t, cmd, "an inactive form ignores keys")
+}
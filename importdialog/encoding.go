@@ -0,0 +1,82 @@
+package importdialog
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Encoding is the character encoding of an imported file.
+type Encoding int
+
+const (
+	UTF8 Encoding = iota
+	UTF16LE
+	UTF16BE
+	Latin1
+)
+
+// encodings are the encodings offered by the dialog, in order.
+var encodings = []Encoding{UTF8, UTF16LE, UTF16BE, Latin1}
+
+// String returns the name of the encoding.
+func (e Encoding) String() string {
+	switch e {
+	case UTF16LE:
+		return "UTF-16LE"
+	case UTF16BE:
+		return "UTF-16BE"
+	case Latin1:
+		return "ISO-8859-1"
+	}
+
+	return "UTF-8"
+}
+
+var (
+	bomUTF8    = []byte{0xef, 0xbb, 0xbf}
+	bomUTF16LE = []byte{0xff, 0xfe}
+	bomUTF16BE = []byte{0xfe, 0xff}
+)
+
+// decode converts the content of a file in the given encoding to a string.
+// A byte order mark, if present, is removed.
+func decode(b []byte, e Encoding) (string, error) {
+	switch e {
+	case UTF16LE, UTF16BE:
+		b = bytes.TrimPrefix(bytes.TrimPrefix(b, bomUTF16LE), bomUTF16BE)
+		if len(b)%2 != 0 {
+			return "", errors.New("invalid UTF-16: odd number of bytes")
+		}
+
+		u := make([]uint16, len(b)/2)
+		for i := range u {
+			if e == UTF16LE {
+				u[i] = uint16(b[2*i]) | uint16(b[2*i+1])<<8
+			} else {
+				u[i] = uint16(b[2*i])<<8 | uint16(b[2*i+1])
+			}
+		}
+
+		return string(utf16.Decode(u)), nil
+
+	case Latin1:
+		sb := strings.Builder{}
+		sb.Grow(len(b))
+
+		for _, c := range b {
+			sb.WriteRune(rune(c))
+		}
+
+		return sb.String(), nil
+	}
+
+	b = bytes.TrimPrefix(b, bomUTF8)
+	if !utf8.Valid(b) {
+		return "", errors.New("invalid UTF-8: try another encoding")
+	}
+
+	return string(b), nil
+}
@@ -0,0 +1,797 @@
+package importdialog
+
+// Package importdialog implements a modal dialog for bubbletea that loads delimited text files (e.g. CSV)
+// into rows for an xtable.
+//
+// The dialog combines entry of the file path, choice of delimiter, encoding and whether the first
+// line is a header, a live preview of the first rows of the file, and mapping of the file's columns
+// to the columns of the table.
+//
+// Activate by calling New from the Update method of the owning control. While the dialog is active,
+// direct all UI messages to its Update method. When the user imports the file, an ImportedMsg containing
+// the columns and rows is returned wrapped in a tea.Cmd; when the dialog is cancelled, a CancelledMsg is returned.
+//
+// The control owning the dialog should call Render as the last step in that control's View method
+// to overlay the dialog.
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fireflycons/bubbles/messagebox"
+	"github.com/fireflycons/bubbles/xtable"
+	"github.com/mattn/go-runewidth"
+)
+
+// ImportedMsg is sent when the user imports the file.
+type ImportedMsg struct {
+	// ID of the dialog that sent the message
+	ID int
+
+	// Columns of the imported data. These are the target columns passed to New, if any.
+	Columns []xtable.Column
+
+	// Imported rows, with cells in column order
+	Rows []xtable.Row
+}
+
+// CancelledMsg is sent when the dialog is dismissed without importing.
+type CancelledMsg struct {
+	// ID of the dialog that was cancelled
+	ID int
+}
+
+// loadedMsg carries the result of loading the file.
+type loadedMsg struct {
+	id      int
+	seq     int
+	records [][]string
+	err     error
+}
+
+// Delimiter is a field separator offered by the dialog.
+type Delimiter struct {
+	Name  string
+	Comma rune
+}
+
+// DefaultDelimiters are the field separators offered by the dialog, unless overridden by WithDelimiters.
+var DefaultDelimiters = []Delimiter{
+	{Name: "Comma", Comma: ','},
+	{Name: "Tab", Comma: '\t'},
+	{Name: "Semicolon", Comma: ';'},
+	{Name: "Pipe", Comma: '|'},
+}
+
+// KeyMap defines the keybindings of the dialog.
+type KeyMap struct {
+	Next   key.Binding
+	Prev   key.Binding
+	Left   key.Binding
+	Right  key.Binding
+	Import key.Binding
+	Cancel key.Binding
+}
+
+// DefaultKeyMap returns a default set of keybindings.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Next: key.NewBinding(
+			key.WithKeys("tab", "down"),
+			key.WithHelp("tab", "next field"),
+		),
+		Prev: key.NewBinding(
+			key.WithKeys("shift+tab", "up"),
+			key.WithHelp("shift+tab", "previous field"),
+		),
+		Left: key.NewBinding(
+			key.WithKeys("left"),
+			key.WithHelp("←", "previous choice"),
+		),
+		Right: key.NewBinding(
+			key.WithKeys("right", " "),
+			key.WithHelp("→", "next choice"),
+		),
+		Import: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "import"),
+		),
+		Cancel: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel"),
+		),
+	}
+}
+
+// Styles contains style definitions for the dialog. By default, these
+// values are generated by DefaultStyles.
+type Styles struct {
+	Border       lipgloss.Style
+	Title        lipgloss.Style
+	Label        lipgloss.Style
+	FocusedLabel lipgloss.Style
+	Error        lipgloss.Style
+	Help         lipgloss.Style
+	Dim          lipgloss.Style // Content behind the dialog, if dimmed by WithDimBackground
+}
+
+// DefaultStyles returns a set of default style definitions for the dialog.
+func DefaultStyles() Styles {
+	return Styles{
+		Border: lipgloss.NewStyle().
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderForeground(lipgloss.Color("63")).
+			Padding(0, 1),
+		Title:        lipgloss.NewStyle().Bold(true),
+		Label:        lipgloss.NewStyle().Foreground(lipgloss.Color("244")),
+		FocusedLabel: lipgloss.NewStyle().Foreground(lipgloss.Color("212")),
+		Error:        lipgloss.NewStyle().Foreground(lipgloss.Color("196")),
+		Help:         lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
+		Dim:          messagebox.DefaultDimStyle(),
+	}
+}
+
+type options struct {
+	xpos        int
+	ypos        int
+	centered    bool
+	dim         bool
+	path        string
+	delimiters  []Delimiter
+	encoding    Encoding
+	header      bool
+	previewRows int
+	width       int
+	style       *Styles
+	keyMap      *KeyMap
+}
+
+// Option sets options in New.
+type Option func(*options)
+
+// WithPosition sets the position of the top left of the dialog in
+// columns from the left (x), and rows from the top (y).
+// By default, the dialog is centered over the content passed to Render.
+func WithPosition(x, y int) Option {
+	return func(o *options) {
+		o.xpos = x
+		o.ypos = y
+		o.centered = false
+	}
+}
+
+// WithPath sets the initial file path.
+func WithPath(path string) Option {
+	return func(o *options) {
+		o.path = path
+	}
+}
+
+// WithDelimiters sets the field separators offered by the dialog. The first is initially selected.
+func WithDelimiters(d ...Delimiter) Option {
+	return func(o *options) {
+		o.delimiters = d
+	}
+}
+
+// WithEncoding sets the initially selected encoding. The default is UTF8.
+func WithEncoding(e Encoding) Option {
+	return func(o *options) {
+		o.encoding = e
+	}
+}
+
+// WithHeader sets whether the first line of the file is initially treated as a header. The default is true.
+func WithHeader(header bool) Option {
+	return func(o *options) {
+		o.header = header
+	}
+}
+
+// WithPreviewRows sets the number of rows shown in the preview. The default is 5.
+func WithPreviewRows(n int) Option {
+	return func(o *options) {
+		o.previewRows = n
+	}
+}
+
+// WithDimBackground dims the content behind the dialog, so that the dialog visually dominates.
+// The content is rendered in the Dim style.
+func WithDimBackground() Option {
+	return func(o *options) {
+		o.dim = true
+	}
+}
+
+// WithWidth sets the width of the preview and file path input.
+func WithWidth(w int) Option {
+	return func(o *options) {
+		o.width = w
+	}
+}
+
+// WithStyle overrides the default style for the dialog.
+func WithStyle(s Styles) Option {
+	return func(o *options) {
+		o.style = &s
+	}
+}
+
+// WithKeyMap overrides the default keybindings for the dialog.
+func WithKeyMap(km KeyMap) Option {
+	return func(o *options) {
+		o.keyMap = &km
+	}
+}
+
+const (
+	defaultPreviewRows = 5
+	defaultWidth       = 60
+	maxPreviewColWidth = 20
+)
+
+// Fields of the dialog, in focus order. Column mappings follow.
+const (
+	fieldPath = iota
+	fieldDelimiter
+	fieldEncoding
+	fieldHeader
+	fieldMapping
+)
+
+// Model is the bubbletea model for the import dialog.
+type Model struct {
+	// Unique ID of the dialog, set by New
+	id int
+
+	// Titles of the columns to import into, or nil to import the file's columns
+	targets []string
+
+	// Field values
+	path       textinput.Model
+	delimiters []Delimiter
+	delimiter  int
+	encoding   int
+	header     bool
+
+	// Index of the file's column for each target column, or -1 for none
+	mapping []int
+
+	// Index of the focused field
+	focused int
+
+	// Content of the file, and the error loading or importing it
+	records [][]string
+	err     error
+
+	// Incremented when the file is reloaded, so stale results can be discarded
+	seq int
+
+	// Preview of the first rows
+	preview     xtable.Model
+	previewRows int
+	width       int
+
+	// Position of the dialog, unless centered
+	xpos     int
+	ypos     int
+	centered bool
+
+	// Whether the content behind the dialog is dimmed
+	dim bool
+
+	styles Styles
+	keyMap KeyMap
+	active bool
+}
+
+var lastID int64
+
+// New creates an active import dialog. targets are the titles of the columns of the table to import into.
+// The user maps a column of the file to each of these. If targets is empty, the file's columns are imported as they are.
+//
+// The returned command loads the file given by WithPath, if any, and must be returned to Bubble Tea.
+func New(targets []string, opts ...Option) (Model, tea.Cmd) {
+	o := &options{
+		centered:    true,
+		delimiters:  DefaultDelimiters,
+		header:      true,
+		previewRows: defaultPreviewRows,
+		width:       defaultWidth,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	path := textinput.New()
+	path.Prompt = ""
+	path.Placeholder = "path to file"
+	path.Width = o.width
+	path.SetValue(o.path)
+	path.Focus()
+
+	m := Model{
+		id:          int(atomic.AddInt64(&lastID, 1)),
+		targets:     targets,
+		path:        path,
+		delimiters:  o.delimiters,
+		header:      o.header,
+		previewRows: o.previewRows,
+		width:       o.width,
+		xpos:        o.xpos,
+		ypos:        o.ypos,
+		centered:    o.centered,
+		dim:         o.dim,
+		styles:      DefaultStyles(),
+		keyMap:      DefaultKeyMap(),
+		active:      true,
+	}
+
+	for i, e := range encodings {
+		if e == o.encoding {
+			m.encoding = i
+		}
+	}
+
+	if o.style != nil {
+		m.styles = *o.style
+	}
+
+	if o.keyMap != nil {
+		m.keyMap = *o.keyMap
+	}
+
+	m.refresh()
+
+	return m, tea.Batch(textinput.Blink, m.reload())
+}
+
+// ID returns the unique ID of the dialog, which identifies it in the messages it sends.
+func (m Model) ID() int {
+	return m.id
+}
+
+// IsActive returns true if the dialog is currently being displayed.
+func (m Model) IsActive() bool {
+	return m.active
+}
+
+// Err returns the error loading the file, or the reason the last attempt to import it failed.
+func (m Model) Err() error {
+	return m.err
+}
+
+// Init satisfies the BubbleTea Model interface.
+func (m Model) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update processes messages while the dialog is active.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.active {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case loadedMsg:
+		if msg.id == m.id && msg.seq == m.seq {
+			m.records, m.err = msg.records, msg.err
+			m.refresh()
+		}
+
+		return m, nil
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keyMap.Cancel):
+			m.active = false
+			id := m.id
+
+			return m, func() tea.Msg {
+				return CancelledMsg{ID: id}
+			}
+
+		case key.Matches(msg, m.keyMap.Import):
+			return m.doImport()
+
+		case key.Matches(msg, m.keyMap.Next):
+			return m, m.focus((m.focused + 1) % m.fieldCount())
+
+		case key.Matches(msg, m.keyMap.Prev):
+			return m, m.focus((m.focused + m.fieldCount() - 1) % m.fieldCount())
+
+		case m.focused != fieldPath && key.Matches(msg, m.keyMap.Left):
+			return m, m.change(-1)
+
+		case m.focused != fieldPath && key.Matches(msg, m.keyMap.Right):
+			return m, m.change(1)
+		}
+	}
+
+	if m.focused != fieldPath {
+		return m, nil
+	}
+
+	path := m.path.Value()
+
+	var cmd tea.Cmd
+
+	m.path, cmd = m.path.Update(msg)
+
+	if m.path.Value() != path {
+		return m, tea.Batch(cmd, m.reload())
+	}
+
+	return m, cmd
+}
+
+// fieldCount returns the number of fields in the dialog.
+func (m Model) fieldCount() int {
+	return fieldMapping + len(m.mapping)
+}
+
+// focus moves the focus to the given field.
+func (m *Model) focus(i int) tea.Cmd {
+	m.focused = i
+
+	if i == fieldPath {
+		return m.path.Focus()
+	}
+
+	m.path.Blur()
+
+	return nil
+}
+
+// change moves the choice of the focused field by delta.
+func (m *Model) change(delta int) tea.Cmd {
+	switch m.focused {
+	case fieldDelimiter:
+		m.delimiter = wrap(m.delimiter+delta, len(m.delimiters))
+		return m.reload()
+
+	case fieldEncoding:
+		m.encoding = wrap(m.encoding+delta, len(encodings))
+		return m.reload()
+
+	case fieldHeader:
+		m.header = !m.header
+		m.refresh()
+
+	default:
+		// Choices are none (-1) and each of the file's columns
+		i := m.focused - fieldMapping
+		m.mapping[i] = wrap(m.mapping[i]+1+delta, len(m.sourceColumns())+1) - 1
+	}
+
+	return nil
+}
+
+// reload returns a command to load the file in the background.
+func (m *Model) reload() tea.Cmd {
+	m.seq++
+
+	path := strings.TrimSpace(m.path.Value())
+	if path == "" {
+		m.records, m.err = nil, nil
+		m.refresh()
+
+		return nil
+	}
+
+	id, seq := m.id, m.seq
+	comma := ','
+
+	if len(m.delimiters) > 0 {
+		comma = m.delimiters[m.delimiter].Comma
+	}
+
+	encoding := encodings[m.encoding]
+
+	return func() tea.Msg {
+		records, err := load(path, comma, encoding)
+		return loadedMsg{id: id, seq: seq, records: records, err: err}
+	}
+}
+
+// load reads and parses a delimited file.
+func load(path string, comma rune, encoding Encoding) ([][]string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := decode(b, encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	r := csv.NewReader(strings.NewReader(s))
+	r.Comma = comma
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+
+	return r.ReadAll()
+}
+
+// sourceColumns returns the titles of the file's columns.
+func (m Model) sourceColumns() []string {
+	if m.header && len(m.records) > 0 {
+		return m.records[0]
+	}
+
+	n := 0
+	for _, r := range m.records {
+		n = max(n, len(r))
+	}
+
+	cols := make([]string, n)
+	for i := range cols {
+		cols[i] = fmt.Sprintf("Column %d", i+1)
+	}
+
+	return cols
+}
+
+// dataRecords returns the records of the file excluding any header.
+func (m Model) dataRecords() [][]string {
+	if m.header && len(m.records) > 0 {
+		return m.records[1:]
+	}
+
+	return m.records
+}
+
+// targetColumns returns the titles of the columns to import into.
+func (m Model) targetColumns() []string {
+	if len(m.targets) > 0 {
+		return m.targets
+	}
+
+	return m.sourceColumns()
+}
+
+// refresh recomputes the column mapping and preview after the file or header setting changes.
+func (m *Model) refresh() {
+	sources := m.sourceColumns()
+	targets := m.targetColumns()
+	m.mapping = make([]int, len(targets))
+
+	for i, t := range targets {
+		m.mapping[i] = -1
+
+		for j, s := range sources {
+			if strings.EqualFold(strings.TrimSpace(s), strings.TrimSpace(t)) {
+				m.mapping[i] = j
+				break
+			}
+		}
+
+		if m.mapping[i] == -1 && len(m.targets) == 0 && i < len(sources) {
+			m.mapping[i] = i
+		}
+	}
+
+	if m.focused >= m.fieldCount() {
+		m.focused = fieldPath
+		m.path.Focus()
+	}
+
+	records := m.dataRecords()
+	if len(records) > m.previewRows {
+		records = records[:m.previewRows]
+	}
+
+	cols, rows := buildTable(sources, records, identity(len(sources)), maxPreviewColWidth)
+
+	m.preview = xtable.New(
+		xtable.WithColumns(cols),
+		xtable.WithRows(rows),
+		xtable.WithHeight(m.previewRows),
+		xtable.WithWidth(m.width),
+	)
+}
+
+// doImport sends ImportedMsg with the mapped rows of the file.
+func (m Model) doImport() (Model, tea.Cmd) {
+	if m.err == nil && len(m.records) == 0 {
+		m.err = errors.New("no data to import")
+	}
+
+	if m.err != nil {
+		return m, nil
+	}
+
+	cols, rows := buildTable(m.targetColumns(), m.dataRecords(), m.mapping, 0)
+
+	m.active = false
+	msg := ImportedMsg{ID: m.id, Columns: cols, Rows: rows}
+
+	return m, func() tea.Msg {
+		return msg
+	}
+}
+
+// buildTable creates table columns with the given titles, and rows from records where the cell of
+// each column is taken from the field given by mapping. Column widths fit the content, up to maxWidth if not zero.
+func buildTable(titles []string, records [][]string, mapping []int, maxWidth int) ([]xtable.Column, []xtable.Row) {
+	cols := make([]xtable.Column, len(titles))
+	for i, t := range titles {
+		cols[i] = xtable.Column{Title: t, Width: runewidth.StringWidth(t)}
+	}
+
+	rows := make([]xtable.Row, len(records))
+
+	for i, rec := range records {
+		data := make([]string, len(titles))
+
+		for j, src := range mapping {
+			if src >= 0 && src < len(rec) {
+				data[j] = rec[src]
+				cols[j].Width = max(cols[j].Width, runewidth.StringWidth(data[j]))
+			}
+		}
+
+		rows[i] = xtable.Row{Data: data}
+	}
+
+	if maxWidth > 0 {
+		for i := range cols {
+			cols[i].Width = min(cols[i].Width, maxWidth)
+		}
+	}
+
+	return cols, rows
+}
+
+// identity returns a mapping of n columns to themselves.
+func identity(n int) []int {
+	mapping := make([]int, n)
+	for i := range mapping {
+		mapping[i] = i
+	}
+
+	return mapping
+}
+
+// View doesn't do anything, and it should never be called directly.
+// Implemented as part of BubbleTea Model interface.
+func (m Model) View() string {
+	return ""
+}
+
+// Render takes in the main view content and overlays the dialog if it is active.
+// It's recommended for this to be the final call of your model's View().
+func (m Model) Render(content string) string {
+	if !m.active {
+		return content
+	}
+
+	box := m.styles.Border.Render(m.render())
+
+	x, y := m.xpos, m.ypos
+	if m.centered {
+		x = max((lipgloss.Width(content)-lipgloss.Width(box))/2, 0)
+		y = max((lipgloss.Height(content)-lipgloss.Height(box))/2, 0)
+	}
+
+	if m.dim {
+		content = messagebox.Dim(content, m.styles.Dim)
+	}
+
+	return messagebox.PlaceOverlay(x, y, box, content)
+}
+
+// render renders the content of the dialog.
+func (m Model) render() string {
+	delimiter := ""
+	if len(m.delimiters) > 0 {
+		delimiter = m.delimiters[m.delimiter].Name
+	}
+
+	header := "no"
+	if m.header {
+		header = "yes"
+	}
+
+	fields := []struct {
+		label string
+		value string
+	}{
+		{"File", m.path.View()},
+		{"Delimiter", choice(delimiter)},
+		{"Encoding", choice(encodings[m.encoding].String())},
+		{"Header row", choice(header)},
+	}
+
+	sources := m.sourceColumns()
+	targets := m.targetColumns()
+
+	labelWidth := 0
+	for _, f := range fields {
+		labelWidth = max(labelWidth, runewidth.StringWidth(f.label))
+	}
+
+	for _, t := range targets {
+		labelWidth = max(labelWidth, runewidth.StringWidth(t))
+	}
+
+	lines := []string{m.styles.Title.Render("Import"), ""}
+
+	for i, f := range fields {
+		lines = append(lines, m.label(i, f.label, labelWidth)+" "+f.value)
+	}
+
+	lines = append(lines, "", m.preview.View(), "", m.styles.Title.Render("Columns"))
+
+	for i, t := range targets {
+		source := "(none)"
+		if src := m.mapping[i]; src >= 0 && src < len(sources) {
+			source = sources[src]
+		}
+
+		lines = append(lines, m.label(fieldMapping+i, t, labelWidth)+" ← "+choice(source))
+	}
+
+	if m.err != nil {
+		lines = append(lines, "", m.styles.Error.Render(m.err.Error()))
+	}
+
+	lines = append(lines, "", m.styles.Help.Render(fmt.Sprintf(
+		"%s: %s • %s: %s • %s: %s",
+		m.keyMap.Next.Help().Key, m.keyMap.Next.Help().Desc,
+		m.keyMap.Import.Help().Key, m.keyMap.Import.Help().Desc,
+		m.keyMap.Cancel.Help().Key, m.keyMap.Cancel.Help().Desc,
+	)))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// label renders the label of a field, right aligned.
+func (m Model) label(field int, text string, width int) string {
+	style := m.styles.Label
+	if field == m.focused {
+		style = m.styles.FocusedLabel
+	}
+
+	return strings.Repeat(" ", width-runewidth.StringWidth(text)) + style.Render(text)
+}
+
+// choice renders the value of a field chosen with left and right.
+func choice(value string) string {
+	return "‹ " + value + " ›"
+}
+
+// wrap wraps i into the range [0, n).
+func wrap(i, n int) int {
+	if n == 0 {
+		return 0
+	}
+
+	return ((i % n) + n) % n
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
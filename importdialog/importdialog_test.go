@@ -0,0 +1,85 @@
+package importdialog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		encoding Encoding
+		want     string
+	}{
+		{"utf8 bom", []byte("\xef\xbb\xbfa,b"), UTF8, "a,b"},
+		{"utf16le", []byte{0xff, 0xfe, 'a', 0, ',', 0, 'b', 0}, UTF16LE, "a,b"},
+		{"utf16be", []byte{0, 'a', 0, ',', 0, 'b'}, UTF16BE, "a,b"},
+		{"latin1", []byte{'c', 'a', 'f', 0xe9}, Latin1, "café"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decode(tt.input, tt.encoding)
+			if err != nil {
+				t.Fatalf("decode() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("decode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := decode([]byte{0xe9}, UTF8); err == nil {
+		t.Error("expected error decoding invalid UTF-8")
+	}
+}
+
+func TestImportMapsColumns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.csv")
+	if err := os.WriteFile(path, []byte("age;name;extra\n30;alice;x\n40;bob;y\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	m, cmd := New([]string{"Name", "Age", "Email"}, WithPath(path), WithDelimiters(Delimiter{Name: "Semicolon", Comma: ';'}))
+
+	msgs := cmd().(tea.BatchMsg)
+	for _, c := range msgs {
+		if c == nil {
+			continue
+		}
+
+		if msg, ok := c().(loadedMsg); ok {
+			m, _ = m.Update(msg)
+		}
+	}
+
+	if m.Err() != nil {
+		t.Fatalf("unexpected error: %v", m.Err())
+	}
+
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.IsActive() {
+		t.Error("dialog still active after import")
+	}
+
+	msg, ok := cmd().(ImportedMsg)
+	if !ok {
+		t.Fatalf("expected ImportedMsg, got %T", cmd())
+	}
+
+	if len(msg.Rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(msg.Rows))
+	}
+
+	want := []string{"alice", "30", ""}
+	for i, v := range want {
+		if msg.Rows[0].Data[i] != v {
+			t.Errorf("cell %d = %q, want %q", i, msg.Rows[0].Data[i], v)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+package keyhelp
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fireflycons/bubbles/messagebox"
+)
+
+// Space between the groups of a cheat sheet
+const groupGap = 4
+
+// CheatSheetStyles contains style definitions for a cheat sheet. By default, these
+// values are generated by DefaultCheatSheetStyles.
+type CheatSheetStyles struct {
+	Border lipgloss.Style
+	Title  lipgloss.Style
+	Group  lipgloss.Style
+	Key    lipgloss.Style
+	Desc   lipgloss.Style
+	Dim    lipgloss.Style // Content behind the sheet, if dimmed by WithCheatSheetDimBackground
+}
+
+// DefaultCheatSheetStyles returns a set of default style definitions for a cheat sheet.
+func DefaultCheatSheetStyles() CheatSheetStyles {
+	return CheatSheetStyles{
+		Border: lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("63")).
+			Padding(0, 1),
+		Title: lipgloss.NewStyle().Bold(true),
+		Group: lipgloss.NewStyle().Bold(true).Underline(true),
+		Key:   lipgloss.NewStyle().Foreground(lipgloss.Color("214")),
+		Desc:  lipgloss.NewStyle().Foreground(lipgloss.Color("250")),
+		Dim:   messagebox.DefaultDimStyle(),
+	}
+}
+
+// CheatSheet is a large overlay listing all the key bindings of a KeyMap, grouped by component in columns,
+// toggled by a key (by default ?). This is richer than the help footer for programs with many keys.
+//
+// Pass all messages to Update, and call Render as the last step in the owning control's View method
+// to overlay the sheet when it is visible.
+type CheatSheet struct {
+	keyMap  KeyMap
+	title   string
+	toggle  key.Binding
+	close   key.Binding
+	styles  CheatSheetStyles
+	visible bool
+	dim     bool
+	width   int
+	height  int
+}
+
+// CheatSheetOption sets options in NewCheatSheet.
+type CheatSheetOption func(*CheatSheet)
+
+// WithCheatSheetTitle sets a title shown at the top of the cheat sheet.
+func WithCheatSheetTitle(title string) CheatSheetOption {
+	return func(c *CheatSheet) {
+		c.title = title
+	}
+}
+
+// WithToggleKey sets the key that shows and hides the cheat sheet.
+func WithToggleKey(b key.Binding) CheatSheetOption {
+	return func(c *CheatSheet) {
+		c.toggle = b
+	}
+}
+
+// WithCheatSheetStyles overrides the default styles of the cheat sheet.
+func WithCheatSheetStyles(s CheatSheetStyles) CheatSheetOption {
+	return func(c *CheatSheet) {
+		c.styles = s
+	}
+}
+
+// WithCheatSheetDimBackground dims the content behind the cheat sheet, so that the sheet visually dominates.
+// The content is rendered in the Dim style.
+func WithCheatSheetDimBackground() CheatSheetOption {
+	return func(c *CheatSheet) {
+		c.dim = true
+	}
+}
+
+// NewCheatSheet creates a hidden cheat sheet for the bindings of a KeyMap.
+func NewCheatSheet(km KeyMap, opts ...CheatSheetOption) CheatSheet {
+	c := CheatSheet{
+		keyMap: km,
+		toggle: key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "all keys")),
+		close:  key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "close")),
+		styles: DefaultCheatSheetStyles(),
+	}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c
+}
+
+// SetKeyMap sets the bindings listed by the cheat sheet, e.g. when components are added or removed.
+func (c *CheatSheet) SetKeyMap(km KeyMap) {
+	c.keyMap = km
+}
+
+// ToggleKey returns the key that shows and hides the cheat sheet, for including in the program's help.
+func (c CheatSheet) ToggleKey() key.Binding {
+	return c.toggle
+}
+
+// Visible returns true if the cheat sheet is shown.
+func (c CheatSheet) Visible() bool {
+	return c.visible
+}
+
+// Show shows the cheat sheet.
+func (c *CheatSheet) Show() {
+	c.visible = true
+}
+
+// Hide hides the cheat sheet.
+func (c *CheatSheet) Hide() {
+	c.visible = false
+}
+
+// Update handles the toggle key, the escape key to close the sheet, and window size messages
+// to fit the sheet to the window. While the sheet is visible, the owning control should not
+// act on key messages.
+func (c CheatSheet) Update(msg tea.Msg) (CheatSheet, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		c.width = msg.Width
+		c.height = msg.Height
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, c.toggle):
+			c.visible = !c.visible
+		case c.visible && key.Matches(msg, c.close):
+			c.visible = false
+		}
+	}
+
+	return c, nil
+}
+
+// View renders the cheat sheet, whether or not it is visible. Groups are laid out in columns,
+// wrapping onto further rows of columns if they do not fit the width of the window.
+func (c CheatSheet) View() string {
+	blocks := c.groupBlocks()
+
+	maxWidth := 0
+	if c.width > 0 {
+		maxWidth = c.width - c.styles.Border.GetHorizontalFrameSize()
+	}
+
+	rows := []string{}
+	row := []string{}
+	rowWidth := 0
+
+	for _, b := range blocks {
+		w := lipgloss.Width(b)
+
+		if len(row) > 0 && maxWidth > 0 && rowWidth+groupGap+w > maxWidth {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+			row, rowWidth = nil, 0
+		}
+
+		if len(row) > 0 {
+			row = append(row, strings.Repeat(" ", groupGap))
+			rowWidth += groupGap
+		}
+
+		row = append(row, b)
+		rowWidth += w
+	}
+
+	if len(row) > 0 {
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+	}
+
+	body := strings.Join(rows, "\n\n")
+
+	if c.title != "" {
+		body = lipgloss.JoinVertical(lipgloss.Center, c.styles.Title.Render(c.title), "", body)
+	}
+
+	return c.styles.Border.Render(body)
+}
+
+// Render overlays the cheat sheet, centered, on the content if it is visible.
+func (c CheatSheet) Render(content string) string {
+	if !c.visible {
+		return content
+	}
+
+	sheet := c.View()
+	x := (lipgloss.Width(content) - lipgloss.Width(sheet)) / 2   //nolint:mnd
+	y := (lipgloss.Height(content) - lipgloss.Height(sheet)) / 2 //nolint:mnd
+
+	if c.dim {
+		content = messagebox.Dim(content, c.styles.Dim)
+	}
+
+	return messagebox.PlaceOverlay(x, y, sheet, content)
+}
+
+// groupBlocks renders each group of enabled bindings as a column headed by the group name.
+func (c CheatSheet) groupBlocks() []string {
+	blocks := []string{}
+
+	var group []Entry
+
+	flush := func() {
+		if len(group) == 0 {
+			return
+		}
+
+		keyWidth := 0
+		for _, e := range group {
+			keyWidth = max(keyWidth, lipgloss.Width(e.Binding.Help().Key))
+		}
+
+		lines := []string{c.styles.Group.Render(group[0].Group)}
+
+		for _, e := range group {
+			h := e.Binding.Help()
+			lines = append(lines, c.styles.Key.Render(h.Key+strings.Repeat(" ", keyWidth-lipgloss.Width(h.Key)))+"  "+c.styles.Desc.Render(h.Desc))
+		}
+
+		blocks = append(blocks, strings.Join(lines, "\n"))
+		group = nil
+	}
+
+	for _, e := range c.keyMap.Entries() {
+		if !e.Binding.Enabled() {
+			continue
+		}
+
+		if len(group) > 0 && (e.Group != group[0].Group || e.Order != group[0].Order) {
+			flush()
+		}
+
+		group = append(group, e)
+	}
+
+	flush()
+
+	return blocks
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,93 @@
+package keyhelp
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/require"
+)
+
+func plainCheatSheetStyles() CheatSheetStyles {
+	return CheatSheetStyles{Border: lipgloss.NewStyle().Border(lipgloss.NormalBorder())}
+}
+
+func TestCheatSheetView(t *testing.T) {
+	km := New().
+		Add("global", 0, binding("q"), binding("ctrl+c")).
+		Add("table", 10, binding("up"))
+
+	c := NewCheatSheet(km, WithCheatSheetStyles(plainCheatSheetStyles()))
+
+	expected := strings.Join([]string{
+		"┌────────────────────────┐",
+		"│global            table │",
+		"│q       q         up  up│",
+		"│ctrl+c  ctrl+c          │",
+		"└────────────────────────┘",
+	}, "\n")
+
+	require.Equal(t, expected, c.View())
+}
+
+func TestCheatSheetWraps(t *testing.T) {
+	km := New().
+		Add("global", 0, binding("q")).
+		Add("table", 10, binding("up"))
+
+	c := NewCheatSheet(km, WithCheatSheetStyles(plainCheatSheetStyles()))
+	c, _ = c.Update(tea.WindowSizeMsg{Width: 12, Height: 20})
+
+	expected := strings.Join([]string{
+		"┌──────┐",
+		"│global│",
+		"│q  q  │",
+		"│      │",
+		"│table │",
+		"│up  up│",
+		"└──────┘",
+	}, "\n")
+
+	require.Equal(t, expected, c.View())
+}
+
+func TestCheatSheetToggle(t *testing.T) {
+	c := NewCheatSheet(New().Add("global", 0, binding("q")))
+	content := strings.Repeat(strings.Repeat(".", 30)+"\n", 9) + strings.Repeat(".", 30)
+
+	require.Equal(t, content, c.Render(content))
+
+	c, _ = c.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	require.True(t, c.Visible())
+	require.NotEqual(t, content, c.Render(content))
+
+	c, _ = c.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	require.False(t, c.Visible())
+}
+
+func TestCheatSheetDimBackground(t *testing.T) {
+	styles := plainCheatSheetStyles()
+	styles.Dim = lipgloss.NewStyle().Transform(strings.ToUpper)
+
+	c := NewCheatSheet(New().Add("g", 0, binding("q")), WithCheatSheetStyles(styles), WithCheatSheetDimBackground())
+	c.Show()
+
+	content := strings.Join([]string{
+		"abcdefghij",
+		"abcdefghij",
+		"abcdefghij",
+		"abcdefghij",
+		"abcdefghij",
+	}, "\n")
+
+	expected := strings.Join([]string{
+		"AB┌────┐IJ",
+		"AB│g   │IJ",
+		"AB│q  q│IJ",
+		"AB└────┘IJ",
+		"ABCDEFGHIJ",
+	}, "\n")
+
+	require.Equal(t, expected, c.Render(content))
+}
@@ -0,0 +1,182 @@
+package keyhelp
+
+// Package keyhelp merges the key bindings of several components (e.g. xtable.KeyMap,
+// the buttons of a message box and application keys) into a single help.KeyMap
+// for rendering with the bubbles help component.
+//
+// Bindings are added in groups, each with an order. Groups are displayed in ascending
+// order, and the bindings of a group in the order they were added. For example
+//
+//	km := keyhelp.New(keyhelp.WithColumnHeight(2)).
+//		Add("global", 0, quitKey).
+//		AddKeyMap("table", 10, table.HelpKeyMap())
+
+import (
+	"sort"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// Entry is a key binding with its ordering and grouping metadata.
+type Entry struct {
+	// The key binding
+	Binding key.Binding
+
+	// Name of the group the binding belongs to
+	Group string
+
+	// Order of the group in the help display
+	Order int
+
+	// Whether the binding is included in the short help
+	Short bool
+}
+
+// KeyMap is a help.KeyMap composed of the key bindings of several components.
+type KeyMap struct {
+	entries      []Entry
+	columnHeight int
+}
+
+var _ help.KeyMap = KeyMap{}
+
+// Option sets options in New.
+type Option func(*KeyMap)
+
+// WithColumnHeight sets the maximum number of bindings in each column of the full help.
+// Groups with more bindings are split over several columns. By default, each group is one column.
+func WithColumnHeight(h int) Option {
+	return func(km *KeyMap) {
+		km.columnHeight = h
+	}
+}
+
+// New creates an empty KeyMap.
+func New(opts ...Option) KeyMap {
+	km := KeyMap{}
+
+	for _, opt := range opts {
+		opt(&km)
+	}
+
+	return km
+}
+
+// Add returns a copy of the KeyMap with the bindings appended to the given group, which is displayed at the given order.
+// The bindings are included in the short help.
+func (km KeyMap) Add(group string, order int, bindings ...key.Binding) KeyMap {
+	return km.add(group, order, bindings, func(int) bool { return true })
+}
+
+// AddKeyMap returns a copy of the KeyMap with the full help bindings of another help.KeyMap appended to the given group,
+// which is displayed at the given order. Only those bindings in the other KeyMap's short help are included in the short help.
+func (km KeyMap) AddKeyMap(group string, order int, other help.KeyMap) KeyMap {
+	bindings := []key.Binding{}
+
+	for _, column := range other.FullHelp() {
+		bindings = append(bindings, column...)
+	}
+
+	short := other.ShortHelp()
+
+	return km.add(group, order, bindings, func(i int) bool {
+		for _, s := range short {
+			if sameBinding(s, bindings[i]) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// Entries returns the bindings in display order.
+func (km KeyMap) Entries() []Entry {
+	entries := make([]Entry, len(km.entries))
+	copy(entries, km.entries)
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Order < entries[j].Order
+	})
+
+	return entries
+}
+
+// ShortHelp implements the help.KeyMap interface.
+func (km KeyMap) ShortHelp() []key.Binding {
+	bindings := []key.Binding{}
+
+	for _, e := range km.Entries() {
+		if e.Short && e.Binding.Enabled() {
+			bindings = append(bindings, e.Binding)
+		}
+	}
+
+	return bindings
+}
+
+// FullHelp implements the help.KeyMap interface.
+func (km KeyMap) FullHelp() [][]key.Binding {
+	columns := [][]key.Binding{}
+	column := []key.Binding{}
+	group, order := "", 0
+
+	for _, e := range km.Entries() {
+		if !e.Binding.Enabled() {
+			// Not rendered by help, so don't leave gaps
+			continue
+		}
+
+		full := km.columnHeight > 0 && len(column) >= km.columnHeight
+		newGroup := e.Group != group || e.Order != order
+
+		if len(column) > 0 && (full || newGroup) {
+			columns = append(columns, column)
+			column = []key.Binding{}
+		}
+
+		column = append(column, e.Binding)
+		group, order = e.Group, e.Order
+	}
+
+	if len(column) > 0 {
+		columns = append(columns, column)
+	}
+
+	return columns
+}
+
+// add returns a copy of the KeyMap with the bindings appended.
+func (km KeyMap) add(group string, order int, bindings []key.Binding, short func(int) bool) KeyMap {
+	entries := make([]Entry, len(km.entries), len(km.entries)+len(bindings))
+	copy(entries, km.entries)
+
+	for i, b := range bindings {
+		entries = append(entries, Entry{
+			Binding: b,
+			Group:   group,
+			Order:   order,
+			Short:   short(i),
+		})
+	}
+
+	km.entries = entries
+
+	return km
+}
+
+// sameBinding returns true if two bindings have the same keys and help.
+func sameBinding(a, b key.Binding) bool {
+	if a.Help() != b.Help() || len(a.Keys()) != len(b.Keys()) {
+		return false
+	}
+
+	for i, k := range a.Keys() {
+		if b.Keys()[i] != k {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,58 @@
+package keyhelp
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/stretchr/testify/require"
+)
+
+func binding(k string) key.Binding {
+	return key.NewBinding(key.WithKeys(k), key.WithHelp(k, k))
+}
+
+type testKeyMap struct {
+	a, b, c key.Binding
+}
+
+func (km testKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{km.a}
+}
+
+func (km testKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{km.a, km.b}, {km.c}}
+}
+
+func helpKeys(columns [][]key.Binding) [][]string {
+	result := [][]string{}
+
+	for _, col := range columns {
+		keys := []string{}
+		for _, b := range col {
+			keys = append(keys, b.Help().Key)
+		}
+		result = append(result, keys)
+	}
+
+	return result
+}
+
+func TestKeyMapOrdering(t *testing.T) {
+	disabled := binding("x")
+	disabled.SetEnabled(false)
+
+	km := New(WithColumnHeight(2)).
+		AddKeyMap("table", 10, testKeyMap{a: binding("a"), b: binding("b"), c: binding("c")}).
+		Add("global", 0, binding("q")).
+		Add("actions", 20, binding("d"), disabled)
+
+	require.Equal(t, [][]string{{"q"}, {"a", "b"}, {"c"}, {"d"}}, helpKeys(km.FullHelp()))
+	require.Equal(t, [][]string{{"q", "a", "d"}}, helpKeys([][]key.Binding{km.ShortHelp()}))
+}
+
+func TestKeyMapAddDoesNotModifyOriginal(t *testing.T) {
+	base := New().Add("global", 0, binding("q"))
+	_ = base.Add("table", 10, binding("a"))
+
+	require.Equal(t, 1, len(base.Entries()))
+}
@@ -0,0 +1,108 @@
+package messagebox
+
+import (
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Key of about boxes
+var copyVersion = key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "copy version"))
+
+// AboutInfo describes an application for an about box. Empty fields are not shown.
+type AboutInfo struct {
+	// Name of the application
+	Name string
+
+	// Version of the application
+	Version string
+
+	// Commit from which the application was built
+	Commit string
+
+	// License under which the application is distributed, e.g. "MIT"
+	License string
+
+	// Acknowledgements, e.g. of the libraries used, shown one per line
+	Acknowledgements []string
+}
+
+// VersionInfo returns the name, version and commit of the application on one line, e.g. "app 1.2.0 (3f2a9c1)".
+// This is the text copied to the clipboard from an about box.
+func (a AboutInfo) VersionInfo() string {
+	parts := []string{}
+
+	for _, s := range []string{a.Name, a.Version} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+
+	if a.Commit != "" {
+		parts = append(parts, "("+a.Commit+")")
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// CopiedMsg is sent when the version information has been copied to the clipboard from an about box.
+type CopiedMsg struct {
+	// Text copied
+	Text string
+
+	// Error copying to the clipboard, or nil on success
+	Err error
+}
+
+// About creates a new modal message box describing the application, with an OK button, centered over the content
+// unless positioned by the options. The c key copies the version information (see AboutInfo.VersionInfo) to the
+// clipboard, after which CopiedMsg is sent. The box should receive this message to show whether the copy succeeded.
+// When dismissed, MB_OK is returned as for New.
+func (m Model) About(info AboutInfo, opts ...Option) Model {
+	lines := []string{}
+
+	if v := info.VersionInfo(); v != "" {
+		lines = append(lines, v)
+	}
+
+	if info.License != "" {
+		lines = append(lines, "License: "+info.License)
+	}
+
+	if len(info.Acknowledgements) > 0 {
+		lines = append(lines, "", "Acknowledgements")
+		lines = append(lines, info.Acknowledgements...)
+	}
+
+	return m.newBox(strings.Join(lines, "\n"), OK, &box{kind: aboutBox, about: info}, append([]Option{WithCentered()}, opts...))
+}
+
+// updateAbout processes keys for an about box. Returns true and a command if the key was handled.
+func (m Model) updateAbout(msg tea.KeyMsg) (bool, tea.Cmd) {
+	if !key.Matches(msg, copyVersion) {
+		return false, nil
+	}
+
+	text := m.box.about.VersionInfo()
+
+	return true, func() tea.Msg {
+		return CopiedMsg{Text: text, Err: clipboard.WriteAll(text)}
+	}
+}
+
+// renderAbout renders the status line of an about box.
+func (m Model) renderAbout() string {
+	status := "c: copy version"
+
+	switch {
+	case m.box.copyErr != nil:
+		status = "Copy failed: " + m.box.copyErr.Error()
+	case m.box.copied:
+		status = "Version copied"
+	}
+
+	return lipgloss.NewStyle().Width(m.width - 2).Align(lipgloss.Center).Render(m.styles.Details.Render(status))
+}
@@ -0,0 +1,54 @@
+package messagebox
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-runewidth"
+)
+
+// Change in width of the box for each key press when resizing
+const resizeStep = 2
+
+// Keys that move and resize a box, enabled by WithMoveResize
+var (
+	moveKeys   = key.NewBinding(key.WithKeys("alt+up", "alt+down", "alt+left", "alt+right"), key.WithHelp("alt+←↑↓→", "move"))
+	resizeKeys = key.NewBinding(key.WithKeys("shift+left", "shift+right"), key.WithHelp("shift+←→", "resize"))
+)
+
+// WithMoveResize lets the user move the message box with alt+arrow keys, and make it narrower or wider with
+// shift+left and shift+right, re-wrapping the message to the new width, e.g. when the box covers content the user
+// needs to read. The box cannot be made narrower than its buttons. Moving works whether the box is positioned,
+// anchored or centered, and the box is kept within the content it is rendered over.
+func WithMoveResize() Option {
+	return func(o *options) {
+		o.adjustable = true
+	}
+}
+
+// adjust moves or resizes the box for a key. Returns true if the key was handled.
+func (m *Model) adjust(msg tea.KeyMsg) bool {
+	switch msg.String() {
+	case "alt+up":
+		m.dy--
+	case "alt+down":
+		m.dy++
+	case "alt+left":
+		m.dx--
+	case "alt+right":
+		m.dx++
+	case "shift+left":
+		m.resize(m.width - resizeStep)
+	case "shift+right":
+		m.resize(m.width + resizeStep)
+	default:
+		return false
+	}
+
+	return true
+}
+
+// resize changes the width of the box, but not to less than the width of its buttons.
+func (m *Model) resize(width int) {
+	m.width = max(width, runewidth.StringWidth(m.renderButtons())+2)
+	m.layout()
+}
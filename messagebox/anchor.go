@@ -0,0 +1,127 @@
+package messagebox
+
+// Rect is a rectangle of the content a message box is rendered over, e.g. the area occupied by a component.
+type Rect struct {
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+// Side is the side of a Rect on which a message box is placed.
+type Side int
+
+const (
+	Below Side = iota
+	Above
+	Right
+	Left
+)
+
+// opposite returns the opposite side.
+func (s Side) opposite() Side {
+	switch s {
+	case Above:
+		return Below
+	case Right:
+		return Left
+	case Left:
+		return Right
+	}
+
+	return Above
+}
+
+// anchor attaches the message box to a rectangle of the content it is rendered over.
+type anchor struct {
+	// Whether the box is anchored. If not, the box is placed at its position.
+	set bool
+
+	// Rectangle the box is adjacent to
+	rect Rect
+
+	// Preferred side of the rectangle
+	side Side
+}
+
+// WithRowAnchor positions the message box adjacent to the given row of the content passed to Render,
+// with its left edge at column x. The box is placed below the row if there is room, otherwise above it.
+// Use MoveAnchor to follow the row when it moves, e.g. when a table scrolls.
+func WithRowAnchor(x, row int) Option {
+	return WithRelativeTo(Rect{X: x, Y: row, Height: 1}, Below)
+}
+
+// WithRelativeTo positions the message box adjacent to the given side of a rectangle of the content passed to Render,
+// e.g. a button, cell or pane. Above or below, the box is aligned with the left of the rectangle. Left or right, it is
+// aligned with the top. If there isn't room on the given side, the box is placed on the opposite side.
+// Use MoveRelativeTo to follow the rectangle when it moves.
+func WithRelativeTo(rect Rect, side Side) Option {
+	return func(o *options) {
+		o.anchor = anchor{set: true, rect: rect, side: side}
+		o.centered = false
+	}
+}
+
+// MoveAnchor returns the message box with its anchor moved to the given row and column.
+// It has no effect if the box was not positioned with WithRowAnchor or WithRelativeTo.
+func (m Model) MoveAnchor(x, row int) Model {
+	if m.anchor.set {
+		m.anchor.rect.X = x
+		m.anchor.rect.Y = row
+	}
+
+	return m
+}
+
+// MoveRelativeTo returns the message box positioned relative to a new rectangle, on the same side.
+// It has no effect if the box was not positioned with WithRowAnchor or WithRelativeTo.
+func (m Model) MoveRelativeTo(rect Rect) Model {
+	if m.anchor.set {
+		m.anchor.rect = rect
+	}
+
+	return m
+}
+
+// position returns the position of the top left of a box of the given size
+// when rendered over content of the given size.
+func (m Model) position(boxWidth, boxHeight, contentWidth, contentHeight int) (int, int) {
+	if m.centered {
+		return max((contentWidth-boxWidth)/2, 0), max((contentHeight-boxHeight)/2, 0) //nolint:mnd
+	}
+
+	if !m.anchor.set {
+		return m.xpos, m.ypos
+	}
+
+	r := m.anchor.rect
+	side := m.anchor.side
+
+	fits := func(s Side) bool {
+		switch s {
+		case Above:
+			return r.Y-boxHeight >= 0
+		case Right:
+			return r.X+r.Width+boxWidth <= contentWidth
+		case Left:
+			return r.X-boxWidth >= 0
+		}
+
+		return r.Y+r.Height+boxHeight <= contentHeight
+	}
+
+	if !fits(side) && fits(side.opposite()) {
+		side = side.opposite()
+	}
+
+	switch side {
+	case Above:
+		return r.X, r.Y - boxHeight
+	case Right:
+		return r.X + r.Width, r.Y
+	case Left:
+		return r.X - boxWidth, r.Y
+	}
+
+	return r.X, r.Y + r.Height
+}
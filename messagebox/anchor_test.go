@@ -0,0 +1,76 @@
+package messagebox
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/require"
+)
+
+// boxBounds returns the first and last lines and the first column of content covered by the box.
+func boxBounds(rendered, content string) (top, bottom, left int) {
+	top, bottom, left = -1, -1, -1
+	contentLines := strings.Split(content, "\n")
+
+	for i, l := range strings.Split(ansi.Strip(rendered), "\n") {
+		if l == contentLines[i] {
+			continue
+		}
+
+		if top == -1 {
+			top = i
+			left = strings.IndexFunc(l, func(r rune) bool { return r != '.' })
+		}
+
+		bottom = i
+	}
+
+	return top, bottom, left
+}
+
+func TestRowAnchor(t *testing.T) {
+	content := strings.TrimSuffix(strings.Repeat(strings.Repeat(".", 60)+"\n", 20), "\n")
+
+	tests := []struct {
+		name  string
+		x     int
+		row   int
+		below bool
+	}{
+		{name: "below the row", x: 2, row: 3, below: true},
+		{name: "below the first row", x: 0, row: 0, below: true},
+		{name: "above the last row", x: 5, row: 19, below: false},
+		{name: "above when no room below", x: 2, row: 16, below: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			box := Model{}.New("Delete?", YES_NO, WithRowAnchor(test.x, test.row))
+
+			top, bottom, left := boxBounds(box.Render(content), content)
+			require.Equal(t, test.x, left)
+
+			if test.below {
+				require.Equal(t, test.row+1, top)
+			} else {
+				require.Equal(t, test.row-1, bottom)
+			}
+		})
+	}
+}
+
+func TestMoveAnchor(t *testing.T) {
+	content := strings.TrimSuffix(strings.Repeat(strings.Repeat(".", 60)+"\n", 20), "\n")
+
+	box := Model{}.New("Delete?", YES_NO, WithRowAnchor(2, 3)).MoveAnchor(4, 6)
+	top, _, left := boxBounds(box.Render(content), content)
+	require.Equal(t, 7, top)
+	require.Equal(t, 4, left)
+
+	// Boxes without an anchor are not moved
+	box = Model{}.New("Delete?", YES_NO, WithPosition(1, 1)).MoveAnchor(4, 6)
+	top, _, left = boxBounds(box.Render(content), content)
+	require.Equal(t, 1, top)
+	require.Equal(t, 1, left)
+}
@@ -0,0 +1,67 @@
+package messagebox
+
+import (
+	"io"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// WithBell causes the command returned by AttentionCmd to ring the terminal bell, to attract the attention
+// of a user who has switched to another window, e.g. when a long-running tool reports an error.
+func WithBell() Option {
+	return func(o *options) {
+		o.bell = true
+	}
+}
+
+// WithBellOutput sets where the terminal bell requested by WithBell is written, which is os.Stdout by default.
+// Pass the writer given to tea.WithOutput when the program does not render to os.Stdout. The bell is written
+// by the command returned by AttentionCmd, outside the program's renderer, so the write is not synchronised
+// with rendering.
+func WithBellOutput(w io.Writer) Option {
+	return func(o *options) {
+		o.bellOutput = w
+	}
+}
+
+// WithTitleFlash causes the command returned by AttentionCmd to set the terminal window title to the given text,
+// e.g. "⚠ Build failed", which most terminals show in the title bar or tab even when the window is not focused.
+// The title is not restored, so the application should set it again when the box is dismissed.
+func WithTitleFlash(title string) Option {
+	return func(o *options) {
+		o.title = title
+	}
+}
+
+// AttentionCmd returns a command that rings the bell and sets the window title as requested by WithBell and
+// WithTitleFlash when the box was created, or nil if neither was requested. Return it from the owning control's
+// Update method along with the new box, e.g.
+//
+//	m.box = m.box.Error(err, messagebox.WithBell())
+//	return m, m.box.AttentionCmd()
+func (m Model) AttentionCmd() tea.Cmd {
+	if m.box == nil {
+		return nil
+	}
+
+	cmds := []tea.Cmd{}
+
+	if m.bell {
+		w := m.bellOutput
+		if w == nil {
+			w = os.Stdout
+		}
+
+		cmds = append(cmds, func() tea.Msg {
+			_, _ = io.WriteString(w, "\a")
+			return nil
+		})
+	}
+
+	if m.title != "" {
+		cmds = append(cmds, tea.SetWindowTitle(m.title))
+	}
+
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,21 @@
+package messagebox
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttentionCmdBell(t *testing.T) {
+	out := bytes.Buffer{}
+
+	require.Nil(t, Model{}.New("Done", OK).AttentionCmd())
+
+	cmd := Model{}.New("Failed", OK, WithBell(), WithBellOutput(&out)).AttentionCmd()
+	require.NotNil(t, cmd)
+	require.Empty(t, out.String(), "the bell is rung when the command runs")
+
+	require.Nil(t, cmd())
+	require.Equal(t, "\a", out.String())
+}
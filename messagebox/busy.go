@@ -0,0 +1,161 @@
+package messagebox
+
+import (
+	"sync/atomic"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Last assigned busy overlay ID
+var lastBusyID int64
+
+// BusyStyles contains style definitions for a busy overlay. By default, these
+// values are generated by DefaultBusyStyles.
+type BusyStyles struct {
+	Box     lipgloss.Style
+	Spinner lipgloss.Style
+	Message lipgloss.Style
+	Dim     lipgloss.Style // Content behind the overlay
+}
+
+// DefaultBusyStyles returns a set of default style definitions for a busy overlay.
+func DefaultBusyStyles() BusyStyles {
+	return BusyStyles{
+		Box: lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color(border)).
+			Padding(0, 2),
+		Spinner: lipgloss.NewStyle().Foreground(lipgloss.Color("63")),
+		Dim:     DefaultDimStyle(),
+	}
+}
+
+// busyMsg shows or hides a busy overlay.
+type busyMsg struct {
+	id      int
+	active  bool
+	message string
+}
+
+// Busy is a full screen overlay that dims the whole view and shows a spinner and message, for moments
+// when the entire UI must be inert, e.g. during a critical operation. Unlike a message box, it has no buttons
+// and cannot be dismissed by the user. It is shown and hidden by the commands returned by ShowBusyCmd
+// and HideBusyCmd, so it can be controlled from background commands.
+//
+// Pass all messages to Update, and while the overlay is active do not pass key or mouse messages to
+// any other component. Call Render as the last step in the owning control's View method.
+type Busy struct {
+	id      int
+	spinner spinner.Model
+	styles  BusyStyles
+	message string
+	active  bool
+}
+
+// BusyOption sets options in NewBusy.
+type BusyOption func(*Busy)
+
+// WithBusySpinner sets the spinner animation. The default is spinner.Dot.
+func WithBusySpinner(s spinner.Spinner) BusyOption {
+	return func(b *Busy) {
+		b.spinner.Spinner = s
+	}
+}
+
+// WithBusyStyles overrides the default styles of the overlay.
+func WithBusyStyles(s BusyStyles) BusyOption {
+	return func(b *Busy) {
+		b.styles = s
+	}
+}
+
+// NewBusy creates an inactive busy overlay.
+func NewBusy(opts ...BusyOption) Busy {
+	b := Busy{
+		id:      int(atomic.AddInt64(&lastBusyID, 1)),
+		spinner: spinner.New(spinner.WithSpinner(spinner.Dot)),
+		styles:  DefaultBusyStyles(),
+	}
+
+	for _, opt := range opts {
+		opt(&b)
+	}
+
+	return b
+}
+
+// ShowBusyCmd returns a command that shows the busy overlay with the given ID, with a message below the spinner.
+// If the overlay is already shown, the message is updated.
+func ShowBusyCmd(id int, message string) tea.Cmd {
+	return func() tea.Msg {
+		return busyMsg{id: id, active: true, message: message}
+	}
+}
+
+// HideBusyCmd returns a command that hides the busy overlay with the given ID.
+func HideBusyCmd(id int) tea.Cmd {
+	return func() tea.Msg {
+		return busyMsg{id: id}
+	}
+}
+
+// ID returns the unique ID of the overlay, which is passed to ShowBusyCmd and HideBusyCmd.
+func (b Busy) ID() int {
+	return b.id
+}
+
+// IsActive returns true if the overlay is shown.
+func (b Busy) IsActive() bool {
+	return b.active
+}
+
+// Update processes the show and hide commands, and animates the spinner.
+func (b Busy) Update(msg tea.Msg) (Busy, tea.Cmd) {
+	switch msg := msg.(type) {
+	case busyMsg:
+		if msg.id != b.id {
+			return b, nil
+		}
+
+		wasActive := b.active
+		b.active = msg.active
+		b.message = msg.message
+
+		if b.active && !wasActive {
+			return b, b.spinner.Tick
+		}
+
+	case spinner.TickMsg:
+		if !b.active {
+			// Stops the animation
+			return b, nil
+		}
+
+		var cmd tea.Cmd
+		b.spinner, cmd = b.spinner.Update(msg)
+
+		return b, cmd
+	}
+
+	return b, nil
+}
+
+// Render dims the content and overlays the spinner and message, centered, if the overlay is active.
+func (b Busy) Render(content string) string {
+	if !b.active {
+		return content
+	}
+
+	inner := b.styles.Spinner.Render(b.spinner.View())
+	if b.message != "" {
+		inner += " " + b.styles.Message.Render(b.message)
+	}
+
+	box := b.styles.Box.Render(inner)
+	x := max((lipgloss.Width(content)-lipgloss.Width(box))/2, 0)   //nolint:mnd
+	y := max((lipgloss.Height(content)-lipgloss.Height(box))/2, 0) //nolint:mnd
+
+	return PlaceOverlay(x, y, box, Dim(content, b.styles.Dim))
+}
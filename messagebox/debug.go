@@ -0,0 +1,288 @@
+package messagebox
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Default number of messages listed by the debug overlay
+const defaultDebugHistory = 8
+
+// Default width at which values in the debug overlay are truncated
+const defaultDebugValueWidth = 48
+
+// DebugField is a named value of a component's internal state, listed by the debug overlay.
+type DebugField struct {
+	Name  string
+	Value string
+}
+
+// DebugReporter is implemented by components that report their internal state to the debug overlay,
+// e.g. xtable.Model and Model.
+type DebugReporter interface {
+	DebugState() []DebugField
+}
+
+// DebugSection is a titled group of fields in the debug overlay, normally the state of one component.
+type DebugSection struct {
+	Title  string
+	Fields []DebugField
+}
+
+// DebugReport returns a section of the debug overlay containing the state of a component.
+func DebugReport(title string, r DebugReporter) DebugSection {
+	return DebugSection{Title: title, Fields: r.DebugState()}
+}
+
+// DebugStyles contains style definitions for the debug overlay. By default, these
+// values are generated by DefaultDebugStyles.
+type DebugStyles struct {
+	Box   lipgloss.Style
+	Title lipgloss.Style
+	Name  lipgloss.Style
+	Value lipgloss.Style
+}
+
+// DefaultDebugStyles returns a set of default style definitions for the debug overlay.
+func DefaultDebugStyles() DebugStyles {
+	return DebugStyles{
+		Box: lipgloss.NewStyle().
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderForeground(lipgloss.Color("214")).
+			Padding(0, 1),
+		Title: lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("214")),
+		Name:  lipgloss.NewStyle().Foreground(lipgloss.Color("244")),
+		Value: lipgloss.NewStyle(),
+	}
+}
+
+// Debug is an overlay for diagnosing layout and state bugs in composed programs. When toggled on by a key
+// (by default f12), it lists the internal state of the components passed to Render, e.g. a table's cursor,
+// offset, sort and filter, or the active message box, and the most recent messages, over the top right
+// of the live UI.
+//
+// Pass all messages to Update, so that they are recorded even while the overlay is hidden, and call Render
+// as the last step in the owning control's View method. The toggle key is not consumed, so choose one the
+// program does not otherwise use.
+type Debug struct {
+	toggle     key.Binding
+	visible    bool
+	history    int
+	valueWidth int
+	record     func(tea.Msg) bool
+	msgs       []string
+	styles     DebugStyles
+}
+
+// DebugOption sets options in NewDebug.
+type DebugOption func(*Debug)
+
+// WithDebugToggleKey sets the key that shows and hides the debug overlay.
+func WithDebugToggleKey(b key.Binding) DebugOption {
+	return func(d *Debug) {
+		d.toggle = b
+	}
+}
+
+// WithDebugHistory sets the number of recent messages listed. Zero disables the list.
+func WithDebugHistory(n int) DebugOption {
+	return func(d *Debug) {
+		d.history = n
+	}
+}
+
+// WithDebugFilter sets a function selecting the messages to record, e.g. to leave out frequent
+// ticks that would push more interesting messages out of the list.
+func WithDebugFilter(record func(tea.Msg) bool) DebugOption {
+	return func(d *Debug) {
+		d.record = record
+	}
+}
+
+// WithDebugValueWidth sets the width at which values and messages are truncated.
+func WithDebugValueWidth(width int) DebugOption {
+	return func(d *Debug) {
+		d.valueWidth = width
+	}
+}
+
+// WithDebugStyles overrides the default styles of the debug overlay.
+func WithDebugStyles(s DebugStyles) DebugOption {
+	return func(d *Debug) {
+		d.styles = s
+	}
+}
+
+// NewDebug creates a hidden debug overlay.
+func NewDebug(opts ...DebugOption) Debug {
+	d := Debug{
+		toggle:     key.NewBinding(key.WithKeys("f12"), key.WithHelp("f12", "debug")),
+		history:    defaultDebugHistory,
+		valueWidth: defaultDebugValueWidth,
+		styles:     DefaultDebugStyles(),
+	}
+
+	for _, opt := range opts {
+		opt(&d)
+	}
+
+	return d
+}
+
+// ToggleKey returns the key that shows and hides the debug overlay, for including in the program's help.
+func (d Debug) ToggleKey() key.Binding {
+	return d.toggle
+}
+
+// Visible returns true if the debug overlay is shown.
+func (d Debug) Visible() bool {
+	return d.visible
+}
+
+// Show shows the debug overlay.
+func (d *Debug) Show() {
+	d.visible = true
+}
+
+// Hide hides the debug overlay.
+func (d *Debug) Hide() {
+	d.visible = false
+}
+
+// Messages returns the recorded messages, oldest first.
+func (d Debug) Messages() []string {
+	return d.msgs
+}
+
+// Update handles the toggle key, and records other messages.
+func (d Debug) Update(msg tea.Msg) (Debug, tea.Cmd) {
+	if k, ok := msg.(tea.KeyMsg); ok && key.Matches(k, d.toggle) {
+		d.visible = !d.visible
+		return d, nil
+	}
+
+	if d.history <= 0 || (d.record != nil && !d.record(msg)) {
+		return d, nil
+	}
+
+	msgs := append(d.msgs, d.truncate(fmt.Sprintf("%T %+v", msg, msg)))
+	if len(msgs) > d.history {
+		msgs = msgs[len(msgs)-d.history:]
+	}
+
+	// Copy, so that copies of the overlay do not share the list
+	d.msgs = append([]string(nil), msgs...)
+
+	return d, nil
+}
+
+// View renders the debug overlay listing the given sections and the recorded messages.
+func (d Debug) View(sections ...DebugSection) string {
+	if d.history > 0 {
+		fields := make([]DebugField, len(d.msgs))
+		for i, m := range d.msgs {
+			fields[i] = DebugField{Name: strconv.Itoa(i + 1), Value: m}
+		}
+
+		sections = append(sections, DebugSection{Title: "Messages", Fields: fields})
+	}
+
+	blocks := make([]string, 0, len(sections))
+
+	for _, s := range sections {
+		nameWidth := 0
+		for _, f := range s.Fields {
+			nameWidth = max(nameWidth, lipgloss.Width(f.Name))
+		}
+
+		lines := []string{d.styles.Title.Render(s.Title)}
+
+		for _, f := range s.Fields {
+			name := f.Name + strings.Repeat(" ", nameWidth-lipgloss.Width(f.Name))
+			lines = append(lines, d.styles.Name.Render(name)+" "+d.styles.Value.Render(d.truncate(f.Value)))
+		}
+
+		blocks = append(blocks, strings.Join(lines, "\n"))
+	}
+
+	return d.styles.Box.Render(strings.Join(blocks, "\n\n"))
+}
+
+// Render overlays the debug overlay, at the top right, on the content if it is visible.
+func (d Debug) Render(content string, sections ...DebugSection) string {
+	if !d.visible {
+		return content
+	}
+
+	overlay := d.View(sections...)
+
+	return PlaceOverlay(lipgloss.Width(content)-lipgloss.Width(overlay), 0, overlay, content)
+}
+
+// truncate shortens a value to the value width, on a single line.
+func (d Debug) truncate(s string) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	if d.valueWidth <= 0 {
+		return s
+	}
+
+	return ansi.Truncate(s, d.valueWidth, "…")
+}
+
+// DebugState implements DebugReporter, reporting the active message box, if any.
+func (m Model) DebugState() []DebugField {
+	if m.box == nil {
+		return []DebugField{{Name: "active", Value: "false"}}
+	}
+
+	fields := []DebugField{
+		{Name: "active", Value: "true"},
+		{Name: "kind", Value: boxKindNames[m.box.kind]},
+		{Name: "message", Value: m.box.text},
+		{Name: "position", Value: fmt.Sprintf("%d,%d", m.xpos, m.ypos)},
+	}
+
+	if m.box.selectedButton < len(m.box.buttons) {
+		selected := strings.Replace(buttonText[m.box.buttons[m.box.selectedButton]], "&", "", 1)
+		fields = append(fields, DebugField{Name: "selected", Value: selected})
+	}
+
+	if m.dx != 0 || m.dy != 0 {
+		fields = append(fields, DebugField{Name: "moved", Value: fmt.Sprintf("%d,%d", m.dx, m.dy)})
+	}
+
+	switch m.box.kind {
+	case promptBox:
+		fields = append(fields, DebugField{Name: "input", Value: m.box.input.Value()})
+	case listBox, checklistBox:
+		fields = append(fields, DebugField{Name: "cursor", Value: strconv.Itoa(m.box.cursor)})
+	}
+
+	return fields
+}
+
+// DebugState implements DebugReporter, reporting the current step and its message box.
+func (s Sequence) DebugState() []DebugField {
+	fields := []DebugField{{Name: "step", Value: fmt.Sprintf("%d/%d", len(s.answers)+1, len(s.steps))}}
+	if !s.IsActive() {
+		fields[0].Value = "-"
+	}
+
+	return append(fields, s.box.DebugState()...)
+}
+
+// Names of the kinds of box, reported by DebugState
+var boxKindNames = map[boxKind]string{
+	plainBox:     "plain",
+	promptBox:    "prompt",
+	listBox:      "list",
+	checklistBox: "checklist",
+	errorBox:     "error",
+	aboutBox:     "about",
+}
@@ -0,0 +1,265 @@
+package messagebox
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// boxKind identifies the content of a message box in addition to its message and buttons.
+type boxKind int
+
+const (
+	plainBox boxKind = iota
+	promptBox
+	listBox
+	checklistBox
+	errorBox
+	aboutBox
+)
+
+// Result is returned in place of a Button by prompt, list and checklist boxes when they are dismissed,
+// carrying the value entered alongside the button that was pressed, so the caller does not need to
+// read it from the model. The value is returned whichever button was pressed.
+//
+//   - NewPrompt returns Result[string] with the entered text.
+//   - NewList returns Result[string] with the chosen item.
+//   - NewChecklist returns Result[[]string] with the checked items, in list order.
+type Result[T any] struct {
+	// Button that dismissed the box
+	Button Button
+
+	// Value entered in the box
+	Value T
+}
+
+// Accepted returns true if the box was dismissed with OK or Yes.
+func (r Result[T]) Accepted() bool {
+	return r.Button&(MB_OK|MB_YES) != 0
+}
+
+// pressed returns the button that dismissed the box.
+func (r Result[T]) pressed() Button {
+	return r.Button
+}
+
+// Navigation keys within lists and checklists
+var (
+	itemUp     = key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up"))
+	itemDown   = key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "down"))
+	itemToggle = key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "check"))
+)
+
+// Recall of earlier entries in prompt boxes
+var (
+	historyPrev = key.NewBinding(key.WithKeys("up"), key.WithHelp("↑", "previous"))
+	historyNext = key.NewBinding(key.WithKeys("down"), key.WithHelp("↓", "next"))
+)
+
+// WithHistory sets earlier entries of a prompt box, oldest first, e.g. the search history of a table.
+// The up key recalls the previous entry, and the down key the next, or the value being entered after the last.
+func WithHistory(entries []string) Option {
+	return func(o *options) {
+		o.history = entries
+	}
+}
+
+// NewPrompt creates a new modal message box with a text input below the message, initially containing value.
+// Enter presses the selected button, which is initially the first, e.g. OK. Other keys are typed into the input,
+// so the buttons are not pressed by their hot keys. When dismissed, a Result[string] is returned.
+func (m Model) NewPrompt(message, value string, boxType Type, opts ...Option) Model {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.SetValue(value)
+	input.Cursor.SetMode(cursor.CursorStatic)
+	input.Focus()
+
+	return m.newBox(message, boxType, &box{kind: promptBox, input: input}, opts)
+}
+
+// NewList creates a new modal message box with a list of items below the message, from which one is chosen
+// with the up and down keys. Initially the item at index selected is chosen.
+// When dismissed, a Result[string] is returned.
+func (m Model) NewList(message string, items []string, selected int, boxType Type, opts ...Option) Model {
+	return m.newBox(message, boxType, &box{
+		kind:   listBox,
+		items:  items,
+		cursor: max(min(selected, len(items)-1), 0),
+	}, opts)
+}
+
+// NewChecklist creates a new modal message box with a list of items below the message, each of which can be
+// checked or unchecked with the space key. Items whose value in checked is true are initially checked.
+// When dismissed, a Result[[]string] is returned.
+func (m Model) NewChecklist(message string, items []string, checked []bool, boxType Type, opts ...Option) Model {
+	b := &box{
+		kind:    checklistBox,
+		items:   items,
+		checked: make([]bool, len(items)),
+	}
+
+	copy(b.checked, checked)
+
+	return m.newBox(message, boxType, b, opts)
+}
+
+// result returns the message sent when the box is dismissed with the given button.
+func (b *box) result(button Button) tea.Msg {
+	switch b.kind {
+	case promptBox:
+		return Result[string]{Button: button, Value: b.input.Value()}
+
+	case listBox:
+		value := ""
+		if b.cursor < len(b.items) {
+			value = b.items[b.cursor]
+		}
+
+		return Result[string]{Button: button, Value: value}
+
+	case checklistBox:
+		value := []string{}
+
+		for i, item := range b.items {
+			if b.checked[i] {
+				value = append(value, item)
+			}
+		}
+
+		return Result[[]string]{Button: button, Value: value}
+	}
+
+	return button
+}
+
+// updateBody processes keys for the content of boxes other than plain message boxes.
+// Returns true, and any command to run, if the key was handled.
+func (m Model) updateBody(msg tea.KeyMsg) (bool, tea.Cmd) {
+	b := m.box
+
+	switch b.kind {
+	case promptBox:
+		switch {
+		case key.Matches(msg, historyPrev) && b.historyPos > 0:
+			if b.historyPos == len(b.history) {
+				b.draft = b.input.Value()
+			}
+
+			b.historyPos--
+			b.recall(b.history[b.historyPos])
+
+		case key.Matches(msg, historyNext) && b.historyPos < len(b.history):
+			b.historyPos++
+
+			if b.historyPos == len(b.history) {
+				b.recall(b.draft)
+			} else {
+				b.recall(b.history[b.historyPos])
+			}
+
+		default:
+			b.input, _ = b.input.Update(msg)
+		}
+
+		return true, nil
+
+	case listBox, checklistBox:
+		switch {
+		case key.Matches(msg, itemUp):
+			b.cursor = max(b.cursor-1, 0)
+			return true, nil
+
+		case key.Matches(msg, itemDown):
+			b.cursor = max(min(b.cursor+1, len(b.items)-1), 0)
+			return true, nil
+
+		case b.kind == checklistBox && key.Matches(msg, itemToggle) && len(b.items) > 0:
+			b.checked[b.cursor] = !b.checked[b.cursor]
+			return true, nil
+		}
+
+	case errorBox:
+		return m.updateDetails(msg), nil
+
+	case aboutBox:
+		return m.updateAbout(msg)
+	}
+
+	return false, nil
+}
+
+// recall replaces the value of a prompt box with an entry from its history.
+func (b *box) recall(value string) {
+	b.input.SetValue(value)
+	b.input.CursorEnd()
+}
+
+// bodyKeyBindings returns the key bindings for the content of boxes other than plain message boxes.
+func (m Model) bodyKeyBindings() []key.Binding {
+	switch m.box.kind {
+	case promptBox:
+		if len(m.box.history) > 0 {
+			return []key.Binding{historyPrev, historyNext}
+		}
+	case listBox:
+		return []key.Binding{itemUp, itemDown}
+	case checklistBox:
+		return []key.Binding{itemUp, itemDown, itemToggle}
+	case errorBox:
+		return m.detailsKeyBindings()
+	case aboutBox:
+		return []key.Binding{copyVersion}
+	}
+
+	return nil
+}
+
+// renderBody renders the content of boxes other than plain message boxes, or returns an empty string for plain boxes.
+func (m Model) renderBody() string {
+	b := m.box
+	left := lipgloss.NewStyle().Width(m.width - 2).PaddingLeft(1)
+
+	switch b.kind {
+	case promptBox:
+		return left.Render(b.input.View())
+
+	case listBox, checklistBox:
+		lines := make([]string, len(b.items))
+
+		for i, item := range b.items {
+			prefix := "  "
+			if i == b.cursor {
+				prefix = "> "
+			}
+
+			if b.kind == checklistBox {
+				if b.checked[i] {
+					prefix += "[x] "
+				} else {
+					prefix += "[ ] "
+				}
+			}
+
+			line := prefix + item
+			if i == b.cursor {
+				line = m.styles.SelectedButton.Render(line)
+			}
+
+			lines[i] = line
+		}
+
+		return left.Render(strings.Join(lines, "\n"))
+
+	case errorBox:
+		return m.renderDetails()
+
+	case aboutBox:
+		return m.renderAbout()
+	}
+
+	return ""
+}
@@ -0,0 +1,27 @@
+package messagebox
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// DefaultDimStyle returns the style used by default to dim the content behind an overlay.
+func DefaultDimStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Faint(true).Foreground(lipgloss.Color("240"))
+}
+
+// Dim removes the colours and other styling from content and renders it in the given style, e.g. DefaultDimStyle,
+// so that an overlay placed on it visually dominates. The width and height of the content are unchanged.
+func Dim(content string, style lipgloss.Style) string {
+	lines := strings.Split(ansi.Strip(content), "\n")
+
+	for i, l := range lines {
+		if l != "" {
+			lines[i] = style.Render(l)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,49 @@
+package messagebox
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// dragState tracks the dragging of a box by its top border with the mouse.
+type dragState struct {
+	// Where the box was last rendered, and where it would have been without being moved
+	rect  Rect
+	baseX int
+	baseY int
+
+	// Whether the box is being dragged, and the column of the border that was grabbed
+	active bool
+	grabX  int
+}
+
+// WithMouseOrigin sets the position on the screen of the top left of the content passed to Render, so that mouse
+// positions can be related to the box for dragging. By default, the content is assumed to fill the screen.
+func WithMouseOrigin(x, y int) Option {
+	return func(o *options) {
+		o.mouseX = x
+		o.mouseY = y
+	}
+}
+
+// updateDrag lets the user reposition the box by dragging its top border with the left mouse button,
+// when mouse support is enabled in the program, e.g. by tea.WithMouseCellMotion.
+func (m *Model) updateDrag(msg tea.MouseMsg) {
+	d := &m.box.drag
+	x, y := msg.X-m.mouseX, msg.Y-m.mouseY
+
+	switch {
+	case msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft:
+		r := d.rect
+		if y == r.Y && x >= r.X && x < r.X+r.Width {
+			d.active = true
+			d.grabX = x - r.X
+		}
+
+	case msg.Action == tea.MouseActionMotion && d.active:
+		m.dx = x - d.grabX - d.baseX
+		m.dy = y - d.baseY
+
+	case msg.Action == tea.MouseActionRelease:
+		d.active = false
+	}
+}
@@ -0,0 +1,132 @@
+package messagebox
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// Maximum number of lines of details shown at once. Longer details are scrolled.
+const detailsHeight = 8
+
+// Keys of error boxes
+var (
+	detailsToggle = key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "details"))
+	detailsScroll = key.NewBinding(key.WithKeys("up", "down", "pgup", "pgdown"), key.WithHelp("↑/↓", "scroll details"))
+)
+
+// WithDetails sets the details shown by an error box (see Error) when expanded, e.g. a stack trace.
+// By default, the details are the chain of wrapped errors.
+func WithDetails(details string) Option {
+	return func(o *options) {
+		o.details = details
+	}
+}
+
+// Error creates a new modal message box reporting an error, with an OK button. The box shows a concise message,
+// and a Details toggle, bound to d, which expands a scrollable section containing the details. These are set by
+// WithDetails, or are otherwise the chain of errors wrapped by err, one per line. If there are no details,
+// the toggle is not shown. When dismissed, MB_OK is returned as for New.
+//
+// The message is the first line of the error's text, or if err wraps other errors, the outermost context
+// followed by the root cause, e.g. "load config: no such file".
+func (m Model) Error(err error, opts ...Option) Model {
+	chain := errorChain(err)
+	message, _, _ := strings.Cut(err.Error(), "\n")
+
+	if chain != nil {
+		message = chain[0] + ": " + chain[len(chain)-1]
+	}
+
+	o := &options{details: strings.Join(chain, "\n")}
+
+	for _, opt := range append(m.defaults, opts...) {
+		opt(o)
+	}
+
+	return m.newBox(message, OK, &box{kind: errorBox, details: strings.TrimSpace(o.details)}, opts)
+}
+
+// errorChain returns the text of err and each error it wraps, without the text of the wrapped error,
+// outermost first, or nil if err does not wrap another error.
+func errorChain(err error) []string {
+	if errors.Unwrap(err) == nil {
+		return nil
+	}
+
+	lines := []string{}
+
+	for ; err != nil; err = errors.Unwrap(err) {
+		text := err.Error()
+
+		// Remove the text of the wrapped error, which is the next in the chain
+		if inner := errors.Unwrap(err); inner != nil {
+			text = strings.TrimSuffix(strings.TrimSuffix(text, inner.Error()), ": ")
+		}
+
+		lines = append(lines, text)
+	}
+
+	return lines
+}
+
+// newDetailsViewport creates the scrollable section of an error box.
+func (m Model) newDetailsViewport() viewport.Model {
+	text := runewidth.Wrap(m.box.details, m.width-4)
+	vp := viewport.New(m.width-4, min(strings.Count(text, "\n")+1, detailsHeight))
+	vp.SetContent(text)
+
+	return vp
+}
+
+// updateDetails processes keys for the details of an error box. Returns true if the key was handled.
+func (m Model) updateDetails(msg tea.KeyMsg) bool {
+	b := m.box
+
+	switch {
+	case b.details == "":
+		return false
+
+	case key.Matches(msg, detailsToggle):
+		b.expanded = !b.expanded
+		return true
+
+	case b.expanded && key.Matches(msg, detailsScroll):
+		b.detailsView, _ = b.detailsView.Update(msg)
+		return true
+	}
+
+	return false
+}
+
+// detailsKeyBindings returns the key bindings of an error box.
+func (m Model) detailsKeyBindings() []key.Binding {
+	switch {
+	case m.box.details == "":
+		return nil
+	case m.box.expanded:
+		return []key.Binding{detailsToggle, detailsScroll}
+	}
+
+	return []key.Binding{detailsToggle}
+}
+
+// renderDetails renders the details toggle of an error box, and the details if expanded.
+func (m Model) renderDetails() string {
+	if m.box.details == "" {
+		return ""
+	}
+
+	left := lipgloss.NewStyle().Width(m.width - 2).PaddingLeft(1)
+
+	if !m.box.expanded {
+		return left.Render(m.styles.Details.Render("▸ Details (d)"))
+	}
+
+	return left.Render(m.styles.Details.Render("▾ Details (d)") + "\n" + m.styles.Details.Render(m.box.detailsView.View()))
+}
@@ -5,15 +5,18 @@ package messagebox
 // Activate by calling the MessageBox function from the Update method of the owning control.
 // When a button is pressed, and the message box is dismissed, a value of type Button is returned
 // wrapped in a tea.Cmd so that it can ben handled in the next call to the owning control's Update method.
+// Prompt, list and checklist boxes instead return a Result carrying the button and the value entered.
 //
 // The control ownning the message box should call messageBox.Render as the last step in that control's View method
 // to overlay the message box.
 
 import (
+	"io"
 	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -21,13 +24,25 @@ import (
 )
 
 type options struct {
-	xpos  int
-	ypos  int
-	width int
-	style *Styles
+	xpos       int
+	ypos       int
+	anchor     anchor
+	centered   bool
+	dim        bool
+	width      int
+	style      *Styles
+	details    string
+	bell       bool
+	bellOutput io.Writer
+	title      string
+	adjustable bool
+	mouseX     int
+	mouseY     int
+	history    []string
 }
 
-type optionFunc func(*options)
+// Option sets options in New.
+type Option func(*options)
 
 // Button represents a button in the message box
 type Button int
@@ -90,7 +105,9 @@ func (b Button) keyBinding() key.Binding {
 		}
 
 		return keys
-	}()...))
+	}()...),
+		key.WithHelp(strings.ToLower(highlight), strings.ToLower(strings.Replace(buttonText[b], "&", "", 1))),
+	)
 
 	return binding
 }
@@ -126,6 +143,8 @@ type Styles struct {
 	Button         lipgloss.Style
 	SelectedButton lipgloss.Style
 	HotKey         lipgloss.Color // Text color of hotkey. Hotkey will also be undelined
+	Details        lipgloss.Style // Details of an error box
+	Dim            lipgloss.Style // Content behind the box, if dimmed by WithDimBackground
 }
 
 // DefaultStyles returns a set of default style definitions for this table.
@@ -140,15 +159,45 @@ func DefaultStyles() Styles {
 		SelectedButton: lipgloss.NewStyle().
 			Foreground(lipgloss.Color(buttonFg)).
 			Background(lipgloss.Color(buttonSelBg)),
-		HotKey: lipgloss.Color(buttonHotkey),
+		HotKey:  lipgloss.Color(buttonHotkey),
+		Details: lipgloss.NewStyle().Faint(true),
+		Dim:     DefaultDimStyle(),
 	}
 }
 
 // box manages an active message box
 type box struct {
+	// Message as given, and wrapped to the width of the box
+	text           string
 	message        string
 	buttons        []Button
 	selectedButton int
+
+	// Kind of box, and the value being entered for prompt, list and checklist boxes
+	kind    boxKind
+	input   textinput.Model
+	items   []string
+	cursor  int
+	checked []bool
+
+	// Earlier entries recalled in a prompt box, the index of the entry recalled, or len(history) if none,
+	// and the value entered before recalling an entry
+	history    []string
+	historyPos int
+	draft      string
+
+	// Details of an error box, and whether they are shown
+	details     string
+	expanded    bool
+	detailsView viewport.Model
+
+	// Dragging of the box with the mouse
+	drag dragState
+
+	// Application described by an about box, and the result of copying its version
+	about   AboutInfo
+	copied  bool
+	copyErr error
 }
 
 // Model is the bubbletea model for message box.
@@ -165,32 +214,74 @@ type Model struct {
 	// Y position in cursor coords
 	ypos int
 
+	// Area the box is attached to, if set by WithRowAnchor or WithRelativeTo
+	anchor anchor
+
+	// Whether the box is centered over the content
+	centered bool
+
+	// Whether the content behind the box is dimmed
+	dim bool
+
+	// Attention requested when the box was created, see AttentionCmd
+	bell       bool
+	bellOutput io.Writer
+	title      string
+
+	// Whether the box can be moved and resized with the keyboard, and how far it has been moved
+	adjustable bool
+	dx, dy     int
+
+	// Position on the screen of the content the box is rendered over, for dragging with the mouse
+	mouseX, mouseY int
+
 	// Width of box
 	width int
 
 	// Message box styling
 	styles Styles
+
+	// Options applied before those passed to the constructors, e.g. by a Sequence
+	defaults []Option
 }
 
 // WithPosition sets the position of the top left of the messagebox in
 // columns from the left (x), and rows from the top (y).
-func WithPosition(x, y int) optionFunc {
+func WithPosition(x, y int) Option {
 	return func(o *options) {
 		o.xpos = x
 		o.ypos = y
+		o.anchor = anchor{}
+		o.centered = false
+	}
+}
+
+// WithDimBackground dims the content behind the message box, so that the box visually dominates.
+// The content is rendered in the Dim style.
+func WithDimBackground() Option {
+	return func(o *options) {
+		o.dim = true
+	}
+}
+
+// WithCentered centers the message box over the content passed to Render, whatever its size.
+func WithCentered() Option {
+	return func(o *options) {
+		o.centered = true
+		o.anchor = anchor{}
 	}
 }
 
 // WithWidth sets the width of the message box. This will not be narrower than the space required to render the buttons.
 // Height is computed from the message text.
-func WithWidth(w int) optionFunc {
+func WithWidth(w int) Option {
 	return func(o *options) {
 		o.width = w
 	}
 }
 
 // WithStyle overrides the default style for the message box
-func WithStyle(s Styles) optionFunc {
+func WithStyle(s Styles) Option {
 	return func(o *options) {
 		o.style = &s
 	}
@@ -200,16 +291,34 @@ func WithStyle(s Styles) optionFunc {
 // You would normally do this in the parent control's Update method in response to a key message.
 //
 // While the message box in in an active state, you should direct all UI messages to its update method.
-func (m Model) New(message string, boxType Type, opts ...optionFunc) Model {
+func (m Model) New(message string, boxType Type, opts ...Option) Model {
+	return m.newBox(message, boxType, &box{}, opts)
+}
+
+// newBox activates a message box, with the given content for boxes other than plain message boxes.
+func (m Model) newBox(message string, boxType Type, content *box, opts []Option) Model {
 
 	o := &options{}
 
+	for _, opt := range m.defaults {
+		opt(o)
+	}
+
 	for _, opt := range opts {
 		opt(o)
 	}
 
 	m.xpos = o.xpos
 	m.ypos = o.ypos
+	m.anchor = o.anchor
+	m.centered = o.centered
+	m.dim = o.dim
+	m.bell = o.bell
+	m.bellOutput = o.bellOutput
+	m.title = o.title
+	m.adjustable = o.adjustable
+	m.dx, m.dy = 0, 0
+	m.mouseX, m.mouseY = o.mouseX, o.mouseY
 
 	if o.style == nil {
 		m.styles = DefaultStyles()
@@ -226,9 +335,13 @@ func (m Model) New(message string, boxType Type, opts ...optionFunc) Model {
 		}
 	}
 
-	if len(buttons) == 1 {
+	switch {
+	case len(buttons) == 1:
 		selectedButton = 0
-	} else {
+	case content.kind != plainBox:
+		// Enter accepts the value
+		selectedButton = 0
+	default:
 		selectedButton = slices.Index(buttons, MB_CANCEL)
 
 		if selectedButton == -1 {
@@ -240,10 +353,14 @@ func (m Model) New(message string, boxType Type, opts ...optionFunc) Model {
 		}
 	}
 
-	m.box = &box{
-		buttons:        buttons,
-		selectedButton: selectedButton,
+	content.buttons = buttons
+	content.selectedButton = selectedButton
+
+	if content.kind == promptBox {
+		content.history = o.history
+		content.historyPos = len(o.history)
 	}
+	m.box = content
 
 	m.width = defaultViewPortWidth
 
@@ -256,12 +373,29 @@ func (m Model) New(message string, boxType Type, opts ...optionFunc) Model {
 		m.width = max(buttonsWidth, o.width)
 	}
 
-	m.box.message = runewidth.Wrap(strings.TrimSpace(message), m.width-2)
-	m.viewport = viewport.New(m.width, strings.Count(m.box.message, "\n")+3)
+	m.box.text = strings.TrimSpace(message)
+	m.layout()
 
 	return m
 }
 
+// layout wraps the message to the width of the box, and sizes the box's content.
+func (m *Model) layout() {
+	m.box.message = runewidth.Wrap(m.box.text, m.width-2)
+	m.box.input.Width = m.width - 6
+
+	if m.box.kind == errorBox {
+		m.box.detailsView = m.newDetailsViewport()
+	}
+
+	height := strings.Count(m.box.message, "\n") + 3
+	if body := m.renderBody(); body != "" {
+		height += lipgloss.Height(body) + 1
+	}
+
+	m.viewport = viewport.New(m.width, height)
+}
+
 // Init satisfies the BubbleTea Model interface.
 // Does nothing here.
 func (m Model) Init() tea.Cmd {
@@ -277,6 +411,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 
+	case tea.MouseMsg:
+
+		m.updateDrag(msg)
+
+	case CopiedMsg:
+
+		if m.box.kind == aboutBox {
+			m.box.copied = msg.Err == nil
+			m.box.copyErr = msg.Err
+		}
+
 	case tea.KeyMsg:
 
 		switch msg.Type {
@@ -284,68 +429,65 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case tea.KeyEsc:
 
 			// Return the button most suited to "take no action"
-			buttonToReturn := func() Button {
-				switch {
-				case slices.Contains(m.box.buttons, MB_CANCEL):
-					return MB_CANCEL
+			switch {
+			case slices.Contains(m.box.buttons, MB_CANCEL):
+				return m.dismiss(MB_CANCEL)
 
-				case slices.Contains(m.box.buttons, MB_NO):
-					return MB_NO
+			case slices.Contains(m.box.buttons, MB_NO):
+				return m.dismiss(MB_NO)
 
-				default:
-					return MB_CANCEL
-				}
+			default:
+				return m.dismiss(MB_CANCEL)
 			}
 
-			// Dismiss message box
-			m.box = nil
-
-			return m, func() tea.Cmd {
-				return func() tea.Msg {
-					// Return pressed button as message for caller's model update
-					return buttonToReturn
-				}
-			}()
-
-		case tea.KeyCtrlI, tea.KeyRight:
+		case tea.KeyCtrlI:
 
 			// Forward tab between buttons
 			m.box.selectedButton = (m.box.selectedButton + 1) % len(m.box.buttons)
 			return m, nil
 
-		case tea.KeyShiftTab, tea.KeyLeft:
+		case tea.KeyShiftTab:
 
 			// Reverse tab between buttons
 			m.box.selectedButton = (len(m.box.buttons) + m.box.selectedButton - 1) % len(m.box.buttons)
 			return m, nil
 
-		case tea.KeySpace, tea.KeyEnter:
+		case tea.KeyEnter:
 
-			// Get selected button before dismissal
-			selectedButton := m.box.buttons[m.box.selectedButton]
+			return m.dismiss(m.box.buttons[m.box.selectedButton])
+		}
 
-			// Dismiss message box
-			m.box = nil
+		if m.adjustable && m.adjust(msg) {
+			return m, nil
+		}
 
-			return m, func() tea.Cmd {
-				return func() tea.Msg {
-					// Return pressed button as message for caller's model update
-					return selectedButton
-				}
-			}()
+		if handled, cmd := m.updateBody(msg); handled {
+			return m, cmd
+		}
+
+		switch msg.Type {
+
+		case tea.KeyRight:
+
+			// Forward between buttons
+			m.box.selectedButton = (m.box.selectedButton + 1) % len(m.box.buttons)
+			return m, nil
+
+		case tea.KeyLeft:
+
+			// Reverse between buttons
+			m.box.selectedButton = (len(m.box.buttons) + m.box.selectedButton - 1) % len(m.box.buttons)
+			return m, nil
+
+		case tea.KeySpace:
+
+			return m.dismiss(m.box.buttons[m.box.selectedButton])
 
 		default:
 			// If a bound key is pressed, return that key's button and dismiss message box
 			for _, b := range m.box.buttons {
 				if key.Matches(msg, b.keyBinding()) {
-					// Dismiss message box
-					m.box = nil
-					return m, func() tea.Cmd {
-						return func() tea.Msg {
-							// Return pressed button as message for caller's model update
-							return b
-						}
-					}()
+					return m.dismiss(b)
 				}
 			}
 		}
@@ -354,6 +496,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// dismiss closes the message box, returning a command that sends the pressed button,
+// or for prompt, list and checklist boxes, a Result carrying the button and value,
+// as a message for the caller's model update.
+func (m Model) dismiss(b Button) (tea.Model, tea.Cmd) {
+	result := m.box.result(b)
+
+	// Dismiss message box
+	m.box = nil
+
+	return m, func() tea.Msg {
+		return result
+	}
+}
+
 // View doesn't do anything, and it should never be called directly
 // Implemented as part of BubbleTea Model interface
 func (m Model) View() string {
@@ -371,11 +527,34 @@ func (m Model) Render(content string) string {
 
 	center := lipgloss.NewStyle().Width(m.width - 2).Align(lipgloss.Center)
 
-	m.viewport.SetContent(
-		center.Render(m.box.message) + "\n\n" + center.Render(m.renderButtons()),
-	)
+	inner := center.Render(m.box.message) + "\n\n"
 
-	return PlaceOverlay(m.xpos, m.ypos, m.styles.Border.Render(m.viewport.View()), content)
+	if body := m.renderBody(); body != "" {
+		inner += body + "\n\n"
+	}
+
+	inner += center.Render(m.renderButtons())
+
+	// The height of the content changes when the details of an error box are expanded
+	m.viewport.Height = lipgloss.Height(inner)
+	m.viewport.SetContent(inner)
+
+	box := m.styles.Border.Render(m.viewport.View())
+	boxWidth, boxHeight := lipgloss.Width(box), lipgloss.Height(box)
+	contentWidth, contentHeight := lipgloss.Width(content), lipgloss.Height(content)
+	baseX, baseY := m.position(boxWidth, boxHeight, contentWidth, contentHeight)
+	x := clamp(baseX+m.dx, 0, max(contentWidth-boxWidth, 0))
+	y := clamp(baseY+m.dy, 0, max(contentHeight-boxHeight, 0))
+
+	// Record where the box is, for dragging. The box is shared by copies of the model.
+	m.box.drag.rect = Rect{X: x, Y: y, Width: boxWidth, Height: boxHeight}
+	m.box.drag.baseX, m.box.drag.baseY = baseX, baseY
+
+	if m.dim {
+		content = Dim(content, m.styles.Dim)
+	}
+
+	return PlaceOverlay(x, y, box, content)
 }
 
 // IsActive returns true if a message box is currently being displayed
@@ -383,6 +562,36 @@ func (m Model) IsActive() bool {
 	return m.box != nil
 }
 
+// KeyBindings returns the key bindings of the active message box, with help text,
+// for including in the owning control's help. Returns nil if no message box is active.
+func (m Model) KeyBindings() []key.Binding {
+	if m.box == nil {
+		return nil
+	}
+
+	bindings := []key.Binding{
+		key.NewBinding(key.WithKeys("tab", "right", "shift+tab", "left"), key.WithHelp("tab", "next button")),
+		key.NewBinding(key.WithKeys("enter", " "), key.WithHelp("enter", "press button")),
+	}
+
+	bindings = append(bindings, m.bodyKeyBindings()...)
+
+	if m.adjustable {
+		bindings = append(bindings, moveKeys, resizeKeys)
+	}
+
+	if m.box.kind == promptBox {
+		// Letters are typed into the prompt, so buttons are only pressed with enter and esc
+		return bindings
+	}
+
+	for _, b := range m.box.buttons {
+		bindings = append(bindings, b.keyBinding())
+	}
+
+	return bindings
+}
+
 // Renders the buttons
 func (m Model) renderButtons() string {
 	bs := []string{}
@@ -62,19 +62,19 @@ func getLines(s string) (lines []string, widest int) {
 }
 
 // PlaceOverlay places fg on top of bg.
+//
+// The position is clamped so that fg lies within the bounds of bg. If fg is larger than bg,
+// it is placed at the top and/or left of bg and clipped to fit, so that the result is never
+// larger than bg.
 func PlaceOverlay(x, y int, fg, bg string, opts ...WhitespaceOption) string {
 	fgLines, fgWidth := getLines(fg)
 	bgLines, bgWidth := getLines(bg)
 	bgHeight := len(bgLines)
-	fgHeight := len(fgLines)
 
-	if fgWidth >= bgWidth && fgHeight >= bgHeight {
-		// FIXME: return fg or bg?
-		return fg
-	}
-	// TODO: allow placement outside of the bg box?
-	x = clamp(x, 0, bgWidth-fgWidth)
-	y = clamp(y, 0, bgHeight-fgHeight)
+	x = clamp(x, 0, max(bgWidth-fgWidth, 0))
+	y = clamp(y, 0, max(bgHeight-len(fgLines), 0))
+	fgLines = clipLines(fgLines, bgWidth-x, bgHeight-y)
+	fgHeight := len(fgLines)
 
 	ws := &whitespace{}
 	for _, opt := range opts {
@@ -119,6 +119,25 @@ func PlaceOverlay(x, y int, fg, bg string, opts ...WhitespaceOption) string {
 	return b.String()
 }
 
+// clipLines truncates lines to the given width and number of lines.
+func clipLines(lines []string, width, height int) []string {
+	if height < len(lines) {
+		lines = lines[:max(height, 0)]
+	}
+
+	clipped := make([]string, len(lines))
+
+	for i, l := range lines {
+		if ansi.PrintableRuneWidth(l) > width {
+			l = truncate.String(l, uint(max(width, 0)))
+		}
+
+		clipped[i] = l
+	}
+
+	return clipped
+}
+
 // cutLeft cuts printable characters from the left.
 // This function is heavily based on muesli's ansi and truncate packages.
 func cutLeft(s string, cutWidth int) string {
@@ -0,0 +1,85 @@
+package messagebox
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaceOverlay(t *testing.T) {
+	bg := strings.Join([]string{".....", ".....", "....."}, "\n")
+
+	tests := []struct {
+		name     string
+		x, y     int
+		fg       string
+		expected []string
+	}{
+		{
+			name:     "inside",
+			x:        1,
+			y:        1,
+			fg:       "ab",
+			expected: []string{".....", ".ab..", "....."},
+		},
+		{
+			name:     "clamped to the bottom right",
+			x:        10,
+			y:        10,
+			fg:       "ab\ncd",
+			expected: []string{".....", "...ab", "...cd"},
+		},
+		{
+			name:     "clamped to the top left",
+			x:        -3,
+			y:        -1,
+			fg:       "ab",
+			expected: []string{"ab...", ".....", "....."},
+		},
+		{
+			name:     "wider than the background",
+			x:        2,
+			y:        1,
+			fg:       "abcdefg",
+			expected: []string{".....", "abcde", "....."},
+		},
+		{
+			name:     "taller than the background",
+			x:        1,
+			y:        1,
+			fg:       "a\nb\nc\nd",
+			expected: []string{".a...", ".b...", ".c..."},
+		},
+		{
+			name:     "larger than the background",
+			x:        3,
+			y:        3,
+			fg:       "abcdefg\nhijklmn\nopqrstu\nvwxyz12",
+			expected: []string{"abcde", "hijkl", "opqrs"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := PlaceOverlay(test.x, test.y, test.fg, bg)
+			require.Equal(t, test.expected, strings.Split(got, "\n"))
+
+			for _, l := range strings.Split(got, "\n") {
+				require.Equal(t, 5, ansi.StringWidth(l), "the result is the size of the background")
+			}
+		})
+	}
+}
+
+func TestPlaceOverlayStyledBackground(t *testing.T) {
+	bg := "\x1b[31m.....\x1b[0m\n\x1b[31m.....\x1b[0m"
+
+	got := PlaceOverlay(1, 0, "ab", bg)
+	lines := strings.Split(got, "\n")
+
+	require.Len(t, lines, 2)
+	require.Equal(t, ".ab..", ansi.Strip(lines[0]))
+	require.Equal(t, "\x1b[31m.....\x1b[0m", lines[1], "lines without the overlay are unchanged")
+}
@@ -0,0 +1,164 @@
+package messagebox
+
+import (
+	"sync/atomic"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Last assigned sequence ID
+var lastSequenceID int64
+
+// Step creates the message box for one step of a Sequence, given the answers to the previous steps.
+// Call one of the constructors of mb, e.g. mb.NewPrompt, and return the result. To skip the step,
+// e.g. when it does not apply to earlier answers, return mb unchanged; its answer is then nil.
+type Step func(mb Model, answers []tea.Msg) Model
+
+// SequenceDoneMsg is sent when all steps of a sequence have been answered.
+type SequenceDoneMsg struct {
+	// ID of the sequence that sent the message
+	ID int
+
+	// Answer to each step, in order. Each is the message the step's box returns when dismissed,
+	// i.e. a Button or a Result.
+	Answers []tea.Msg
+}
+
+// SequenceCancelledMsg is sent when a step of a sequence is dismissed with Cancel. The remaining steps are not shown.
+type SequenceCancelledMsg struct {
+	// ID of the sequence that sent the message
+	ID int
+
+	// Answers to the steps up to and including the cancelled step
+	Answers []tea.Msg
+}
+
+// answerMsg carries the answer to the current step back to the sequence.
+type answerMsg struct {
+	id     int
+	answer tea.Msg
+}
+
+// Sequence runs message boxes one after another, e.g. prompt for a name, then pick a type, then confirm,
+// passing the answers so far to each step, and stopping if any step is cancelled. This avoids hand-written
+// state machines in the owning control's Update method for multi-question flows.
+//
+// As with a single message box, while the sequence is active direct all UI messages to its Update method,
+// and call Render as the last step in the owning control's View method.
+type Sequence struct {
+	id      int
+	steps   []Step
+	answers []tea.Msg
+	box     Model
+	opts    []Option
+}
+
+// NewSequence creates a sequence of the given steps. The options, e.g. position and style, are applied
+// to the box of each step, before any options passed by the step. Call Start to show the first step.
+func NewSequence(steps []Step, opts ...Option) Sequence {
+	return Sequence{
+		id:    int(atomic.AddInt64(&lastSequenceID, 1)),
+		steps: steps,
+		opts:  opts,
+	}
+}
+
+// ID returns the unique ID of the sequence, which identifies it in the messages it sends.
+func (s Sequence) ID() int {
+	return s.id
+}
+
+// Start shows the first step of the sequence, discarding any previous answers. The returned command
+// sends SequenceDoneMsg immediately if every step is skipped.
+func (s Sequence) Start() (Sequence, tea.Cmd) {
+	s.answers = nil
+	return s.next()
+}
+
+// Update processes messages for the current step, moving to the next step when it is answered.
+func (s Sequence) Update(msg tea.Msg) (Sequence, tea.Cmd) {
+	if msg, ok := msg.(answerMsg); ok {
+		if msg.id != s.id {
+			return s, nil
+		}
+
+		s.answers = append(s.answers, msg.answer)
+
+		if answerButton(msg.answer) == MB_CANCEL {
+			answers := s.answers
+			id := s.id
+
+			return s, func() tea.Msg {
+				return SequenceCancelledMsg{ID: id, Answers: answers}
+			}
+		}
+
+		return s.next()
+	}
+
+	if !s.box.IsActive() {
+		return s, nil
+	}
+
+	box, cmd := s.box.Update(msg)
+	s.box = box.(Model)
+
+	if cmd == nil || s.box.IsActive() {
+		return s, cmd
+	}
+
+	// The step was answered. Route the answer back to the sequence.
+	id := s.id
+
+	return s, func() tea.Msg {
+		return answerMsg{id: id, answer: cmd()}
+	}
+}
+
+// next shows the next step that is not skipped, or completes the sequence.
+func (s Sequence) next() (Sequence, tea.Cmd) {
+	for len(s.answers) < len(s.steps) {
+		s.box = s.steps[len(s.answers)](Model{defaults: s.opts}, s.answers)
+
+		if s.box.IsActive() {
+			return s, nil
+		}
+
+		s.answers = append(s.answers, nil)
+	}
+
+	answers := s.answers
+	id := s.id
+
+	return s, func() tea.Msg {
+		return SequenceDoneMsg{ID: id, Answers: answers}
+	}
+}
+
+// Render overlays the box of the current step on the content.
+func (s Sequence) Render(content string) string {
+	return s.box.Render(content)
+}
+
+// IsActive returns true if a step of the sequence is being displayed.
+func (s Sequence) IsActive() bool {
+	return s.box.IsActive()
+}
+
+// KeyBindings returns the key bindings of the current step, for including in the owning control's help.
+func (s Sequence) KeyBindings() []key.Binding {
+	return s.box.KeyBindings()
+}
+
+// answerButton returns the button that dismissed a box, given the message it returned.
+func answerButton(answer tea.Msg) Button {
+	switch a := answer.(type) {
+	case Button:
+		return a
+	case interface{ pressed() Button }:
+		return a.pressed()
+	}
+
+	return 0
+}
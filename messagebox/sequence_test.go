@@ -0,0 +1,155 @@
+package messagebox
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+)
+
+// runSequence starts the sequence and sends it the keys, following the commands returned as Bubble Tea would,
+// and returns the message the sequence finished with, or nil if it is still active.
+func runSequence(t *testing.T, s Sequence, keys []tea.KeyMsg) tea.Msg {
+	t.Helper()
+
+	s, cmd := s.Start()
+	keys = append([]tea.KeyMsg(nil), keys...)
+
+	for {
+		for cmd != nil {
+			msg := cmd()
+
+			switch msg.(type) {
+			case SequenceDoneMsg, SequenceCancelledMsg:
+				return msg
+			}
+
+			s, cmd = s.Update(msg)
+		}
+
+		if len(keys) == 0 {
+			require.True(t, s.IsActive())
+			return nil
+		}
+
+		s, cmd = s.Update(keys[0])
+		keys = keys[1:]
+	}
+}
+
+func TestSequence(t *testing.T) {
+	runes := func(s string) tea.KeyMsg {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	}
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	esc := tea.KeyMsg{Type: tea.KeyEsc}
+	down := tea.KeyMsg{Type: tea.KeyDown}
+
+	steps := []Step{
+		func(mb Model, _ []tea.Msg) Model {
+			return mb.NewPrompt("Name?", "", OK_CANCEL)
+		},
+		func(mb Model, answers []tea.Msg) Model {
+			// Only asked for names other than "x"
+			if answers[0].(Result[string]).Value == "x" {
+				return mb
+			}
+
+			return mb.NewList("Type?", []string{"biscuit", "cake"}, 0, OK_CANCEL)
+		},
+		func(mb Model, _ []tea.Msg) Model {
+			return mb.New("Save?", YES_NO)
+		},
+	}
+
+	tests := []struct {
+		name      string
+		keys      []tea.KeyMsg
+		cancelled bool
+		answers   []tea.Msg
+	}{
+		{
+			name: "answered",
+			keys: []tea.KeyMsg{runes("tim"), enter, down, enter, runes("y")},
+			answers: []tea.Msg{
+				Result[string]{Button: MB_OK, Value: "tim"},
+				Result[string]{Button: MB_OK, Value: "cake"},
+				MB_YES,
+			},
+		},
+		{
+			name: "no is an answer",
+			keys: []tea.KeyMsg{runes("tim"), enter, enter, runes("n")},
+			answers: []tea.Msg{
+				Result[string]{Button: MB_OK, Value: "tim"},
+				Result[string]{Button: MB_OK, Value: "biscuit"},
+				MB_NO,
+			},
+		},
+		{
+			name: "step skipped",
+			keys: []tea.KeyMsg{runes("x"), enter, runes("y")},
+			answers: []tea.Msg{
+				Result[string]{Button: MB_OK, Value: "x"},
+				nil,
+				MB_YES,
+			},
+		},
+		{
+			name:      "cancelled",
+			keys:      []tea.KeyMsg{runes("tim"), enter, esc},
+			cancelled: true,
+			answers: []tea.Msg{
+				Result[string]{Button: MB_OK, Value: "tim"},
+				Result[string]{Button: MB_CANCEL, Value: "biscuit"},
+			},
+		},
+		{
+			name: "unanswered",
+			keys: []tea.KeyMsg{runes("tim"), enter},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := NewSequence(steps)
+
+			var expected tea.Msg
+			switch {
+			case test.cancelled:
+				expected = SequenceCancelledMsg{ID: s.ID(), Answers: test.answers}
+			case test.answers != nil:
+				expected = SequenceDoneMsg{ID: s.ID(), Answers: test.answers}
+			}
+
+			require.Equal(t, expected, runSequence(t, s, test.keys))
+		})
+	}
+}
+
+func TestSequenceAllStepsSkipped(t *testing.T) {
+	skip := func(mb Model, _ []tea.Msg) Model {
+		return mb
+	}
+
+	s := NewSequence([]Step{skip, skip})
+	s, cmd := s.Start()
+
+	require.False(t, s.IsActive())
+	require.Equal(t, SequenceDoneMsg{ID: s.ID(), Answers: []tea.Msg{nil, nil}}, cmd())
+}
+
+func TestSequenceIgnoresOtherSequences(t *testing.T) {
+	ask := func(mb Model, _ []tea.Msg) Model {
+		return mb.New("Save?", YES_NO)
+	}
+
+	s, _ := NewSequence([]Step{ask}).Start()
+	other, _ := NewSequence([]Step{ask}).Start()
+
+	_, cmd := other.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	s, cmd = s.Update(cmd())
+
+	require.Nil(t, cmd)
+	require.True(t, s.IsActive())
+}
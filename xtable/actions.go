@@ -0,0 +1,146 @@
+package xtable
+
+import (
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fireflycons/bubbles/messagebox"
+)
+
+// ActionHandler performs an action on the selected row. button is the button that dismissed the
+// confirmation message box, or messagebox.MB_OK if the action has no confirmation.
+// The handler may modify the table, e.g. to remove the row.
+type ActionHandler func(m *Model, r Row, button messagebox.Button) tea.Cmd
+
+// Action is a named operation on the selected row, launched by a key binding.
+type Action struct {
+	// Name of the action
+	Name string
+
+	// Key(s) to launch the action. The binding's help is included in the table's help.
+	Key key.Binding
+
+	// If set, returns a message to display in a message box for the selected row.
+	// The handler is called when the message box is dismissed.
+	// If not set, the handler is called immediately.
+	Confirm func(Row) string
+
+	// Type of the confirmation message box. If zero, messagebox.YES_NO is used.
+	ConfirmType messagebox.Type
+
+	// Options for the confirmation message box. By default it is displayed below the selected row.
+	ConfirmOptions []messagebox.Option
+
+	// Performs the action
+	Handler ActionHandler
+}
+
+// WithActions registers actions that are launched by their key bindings when the table is focused.
+// Action key bindings take precedence over the table's own key bindings.
+//
+// While a confirmation message box is displayed, the table's Update must receive all messages.
+// Use ModalActive to determine this.
+func WithActions(actions ...Action) Option {
+	return func(m *Model) {
+		m.actions = append(m.actions, actions...)
+	}
+}
+
+// Actions returns the registered actions.
+func (m Model) Actions() []Action {
+	return m.actions
+}
+
+// HelpKeyMap returns a help.KeyMap containing the table's key bindings and those of the registered actions
+// and bulk actions.
+// While a confirmation message box or menu is displayed, it contains the message box's key bindings instead.
+func (m Model) HelpKeyMap() help.KeyMap {
+	return helpKeyMap{
+		KeyMap:  m.KeyMap,
+		actions: m.actions,
+		bulk:    m.bulkActions,
+		modal:   m.modalKeyBindings(),
+	}
+}
+
+// helpKeyMap adds the key bindings of actions to the table's help.
+type helpKeyMap struct {
+	KeyMap
+	actions []Action
+	bulk    []BulkAction
+
+	// Bindings of the active message box, if any
+	modal []key.Binding
+}
+
+// ShortHelp implements the KeyMap interface.
+func (km helpKeyMap) ShortHelp() []key.Binding {
+	if len(km.modal) > 0 {
+		return km.modal
+	}
+
+	return append(km.KeyMap.ShortHelp(), km.actionKeys()...)
+}
+
+// FullHelp implements the KeyMap interface.
+func (km helpKeyMap) FullHelp() [][]key.Binding {
+	if len(km.modal) > 0 {
+		return [][]key.Binding{km.modal}
+	}
+
+	bindings := km.KeyMap.FullHelp()
+
+	if len(km.actions) == 0 && len(km.bulk) == 0 {
+		return bindings
+	}
+
+	return append(bindings, km.actionKeys())
+}
+
+// actionKeys returns the key bindings of the actions and bulk actions.
+func (km helpKeyMap) actionKeys() []key.Binding {
+	keys := make([]key.Binding, 0, len(km.actions)+len(km.bulk))
+	for _, a := range km.actions {
+		keys = append(keys, a.Key)
+	}
+
+	for _, a := range km.bulk {
+		keys = append(keys, a.Key)
+	}
+
+	return keys
+}
+
+// performAction launches the action whose key binding matches the key, if any.
+// It returns false if no action matched.
+func (m *Model) performAction(msg tea.KeyMsg) (bool, tea.Cmd) {
+	for _, a := range m.actions {
+		if !key.Matches(msg, a.Key) {
+			continue
+		}
+
+		return true, m.runAction(a)
+	}
+
+	return false, nil
+}
+
+// runAction performs an action on the selected row, or opens its confirmation message box.
+func (m *Model) runAction(a Action) tea.Cmd {
+	if m.cursor < 0 || m.cursor >= len(m.rows) || a.Handler == nil {
+		return nil
+	}
+
+	if a.Confirm == nil {
+		return a.Handler(m, m.rows[m.cursor], messagebox.MB_OK)
+	}
+
+	boxType := a.ConfirmType
+	if boxType == 0 {
+		boxType = messagebox.YES_NO
+	}
+
+	m.openConfirm(a.Confirm(m.rows[m.cursor]), boxType, a.ConfirmOptions, confirmHandler(a.Handler))
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package xtable
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fireflycons/bubbles/messagebox"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionWithoutConfirmation(t *testing.T) {
+	var actioned []Row
+
+	table := New(
+		WithStructData([]rowData{newRowData("Hobnobs", 10), newRowData("Tim Tams", 8)}),
+		WithFocused(true),
+		WithActions(Action{
+			Name: "Open",
+			Key:  key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "open")),
+			Handler: func(_ *Model, r Row, button messagebox.Button) tea.Cmd {
+				require.Equal(t, messagebox.MB_OK, button)
+				actioned = append(actioned, r)
+				return nil
+			},
+		}),
+	)
+	table.SetCursor(1)
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	require.False(t, table.ModalActive())
+	require.Equal(t, 1, len(actioned))
+	require.Equal(t, "Tim Tams", actioned[0].Data[0])
+	require.Contains(t, table.HelpView(), "open")
+}
+
+func TestActionWithConfirmation(t *testing.T) {
+	table := New(
+		WithStructData([]rowData{newRowData("Hobnobs", 10), newRowData("Tim Tams", 8)}),
+		WithFocused(true),
+		WithActions(Action{
+			Name: "Remove",
+			// Overrides the table's half page down binding
+			Key: key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "remove")),
+			Confirm: func(r Row) string {
+				return "Remove " + r.Data[0] + "?"
+			},
+			ConfirmType: messagebox.YES_NO_ALL,
+			Handler: func(m *Model, r Row, button messagebox.Button) tea.Cmd {
+				if button == messagebox.MB_ALL {
+					m.RemoveRowByIndex(0)
+					m.RemoveRowByIndex(0)
+				}
+				return nil
+			},
+		}),
+	)
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	require.True(t, table.ModalActive())
+	require.Contains(t, table.View(), "Remove Hobnobs?")
+
+	table, cmd := table.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	require.False(t, table.ModalActive())
+
+	table, _ = table.Update(cmd())
+	require.Equal(t, 0, len(table.Rows()))
+}
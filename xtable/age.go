@@ -0,0 +1,112 @@
+package xtable
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DefaultAgeInterval is the interval at which columns of KindAge are refreshed, unless set by WithAgeRefresh.
+const DefaultAgeInterval = time.Second
+
+// ageTimeLayouts are the timestamp formats accepted in columns of KindAge. The last is the format of
+// time.Time.String.
+var ageTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+}
+
+// now returns the current time. It is replaced in tests.
+var now = time.Now
+
+// ageTickMsg signals that it is time to re-render relative times.
+type ageTickMsg struct {
+	id int
+}
+
+// WithAgeRefresh sets the interval at which columns of KindAge are re-rendered, so that the relative times
+// they show stay current without the rows being set again. The default is DefaultAgeInterval.
+func WithAgeRefresh(interval time.Duration) Option {
+	return func(m *Model) {
+		m.ageInterval = interval
+	}
+}
+
+// ageTick returns a command that fires the next re-render of relative times, or nil if the table has no column of KindAge.
+func (m Model) ageTick() tea.Cmd {
+	hasAge := false
+
+	for _, c := range m.cols {
+		hasAge = hasAge || c.Kind == KindAge
+	}
+
+	if !hasAge {
+		return nil
+	}
+
+	interval := m.ageInterval
+	if interval <= 0 {
+		interval = DefaultAgeInterval
+	}
+
+	id := m.id
+
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return ageTickMsg{id: id}
+	})
+}
+
+// renderAge renders a timestamp as the time elapsed since, e.g. 42s ago.
+func renderAge(value string, _ Metadata, _ int) string {
+	t, ok := parseAgeTime(value)
+	if !ok {
+		return value
+	}
+
+	d := now().Sub(t)
+	if d < 0 {
+		return "in " + formatAge(-d)
+	}
+
+	return formatAge(d) + " ago"
+}
+
+// parseAgeTime parses a timestamp in any of the accepted layouts, or as Unix seconds.
+func parseAgeTime(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+
+	// Remove the monotonic clock reading included when formatting a time.Time from time.Now
+	if i := strings.Index(value, " m="); i >= 0 {
+		value = value[:i]
+	}
+
+	for _, layout := range ageTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+
+	if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(secs, 0), true
+	}
+
+	return time.Time{}, false
+}
+
+// formatAge formats a duration in its largest whole unit, from seconds to days.
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d/time.Second))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	}
+
+	return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+}
@@ -0,0 +1,56 @@
+package xtable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderAge(t *testing.T) {
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return base }
+	t.Cleanup(func() { now = time.Now })
+
+	tests := []struct {
+		value  string
+		expect string
+	}{
+		{"2024-05-01T11:59:18Z", "42s ago"},
+		{"2024-05-01 11:55:00", "5m ago"},
+		{base.Add(-3*time.Hour - time.Minute).String(), "3h ago"},
+		{"2024-04-28T12:00:00Z", "3d ago"},
+		{"2024-05-01T12:10:00Z", "in 10m"},
+		{"1714564800", "0s ago"},
+		{"2024-05-01 11:59:59.5 +0000 UTC m=+0.000123", "0s ago"},
+		{"yesterday", "yesterday"},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.expect, renderAge(tt.value, nil, 10), tt.value)
+	}
+}
+
+func TestAgeTick(t *testing.T) {
+	plain := New(WithColumns([]Column{{Title: "Name", Width: 10}}))
+	require.Nil(t, plain.Init())
+
+	table := New(
+		WithColumns([]Column{{Title: "Age", Width: 10, Kind: KindAge}}),
+		WithRows([]Row{{Data: []string{"2024-05-01T11:59:18Z"}}}),
+		WithAgeRefresh(time.Millisecond),
+	)
+
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return base }
+	t.Cleanup(func() { now = time.Now })
+
+	cmd := table.Init()
+	require.NotNil(t, cmd)
+
+	// Time passes without the rows being set again
+	base = base.Add(time.Minute)
+	table, cmd = table.Update(cmd())
+	require.NotNil(t, cmd, "tick should be rescheduled")
+	require.Contains(t, table.View(), "1m ago")
+}
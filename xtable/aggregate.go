@@ -0,0 +1,93 @@
+package xtable
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrNoValues is returned by Aggregate when a column has no numeric values to aggregate.
+var ErrNoValues = errors.New("no numeric values")
+
+// AggregateFunc computes a single value from the numeric values of a column.
+type AggregateFunc func(values []float64) (float64, error)
+
+var (
+	// AggregateSum is the sum of the values, or zero if there are none.
+	AggregateSum AggregateFunc = func(values []float64) (float64, error) {
+		sum := 0.0
+		for _, v := range values {
+			sum += v
+		}
+
+		return sum, nil
+	}
+
+	// AggregateAvg is the mean of the values.
+	AggregateAvg AggregateFunc = func(values []float64) (float64, error) {
+		if len(values) == 0 {
+			return 0, ErrNoValues
+		}
+
+		sum, _ := AggregateSum(values)
+
+		return sum / float64(len(values)), nil
+	}
+
+	// AggregateMin is the smallest of the values.
+	AggregateMin AggregateFunc = func(values []float64) (float64, error) {
+		return extreme(values, func(a, b float64) bool { return a < b })
+	}
+
+	// AggregateMax is the largest of the values.
+	AggregateMax AggregateFunc = func(values []float64) (float64, error) {
+		return extreme(values, func(a, b float64) bool { return a > b })
+	}
+
+	// AggregateCount is the number of values.
+	AggregateCount AggregateFunc = func(values []float64) (float64, error) {
+		return float64(len(values)), nil
+	}
+)
+
+// extreme returns the value that is better than all others.
+func extreme(values []float64, better func(a, b float64) bool) (float64, error) {
+	if len(values) == 0 {
+		return 0, ErrNoValues
+	}
+
+	result := values[0]
+	for _, v := range values[1:] {
+		if better(v, result) {
+			result = v
+		}
+	}
+
+	return result, nil
+}
+
+// Aggregate applies fn to the values of the column identified by index, e.g. to show a total in a
+// status bar or footer. Only visible rows are included, so an active filter is respected. Cells whose
+// values cannot be parsed as numbers are ignored.
+//
+// An error is returned if the column does not exist, or from fn, e.g. ErrNoValues if there are no values to average.
+func (m Model) Aggregate(index int, fn AggregateFunc) (float64, error) {
+	if index < 0 || index >= len(m.cols) {
+		return 0, fmt.Errorf("column %d does not exist", index)
+	}
+
+	values := make([]float64, 0, len(m.rows))
+
+	for _, r := range m.rows {
+		if index >= len(r.Data) {
+			continue
+		}
+
+		if f, err := strconv.ParseFloat(strings.TrimSpace(r.Data[index]), 64); err == nil {
+			values = append(values, f)
+		}
+	}
+
+	return fn(values)
+}
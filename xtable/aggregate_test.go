@@ -0,0 +1,53 @@
+package xtable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregate(t *testing.T) {
+	table := New(
+		WithFilterDebounce(time.Millisecond),
+		WithColumns([]Column{{Title: "Name", Width: 10}, {Title: "Count", Width: 10}}),
+		WithRows([]Row{
+			{Data: []string{"apples", "4"}},
+			{Data: []string{"pears", " 10 "}},
+			{Data: []string{"plums", "n/a"}},
+			{Data: []string{"figs", "1.5"}},
+		}),
+	)
+
+	tests := []struct {
+		name   string
+		fn     AggregateFunc
+		expect float64
+	}{
+		{"sum", AggregateSum, 15.5},
+		{"avg", AggregateAvg, 15.5 / 3},
+		{"min", AggregateMin, 1.5},
+		{"max", AggregateMax, 10},
+		{"count", AggregateCount, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := table.Aggregate(1, tt.fn)
+			require.NoError(t, err)
+			require.InDelta(t, tt.expect, got, 1e-9)
+		})
+	}
+
+	// Filtered rows are excluded
+	table = runFilter(t, table, table.SetFilterText("p"))
+	got, err := table.Aggregate(1, AggregateSum)
+	require.NoError(t, err)
+	require.Equal(t, 14.0, got)
+
+	_, err = table.Aggregate(0, AggregateAvg)
+	require.ErrorIs(t, err, ErrNoValues)
+
+	_, err = table.Aggregate(2, AggregateSum)
+	require.Error(t, err)
+}
@@ -0,0 +1,69 @@
+package xtable
+
+// WithFollowTail keeps the last row selected as rows are appended, like tail -f, e.g. for logs and other
+// growing data. While the last row is selected, rows added by AppendRow, AppendRows, AppendFromChannel or
+// LoadRows with ImportAppend cause the new last row to be selected and scrolled into view.
+// Moving the cursor off the last row stops following, until the last row is selected again.
+func WithFollowTail() Option {
+	return func(m *Model) {
+		m.followTail = true
+	}
+}
+
+// SetFollowTail sets whether the last row remains selected as rows are appended. See WithFollowTail.
+func (m *Model) SetFollowTail(follow bool) {
+	m.followTail = follow
+}
+
+// AppendRow adds a row to the end of the table. See AppendRows.
+func (m *Model) AppendRow(r Row) {
+	m.appendRows([]Row{r})
+}
+
+// AppendRows adds rows to the end of the table, after construction. The selected row remains selected,
+// unless following the tail (see WithFollowTail). If a filter is active, only the added rows matching
+// it are shown. The row number cell should not be included in the rows' data, as it is added by the table
+// if row numbers are enabled. If row events are enabled, RowAddedMsg is queued for each row.
+func (m *Model) AppendRows(rows ...Row) {
+	m.appendRows(rows)
+}
+
+// InsertRowAt inserts a row before the row at the given index, e.g. in an ordered list, or at the end of the
+// table if index is the number of rows. If a filter is active, index is that of the rows shown, and the row is
+// only shown if it matches the filter. The selected row remains selected, so the cursor moves down if the row
+// is inserted at or before it. The row number cell should not be included in the row's data, as it is added
+// by the table if row numbers are enabled. If the index is out of range, the row is not inserted and false
+// is returned.
+func (m *Model) InsertRowAt(index int, r Row) bool {
+	if index < 0 || index > len(m.rows) {
+		return false
+	}
+
+	r = m.numberRows([]Row{r})[0]
+	m.emitRowAdded(r)
+
+	// Position of the insert and of the selected row in the complete set of rows
+	at := len(m.AllRows())
+	if index < len(m.rows) {
+		at = m.allIndex(index)
+	}
+
+	selected := m.allIndex(m.cursor)
+	if selected >= at {
+		selected++
+	}
+
+	if m.filter.match != nil {
+		m.filter.all = insertIndex(m.filter.all, at, r)
+	} else {
+		m.rows = insertIndex(m.rows, at, r)
+	}
+
+	m.refilter()
+	m.cursor = clamp(m.visibleIndex(selected), 0, len(m.rows)-1)
+	m.RenumberRows()
+	m.UpdateViewport()
+	m.scrollToCursor()
+
+	return true
+}
@@ -0,0 +1,103 @@
+package xtable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendRows(t *testing.T) {
+	table := importTable()
+	table.AppendRow(importRow("Penguins", "6", 3))
+	table.AppendRows(importRow("Jaffa Cakes", "12", 4), importRow("Bourbons", "16", 5))
+
+	require.Equal(t, []string{"Tim Tams", "Hobnobs", "Penguins", "Jaffa Cakes", "Bourbons"}, columnValues(table.Rows(), 0))
+	require.Equal(t, 0, table.Cursor(), "selected row is unchanged")
+}
+
+func TestAppendRowsRowNumbers(t *testing.T) {
+	table := New(
+		WithStructData([]rowData{{Name: "Tim Tams", PacketSize: 8, hash: 1}}),
+		WithRowNumbers(),
+	)
+
+	added := []Row{importRow("Hobnobs", "10", 2)}
+	table.AppendRows(added...)
+	table.AppendRow(importRow("Penguins", "6", 3))
+
+	require.Equal(t, []string{"1", "Tim Tams", "8"}, table.Rows()[0].Data)
+	require.Equal(t, []string{"2", "Hobnobs", "10"}, table.Rows()[1].Data)
+	require.Equal(t, []string{"3", "Penguins", "6"}, table.Rows()[2].Data)
+	require.Equal(t, []string{"Hobnobs", "10"}, added[0].Data, "the caller's rows are unchanged")
+}
+
+func TestAppendRowsFollowTail(t *testing.T) {
+	table := New(
+		WithStructData([]rowData{
+			{Name: "Tim Tams", PacketSize: 8, hash: 1},
+			{Name: "Hobnobs", PacketSize: 10, hash: 2},
+		}),
+		WithFollowTail(),
+		WithHeight(3),
+	)
+
+	table.GotoBottom()
+	table.AppendRows(importRow("Penguins", "6", 3), importRow("Jaffa Cakes", "12", 4))
+	require.Equal(t, 3, table.Cursor(), "last row is followed")
+	require.Contains(t, table.View(), "Jaffa")
+
+	table.SetCursor(1)
+	table.AppendRow(importRow("Bourbons", "16", 5))
+	require.Equal(t, 1, table.Cursor(), "not following when the last row is not selected")
+
+	table.SetFollowTail(false)
+	table.GotoBottom()
+	table.AppendRow(importRow("Custard Creams", "20", 6))
+	require.Equal(t, 4, table.Cursor())
+}
+
+func TestInsertRowAt(t *testing.T) {
+	table := importTable()
+	table.SetCursor(1)
+
+	require.True(t, table.InsertRowAt(0, importRow("Penguins", "6", 3)))
+	require.Equal(t, []string{"Penguins", "Tim Tams", "Hobnobs"}, columnValues(table.Rows(), 0))
+	require.Equal(t, "Hobnobs", table.SelectedRow().Data[0], "cursor moves with the selected row")
+
+	require.True(t, table.InsertRowAt(3, importRow("Bourbons", "16", 4)))
+	require.Equal(t, "Bourbons", table.Rows()[3].Data[0])
+	require.Equal(t, 2, table.Cursor())
+
+	require.False(t, table.InsertRowAt(5, importRow("Jaffa Cakes", "12", 5)))
+	require.False(t, table.InsertRowAt(-1, importRow("Jaffa Cakes", "12", 5)))
+	require.Len(t, table.Rows(), 4)
+}
+
+func TestInsertRowAtRowNumbers(t *testing.T) {
+	table := New(
+		WithStructData([]rowData{{Name: "Tim Tams", PacketSize: 8, hash: 1}, {Name: "Hobnobs", PacketSize: 10, hash: 2}}),
+		WithRowNumbers(),
+	)
+
+	require.True(t, table.InsertRowAt(1, importRow("Penguins", "6", 3)))
+	require.Equal(t, []string{"2", "Penguins", "6"}, table.Rows()[1].Data)
+	require.Equal(t, []string{"3", "Hobnobs", "10"}, table.Rows()[2].Data)
+}
+
+func TestInsertRowAtFiltered(t *testing.T) {
+	table := importTable()
+	table.AppendRows(importRow("Hobnobs Dark", "10", 3))
+	table.filter.match = table.textMatcher("Hobnobs")
+	table.filter.all = table.rows
+	table.refilter()
+	table.SetCursor(1)
+
+	require.True(t, table.InsertRowAt(1, importRow("Hobnobs Milk", "10", 4)))
+	require.Equal(t, []string{"Hobnobs", "Hobnobs Milk", "Hobnobs Dark"}, columnValues(table.Rows(), 0))
+	require.Equal(t, "Hobnobs Dark", table.SelectedRow().Data[0])
+	require.Equal(t, []string{"Tim Tams", "Hobnobs", "Hobnobs Milk", "Hobnobs Dark"}, columnValues(table.AllRows(), 0))
+
+	require.True(t, table.InsertRowAt(0, importRow("Penguins", "6", 5)))
+	require.Len(t, table.Rows(), 3, "hidden by the filter")
+	require.Equal(t, "Penguins", table.AllRows()[1].Data[0])
+}
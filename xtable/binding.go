@@ -0,0 +1,172 @@
+package xtable
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// BindStructData creates a table from a slice of structs implementing the Metadata interface, as WithStructData,
+// but keeps a reference to the caller's slice so that the table can be updated from it by calling Refresh.
+// slicePtr must be a pointer to the slice, e.g. &users. The slice may be empty.
+//
+// Panics if slicePtr is not a pointer to a slice of structs implementing Metadata, or any field in fields is not found.
+func BindStructData(slicePtr interface{}, fields ...string) Option {
+	return func(m *Model) {
+		binding, schema, err := bindSlice(slicePtr, fields, m.structOptions())
+		if err != nil {
+			panic(fmt.Sprintf("Cannot bind table: %s", err.Error()))
+		}
+
+		m.cols = schema.columns
+		m.rows = schema.rows(binding.Elem())
+		m.schema = schema
+		m.binding = binding
+
+		for _, r := range m.rows {
+			for j, valStr := range r.Data {
+				m.cols[j].Width = max(m.cols[j].Width, len(valStr))
+			}
+		}
+	}
+}
+
+// bindSlice validates a pointer to a slice of structs, and creates the schema for the struct type.
+func bindSlice(slicePtr interface{}, fields []string, opts structOptions) (reflect.Value, *structSchema, error) {
+	v := reflect.ValueOf(slicePtr)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Slice {
+		return reflect.Value{}, nil, errors.New("argument must be a pointer to a slice")
+	}
+
+	schema, err := newStructSchema(v.Elem().Type().Elem(), fields, opts)
+	if err != nil {
+		return reflect.Value{}, nil, err
+	}
+
+	return v, schema, nil
+}
+
+// rows creates table rows from each element of a slice of the schema's type. Nil pointers are skipped.
+func (s *structSchema) rows(slice reflect.Value) []Row {
+	rows := make([]Row, 0, slice.Len())
+
+	for i := 0; i < slice.Len(); i++ {
+		elem := slice.Index(i)
+		if elem.Kind() == reflect.Ptr && elem.IsNil() {
+			continue
+		}
+
+		rows = append(rows, s.row(elem))
+	}
+
+	return rows
+}
+
+// Refresh updates a table created by BindStructData from the current content of the bound slice.
+// Rows are reconciled by metadata hash: rows whose element is still in the slice are updated in place,
+// keeping their position in the table, rows whose element has been removed from the slice are removed,
+// and rows for new elements are added at the end. The selected row remains selected if it is still present.
+//
+// Any active filter is re-applied. If row events are enabled, RowAddedMsg and RowRemovedMsg are queued
+// for the added and removed rows. An error is returned if the table is not bound to a slice.
+//
+// Changes made to the rows of the table, e.g. by deleting or editing rows, are not written back to the slice,
+// so are undone by Refresh unless the application makes the same change to the slice.
+func (m *Model) Refresh() error {
+	if !m.binding.IsValid() {
+		return errors.New("table is not bound to a slice")
+	}
+
+	fresh := m.schema.rows(m.binding.Elem())
+	if m.rowNumbers {
+		prependRowNumbers(fresh)
+	}
+
+	// Queue of indexes into fresh by hash, so that elements with duplicate hashes are each matched once
+	byHash := make(map[uint64][]int, len(fresh))
+	for i, r := range fresh {
+		h := r.Metadata.GetHashCode()
+		byHash[h] = append(byHash[h], i)
+	}
+
+	matched := make([]bool, len(fresh))
+	rows := make([]Row, 0, len(fresh))
+
+	for _, r := range m.AllRows() {
+		if r.Metadata == nil {
+			m.emitRowRemoved(r)
+			continue
+		}
+
+		h := r.Metadata.GetHashCode()
+
+		if q := byHash[h]; len(q) > 0 {
+			byHash[h] = q[1:]
+			matched[q[0]] = true
+			rows = append(rows, fresh[q[0]])
+
+			continue
+		}
+
+		m.emitRowRemoved(r)
+	}
+
+	for i, r := range fresh {
+		if !matched[i] {
+			m.emitRowAdded(r)
+			rows = append(rows, r)
+		}
+	}
+
+	hash, hasHash := m.selectedHash()
+
+	if m.filter.match != nil {
+		m.filter.all = rows
+	} else {
+		m.rows = rows
+	}
+
+	m.refilter()
+	m.restoreCursor(hash, hasHash)
+
+	return nil
+}
+
+// SetStructData replaces the rows of a table created from struct data, e.g. by WithStructData, with rows created
+// from data, a refreshed slice of the same element type, in the order of the slice. Any active filter and sort
+// are re-applied, and the selected row, identified by its metadata hash, remains selected at the same position
+// in the viewport if it is still present. The columns are unchanged.
+//
+// An error is returned if the table was not created from struct data, or data is not a slice of the same type.
+func (m *Model) SetStructData(data interface{}) error {
+	if m.schema == nil {
+		return errors.New("table was not created from struct data")
+	}
+
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice || v.Type().Elem() != m.schema.elemType {
+		return fmt.Errorf("data must be a slice of %s", m.schema.elemType)
+	}
+
+	rows := m.schema.rows(v)
+	if m.rowNumbers {
+		prependRowNumbers(rows)
+	}
+
+	hash, hasHash := m.selectedHash()
+	offset := m.SelectedRowYOffset()
+
+	m.setAllRows(rows)
+
+	if sorted := m.SortState(); len(sorted) > 0 {
+		m.SortByMulti(sorted...)
+	}
+
+	m.restoreCursor(hash, hasHash)
+
+	// Keep the selected row on the same line of the viewport
+	m.viewport.SetYOffset(clamp(m.cursor-m.start-offset, 0, m.cursor-m.start))
+	m.scrollToCursor()
+
+	return nil
+}
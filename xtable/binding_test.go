@@ -0,0 +1,93 @@
+package xtable
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type boundUser struct {
+	ID   int
+	Name string
+}
+
+func (u boundUser) GetHashCode() uint64 {
+	return uint64(u.ID)
+}
+
+func TestBindStructDataRefresh(t *testing.T) {
+	users := []boundUser{{1, "alice"}, {2, "bob"}, {3, "carol"}}
+	table := New(BindStructData(&users), WithRowEvents())
+
+	table.SortBy(1, SortDescending, SortString)
+	table.SetCursor(1)
+	require.Equal(t, "bob", table.SelectedRow().Data[1])
+	table.FlushEvents()
+
+	// Update bob, remove carol, add dave
+	users[1].Name = "robert"
+	users = append(users[:2], boundUser{4, "dave"})
+
+	require.NoError(t, table.Refresh())
+	require.Equal(t, []string{"robert", "alice", "dave"}, columnValues(table.Rows(), 1), "existing rows keep their order")
+	require.Equal(t, "robert", table.SelectedRow().Data[1])
+
+	events := collectMsgs(table.FlushEvents())
+	require.Len(t, events, 2)
+	require.Equal(t, "carol", events[0].(RowRemovedMsg).Row.Data[1])
+	require.Equal(t, "dave", events[1].(RowAddedMsg).Row.Data[1])
+}
+
+func TestBindStructDataEmptySlice(t *testing.T) {
+	var users []boundUser
+	table := New(BindStructData(&users))
+
+	require.Equal(t, []string{"ID", "Name"}, []string{table.Columns()[0].Title, table.Columns()[1].Title})
+	require.Empty(t, table.Rows())
+
+	users = append(users, boundUser{1, "alice"})
+	require.NoError(t, table.Refresh())
+	require.Len(t, table.Rows(), 1)
+}
+
+func TestRefreshUnbound(t *testing.T) {
+	table := New(WithStructData([]boundUser{{1, "alice"}}))
+	require.Error(t, table.Refresh())
+}
+
+func TestBindStructDataPanics(t *testing.T) {
+	require.Panics(t, func() { New(BindStructData([]boundUser{})) })
+}
+
+func TestSetStructData(t *testing.T) {
+	users := []boundUser{}
+	for i := 1; i <= 20; i++ {
+		users = append(users, boundUser{i, fmt.Sprintf("user%02d", i)})
+	}
+
+	table := New(WithStructData(users), WithHeight(6))
+	table.MoveDown(12)
+	table.MoveUp(2)
+	offset := table.SelectedRowYOffset()
+
+	// Refreshed slice with a new row before the selected one, and the selected row renamed
+	fresh := append([]boundUser{{0, "root"}}, users...)
+	fresh[11].Name = "renamed"
+
+	require.NoError(t, table.SetStructData(fresh))
+	require.Len(t, table.Rows(), 21)
+	require.Equal(t, "renamed", table.SelectedRow().Data[1])
+	require.Equal(t, 11, table.Cursor())
+	require.Equal(t, offset, table.SelectedRowYOffset(), "selected row stays on the same line")
+
+	table.SortBy(1, SortDescending, SortString)
+	require.NoError(t, table.SetStructData(users))
+	require.Equal(t, "user20", table.Rows()[0].Data[1], "sort is re-applied")
+	require.Equal(t, "user11", table.SelectedRow().Data[1])
+
+	require.Error(t, table.SetStructData([]rowData{}))
+
+	table = New()
+	require.Error(t, table.SetStructData(users))
+}
@@ -0,0 +1,124 @@
+package xtable
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fireflycons/bubbles/messagebox"
+)
+
+// BulkActionMsg is sent when a bulk action is launched on the marked rows.
+type BulkActionMsg struct {
+	// ID of the table that sent the message
+	TableID int
+
+	// Name of the action
+	Action string
+
+	// The marked rows, in table order
+	Rows []Row
+}
+
+// BulkAction is a named operation on all marked rows, launched by a key binding when multi-select is enabled.
+// Rather than calling a handler, the table sends a BulkActionMsg, which the owning model handles.
+type BulkAction struct {
+	// Name of the action, sent in BulkActionMsg
+	Name string
+
+	// Key(s) to launch the action. The binding's help is included in the table's help.
+	Key key.Binding
+
+	// If set, returns a message to display in a message box for the number of marked rows.
+	// BulkActionMsg is sent only if the message box is dismissed with MB_YES or MB_OK.
+	// If not set, BulkActionMsg is sent immediately.
+	Confirm func(count int) string
+
+	// Type of the confirmation message box. If zero, messagebox.YES_NO is used.
+	ConfirmType messagebox.Type
+
+	// Options for the confirmation message box. By default it is displayed below the selected row.
+	ConfirmOptions []messagebox.Option
+}
+
+// WithBulkActions registers actions on the marked rows (see WithMultiSelect), launched by their key bindings
+// when the table is focused and any rows are marked. Bulk action key bindings take precedence over the key
+// bindings of actions on the selected row.
+func WithBulkActions(actions ...BulkAction) Option {
+	return func(m *Model) {
+		m.bulkActions = append(m.bulkActions, actions...)
+	}
+}
+
+// ConfirmCount returns a confirmation function for a bulk action, which formats the number of marked rows
+// with the given format, e.g. "Delete %d rows?".
+func ConfirmCount(format string) func(int) string {
+	return func(count int) string {
+		return fmt.Sprintf(format, count)
+	}
+}
+
+// PerformBulkAction launches the named bulk action on the marked rows, as if by its key binding.
+// It returns nil if there is no such action, or no rows are marked.
+func (m *Model) PerformBulkAction(name string) tea.Cmd {
+	for _, a := range m.bulkActions {
+		if a.Name == name {
+			return m.runBulkAction(a)
+		}
+	}
+
+	return nil
+}
+
+// performBulkAction launches the bulk action whose key binding matches the key, if any rows are marked.
+// It returns false if no action was launched.
+func (m *Model) performBulkAction(msg tea.KeyMsg) (bool, tea.Cmd) {
+	if len(m.marks) == 0 {
+		return false, nil
+	}
+
+	for _, a := range m.bulkActions {
+		if key.Matches(msg, a.Key) {
+			return true, m.runBulkAction(a)
+		}
+	}
+
+	return false, nil
+}
+
+// runBulkAction sends BulkActionMsg for the marked rows, or opens the action's confirmation message box.
+func (m *Model) runBulkAction(a BulkAction) tea.Cmd {
+	rows := m.MarkedRows()
+	if len(rows) == 0 {
+		return nil
+	}
+
+	msg := BulkActionMsg{
+		TableID: m.id,
+		Action:  a.Name,
+		Rows:    rows,
+	}
+
+	if a.Confirm == nil {
+		return func() tea.Msg {
+			return msg
+		}
+	}
+
+	boxType := a.ConfirmType
+	if boxType == 0 {
+		boxType = messagebox.YES_NO
+	}
+
+	m.openBulkConfirm(a.Confirm(len(rows)), boxType, a.ConfirmOptions, func(button messagebox.Button) tea.Cmd {
+		if button&(messagebox.MB_YES|messagebox.MB_OK) == 0 {
+			return nil
+		}
+
+		return func() tea.Msg {
+			return msg
+		}
+	})
+
+	return nil
+}
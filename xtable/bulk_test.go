@@ -0,0 +1,98 @@
+package xtable
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+)
+
+func bulkTable(confirm func(int) string) Model {
+	return New(
+		WithStructData([]rowData{
+			newRowData("Chocolate Digestives", 12),
+			newRowData("Tim Tams", 8),
+			newRowData("Hobnobs", 10),
+		}),
+		WithFocused(true),
+		WithWidth(60),
+		WithMultiSelect(),
+		WithBulkActions(BulkAction{
+			Name:    "delete",
+			Key:     key.NewBinding(key.WithKeys("D"), key.WithHelp("D", "delete marked")),
+			Confirm: confirm,
+		}),
+	)
+}
+
+var keyBulk = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("D")}
+
+func bulkMsgs(msgs []tea.Msg) []BulkActionMsg {
+	bulk := []BulkActionMsg{}
+
+	for _, msg := range msgs {
+		if b, ok := msg.(BulkActionMsg); ok {
+			bulk = append(bulk, b)
+		}
+	}
+
+	return bulk
+}
+
+func TestBulkAction(t *testing.T) {
+	table := bulkTable(nil)
+
+	// Without marks, the key is not handled as a bulk action
+	_, cmd := table.Update(keyBulk)
+	require.Empty(t, bulkMsgs(collectMsgs(cmd)))
+
+	table.MarkRow(rowHash(table.Rows()[0]), true)
+	table.MarkRow(rowHash(table.Rows()[2]), true)
+
+	_, cmd = table.Update(keyBulk)
+	msgs := bulkMsgs(collectMsgs(cmd))
+	require.Len(t, msgs, 1)
+	require.Equal(t, "delete", msgs[0].Action)
+	require.Equal(t, table.ID(), msgs[0].TableID)
+	require.Equal(t, []string{"Chocolate Digestives", "Hobnobs"}, columnValues(msgs[0].Rows, 0))
+}
+
+func TestBulkActionConfirmation(t *testing.T) {
+	table := bulkTable(ConfirmCount("Delete %d rows?"))
+	table.MarkRow(rowHash(table.Rows()[1]), true)
+	table.MarkRow(rowHash(table.Rows()[2]), true)
+
+	table, cmd := table.Update(keyBulk)
+	require.Nil(t, cmd)
+	require.True(t, table.ModalActive())
+	require.Contains(t, table.View(), "Delete 2 rows?")
+
+	// Declined
+	table, cmd = table.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	require.False(t, table.ModalActive())
+
+	table, cmd = table.Update(cmd())
+	require.Empty(t, bulkMsgs(collectMsgs(cmd)))
+
+	// Accepted
+	table, _ = table.Update(keyBulk)
+	table, cmd = table.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	_, cmd = table.Update(cmd())
+
+	msgs := bulkMsgs(collectMsgs(cmd))
+	require.Len(t, msgs, 1)
+	require.Equal(t, []string{"Tim Tams", "Hobnobs"}, columnValues(msgs[0].Rows, 0))
+}
+
+func TestPerformBulkAction(t *testing.T) {
+	table := bulkTable(nil)
+	require.Nil(t, table.PerformBulkAction("delete"))
+
+	table.MarkRow(rowHash(table.Rows()[1]), true)
+	require.Nil(t, table.PerformBulkAction("archive"))
+
+	msgs := bulkMsgs(collectMsgs(table.PerformBulkAction("delete")))
+	require.Len(t, msgs, 1)
+	require.Equal(t, []string{"Tim Tams"}, columnValues(msgs[0].Rows, 0))
+}
@@ -0,0 +1,184 @@
+package xtable
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// CellRenderer renders a cell value for display in a column of width characters.
+// meta is the Metadata of the row containing the cell, which may be nil.
+type CellRenderer func(value string, meta Metadata, width int) string
+
+// Built-in column kinds.
+const (
+	// KindProgress renders a percentage (0-100, optionally suffixed with %) as a progress bar.
+	KindProgress = "progress"
+
+	// KindSparkline renders a comma or space separated list of numbers as a sparkline.
+	KindSparkline = "sparkline"
+
+	// KindBool renders boolean values as ✓ or ✗.
+	KindBool = "bool"
+
+	// KindBytes renders a number of bytes in human readable form, e.g. 1.2 GiB.
+	KindBytes = "bytes"
+
+	// KindAge renders a timestamp (RFC 3339, a time.Time value or Unix seconds) as the time elapsed since, e.g. 42s ago.
+	// The column is re-rendered periodically to keep it current (see WithAgeRefresh).
+	KindAge = "age"
+)
+
+var (
+	renderersMu sync.RWMutex
+	renderers   = map[string]CellRenderer{
+		KindProgress:  renderProgress,
+		KindSparkline: renderSparkline,
+		KindBool:      renderBool,
+		KindBytes:     renderBytes,
+		KindAge:       renderAge,
+	}
+)
+
+// RegisterRenderer registers a cell renderer for the given column kind for all tables.
+// Registering a kind that already exists replaces it.
+func RegisterRenderer(kind string, r CellRenderer) {
+	renderersMu.Lock()
+	defer renderersMu.Unlock()
+
+	renderers[kind] = r
+}
+
+// WithRenderer registers a cell renderer for the given column kind for this table only.
+// It takes precedence over a renderer of the same kind registered with RegisterRenderer.
+func WithRenderer(kind string, r CellRenderer) Option {
+	return func(m *Model) {
+		if m.renderers == nil {
+			m.renderers = map[string]CellRenderer{}
+		}
+
+		m.renderers[kind] = r
+	}
+}
+
+// renderer finds the renderer for a column kind.
+func (m Model) renderer(kind string) (CellRenderer, bool) {
+	if r, ok := m.renderers[kind]; ok {
+		return r, true
+	}
+
+	renderersMu.RLock()
+	defer renderersMu.RUnlock()
+
+	r, ok := renderers[kind]
+	return r, ok
+}
+
+// renderCell applies the column's renderer, if any, to a cell value.
+func (m Model) renderCell(value string, meta Metadata, col Column) string {
+	if col.Kind == "" {
+		return value
+	}
+
+	if r, ok := m.renderer(col.Kind); ok {
+		return r(value, meta, col.Width)
+	}
+
+	return value
+}
+
+// renderProgress renders a progress bar.
+func renderProgress(value string, _ Metadata, width int) string {
+	pct, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(value), "%"), 64)
+	if err != nil || !isFinite(pct) || width <= 0 {
+		return value
+	}
+
+	filled := clamp(int(math.Round(clampFloat(pct, 0, 100)/100*float64(width))), 0, width)
+
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// renderSparkline renders the most recent values that fit within width as a sparkline.
+func renderSparkline(value string, _ Metadata, width int) string {
+	fields := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+
+	if len(fields) > width {
+		fields = fields[len(fields)-width:]
+	}
+
+	values := make([]float64, len(fields))
+	low, high := math.Inf(1), math.Inf(-1)
+
+	for i, f := range fields {
+		v, err := strconv.ParseFloat(f, 64)
+		if err != nil || !isFinite(v) {
+			return value
+		}
+		values[i] = v
+		low, high = math.Min(low, v), math.Max(high, v)
+	}
+
+	sb := strings.Builder{}
+	for _, v := range values {
+		ind := 0
+		if high > low {
+			ind = clamp(int((v-low)/(high-low)*float64(len(sparks)-1)), 0, len(sparks)-1)
+		}
+		sb.WriteRune(sparks[ind])
+	}
+
+	return sb.String()
+}
+
+// renderBool renders a boolean value as a glyph.
+func renderBool(value string, _ Metadata, _ int) string {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "yes", "y", "1":
+		return "✓"
+	case "false", "no", "n", "0":
+		return "✗"
+	}
+
+	return value
+}
+
+// renderBytes renders a number of bytes using binary units.
+func renderBytes(value string, _ Metadata, _ int) string {
+	n, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || !isFinite(n) {
+		return value
+	}
+
+	return formatBytes(n)
+}
+
+// formatBytes formats a number of bytes using binary units.
+func formatBytes(n float64) string {
+	const unit = 1024
+
+	if math.Abs(n) < unit {
+		return fmt.Sprintf("%d B", int64(n))
+	}
+
+	exp := 0
+	for div := float64(unit); math.Abs(n)/div >= unit && exp < 5; div *= unit {
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", n/math.Pow(unit, float64(exp+1)), "KMGTPE"[exp])
+}
+
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+func clampFloat(v, low, high float64) float64 {
+	return math.Min(math.Max(v, low), high)
+}
@@ -0,0 +1,76 @@
+package xtable
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltinRenderers(t *testing.T) {
+	tests := []struct {
+		kind     string
+		value    string
+		width    int
+		expected string
+	}{
+		{kind: KindProgress, value: "50%", width: 4, expected: "██░░"},
+		{kind: KindProgress, value: "150", width: 4, expected: "████"},
+		{kind: KindProgress, value: "n/a", width: 4, expected: "n/a"},
+		{kind: KindProgress, value: "NaN", width: 4, expected: "NaN"},
+		{kind: KindProgress, value: "+Inf", width: 4, expected: "+Inf"},
+		{kind: KindProgress, value: "-Inf%", width: 4, expected: "-Inf%"},
+		{kind: KindSparkline, value: "1,2,3,4,5,6,7,8", width: 8, expected: "▁▂▃▄▅▆▇█"},
+		{kind: KindSparkline, value: "1 8 1", width: 2, expected: "█▁"},
+		{kind: KindSparkline, value: "1,NaN,3", width: 4, expected: "1,NaN,3"},
+		{kind: KindSparkline, value: "1,+Inf,3", width: 4, expected: "1,+Inf,3"},
+		{kind: KindSparkline, value: "-Inf 1", width: 4, expected: "-Inf 1"},
+		{kind: KindBool, value: "true", width: 5, expected: "✓"},
+		{kind: KindBool, value: "No", width: 5, expected: "✗"},
+		{kind: KindBool, value: "maybe", width: 5, expected: "maybe"},
+		{kind: KindBytes, value: "512", width: 10, expected: "512 B"},
+		{kind: KindBytes, value: "1288490189", width: 10, expected: "1.2 GiB"},
+		{kind: KindBytes, value: "NaN", width: 10, expected: "NaN"},
+	}
+
+	m := New()
+
+	for _, test := range tests {
+		t.Run(test.kind+" "+test.value, func(t *testing.T) {
+			got := m.renderCell(test.value, nil, Column{Kind: test.kind, Width: test.width})
+			require.Equal(t, test.expected, got)
+		})
+	}
+}
+
+func TestCustomRenderer(t *testing.T) {
+	upper := func(value string, _ Metadata, _ int) string {
+		return strings.ToUpper(value)
+	}
+
+	table := &Model{
+		rows:   []Row{{Data: []string{"foo", "bar"}}},
+		cols:   []Column{{Title: "A", Width: 3, Kind: "upper"}, {Title: "B", Width: 3}},
+		styles: Styles{Cell: lipgloss.NewStyle()},
+	}
+
+	WithRenderer("upper", upper)(table)
+	require.Equal(t, "FOObar", table.renderRow(0))
+}
+
+type progressData struct {
+	Name     string
+	Complete int `xtable:"Done,kind=progress"`
+}
+
+func (p progressData) GetHashCode() uint64 {
+	return uint64(p.Complete)
+}
+
+func TestRendererFromStructTag(t *testing.T) {
+	table := New(WithStructData([]progressData{{Name: "job", Complete: 50}}))
+
+	require.Equal(t, "Done", table.cols[1].Title)
+	require.Equal(t, KindProgress, table.cols[1].Kind)
+}
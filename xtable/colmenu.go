@@ -0,0 +1,191 @@
+package xtable
+
+import (
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fireflycons/bubbles/messagebox"
+)
+
+// Actions offered by the column menu
+const (
+	columnSortAscending  = "Sort ascending"
+	columnSortDescending = "Sort descending"
+	columnFilterByValue  = "Filter by value"
+	columnClearFilter    = "Clear filter"
+	columnAutoSize       = "Auto-size"
+	columnHide           = "Hide column"
+	columnShowAll        = "Show hidden columns"
+)
+
+// columnMenuState holds the state of the column menu.
+type columnMenuState struct {
+	// Whether the key bound to KeyMap.ColumnMenu opens the menu, set by WithColumnMenu
+	enabled bool
+
+	// The menu, which runs from opening until an action is chosen or the menu is cancelled
+	seq     messagebox.Sequence
+	running bool
+
+	// Column the menu was opened for, or -1 if the column is chosen in the menu
+	col int
+}
+
+// WithColumnMenu enables the key bound to KeyMap.ColumnMenu (by default c), which opens a menu of actions
+// on a column: sort ascending or descending, filter by the value in the selected row, auto-size, hide, and
+// show hidden columns. The column is chosen from a list first. To open the menu for a given column, e.g. when
+// its header is right clicked, use OpenColumnMenu. While the menu is open, the table's Update must receive all messages.
+func WithColumnMenu() Option {
+	return func(m *Model) {
+		m.columnMenu.enabled = true
+	}
+}
+
+// OpenColumnMenu opens the column menu for the column at the given index, or if the index is negative,
+// a list of columns to choose from first. The menu can be opened whether or not WithColumnMenu was set.
+// Use ColumnAt to find the column under the mouse.
+func (m *Model) OpenColumnMenu(index int) {
+	if index >= len(m.cols) || m.ModalActive() {
+		return
+	}
+
+	steps := []messagebox.Step{}
+
+	if index < 0 {
+		steps = append(steps, m.chooseColumnStep)
+	}
+
+	steps = append(steps, m.columnActionsStep)
+
+	m.columnMenu.col = index
+	// Anchored below the header, which is the first line of the table
+	m.columnMenu.seq, _ = messagebox.NewSequence(steps, messagebox.WithRowAnchor(confirmAnchorX, 0)).Start()
+	m.columnMenu.running = m.columnMenu.seq.IsActive()
+}
+
+// chooseColumnStep lists the visible columns, numbered as for the quick sort keys.
+func (m Model) chooseColumnStep(mb messagebox.Model, _ []tea.Msg) messagebox.Model {
+	items := []string{}
+	selected := 0
+
+	for i, col := range m.cols {
+		if col.Width <= 0 || (i == 0 && m.rowNumbers) {
+			continue
+		}
+
+		if m.sorted.priority(i) == 0 {
+			selected = len(items)
+		}
+
+		items = append(items, strconv.Itoa(i)+" "+col.Title)
+	}
+
+	return mb.NewList("Column", items, selected, messagebox.OK_CANCEL)
+}
+
+// columnActionsStep lists the actions on the chosen column.
+func (m Model) columnActionsStep(mb messagebox.Model, answers []tea.Msg) messagebox.Model {
+	col := m.menuColumn(answers)
+	if col < 0 {
+		return mb
+	}
+
+	items := []string{}
+
+	if m.IsSortable(col) {
+		items = append(items, columnSortAscending, columnSortDescending)
+	}
+
+	if m.filter.byColumn && m.filter.column == col {
+		items = append(items, columnClearFilter)
+	} else if m.cursor >= 0 && m.cursor < len(m.rows) {
+		items = append(items, columnFilterByValue)
+	}
+
+	items = append(items, columnAutoSize, columnHide)
+
+	if len(m.hidden) > 0 {
+		items = append(items, columnShowAll)
+	}
+
+	return mb.NewList(m.cols[col].Title, items, 0, messagebox.OK_CANCEL)
+}
+
+// menuColumn returns the column the menu applies to, given the answers so far.
+func (m Model) menuColumn(answers []tea.Msg) int {
+	if m.columnMenu.col >= 0 {
+		return m.columnMenu.col
+	}
+
+	if len(answers) == 0 {
+		return -1
+	}
+
+	chosen, ok := answers[0].(messagebox.Result[string])
+	if !ok {
+		return -1
+	}
+
+	number, _, _ := strings.Cut(chosen.Value, " ")
+
+	col, err := strconv.Atoi(number)
+	if err != nil || col >= len(m.cols) {
+		return -1
+	}
+
+	return col
+}
+
+// updateColumnMenu passes a message to the column menu.
+func (m *Model) updateColumnMenu(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	m.columnMenu.seq, cmd = m.columnMenu.seq.Update(msg)
+
+	return cmd
+}
+
+// applyColumnMenu performs the action chosen from the column menu.
+func (m *Model) applyColumnMenu(msg messagebox.SequenceDoneMsg) tea.Cmd {
+	m.columnMenu.running = false
+
+	col := m.menuColumn(msg.Answers)
+	if col < 0 || len(msg.Answers) == 0 {
+		return nil
+	}
+
+	action, ok := msg.Answers[len(msg.Answers)-1].(messagebox.Result[string])
+	if !ok || !action.Accepted() {
+		return nil
+	}
+
+	switch action.Value {
+	case columnSortAscending:
+		m.SortBy(col, SortAscending, m.columnSortHint(col))
+	case columnSortDescending:
+		m.SortBy(col, SortDescending, m.columnSortHint(col))
+	case columnFilterByValue:
+		if m.cursor >= 0 && m.cursor < len(m.rows) && col < len(m.rows[m.cursor].Data) {
+			return m.SetColumnFilter(col, m.rows[m.cursor].Data[col])
+		}
+	case columnClearFilter:
+		return m.SetFilterText("")
+	case columnAutoSize:
+		m.AutoSizeColumn(col)
+	case columnHide:
+		m.HideColumn(col)
+	case columnShowAll:
+		m.ShowColumns()
+	}
+
+	return nil
+}
+
+// columnSortHint returns the type hint for sorting by a column.
+func (m Model) columnSortHint(col int) interface{} {
+	if hint := m.cols[col].SortHint; hint != nil {
+		return hint
+	}
+
+	return SortNumeric
+}
@@ -0,0 +1,121 @@
+package xtable
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+)
+
+func columnMenuTable() Model {
+	return New(
+		WithStructData([]rowData{
+			newRowData("Tim Tams", 8),
+			newRowData("Hobnobs", 10),
+			newRowData("Penguins", 8),
+		}),
+		WithFocused(true),
+		WithHeight(10),
+		WithWidth(60),
+		WithColumnMenu(),
+		WithFilterDebounce(time.Millisecond),
+	)
+}
+
+// pressKeys sends keys to the table, feeding the resulting messages back until none remain.
+func pressKeys(table Model, keys ...tea.KeyMsg) Model {
+	for _, k := range keys {
+		queue := []tea.Msg{k}
+
+		for len(queue) > 0 {
+			var cmd tea.Cmd
+			table, cmd = table.Update(queue[0])
+			queue = append(queue[1:], collectMsgs(cmd)...)
+		}
+	}
+
+	return table
+}
+
+var (
+	keyDown  = tea.KeyMsg{Type: tea.KeyDown}
+	keyEnter = tea.KeyMsg{Type: tea.KeyEnter}
+	keyEsc   = tea.KeyMsg{Type: tea.KeyEsc}
+	keyMenu  = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")}
+)
+
+func TestColumnMenuSort(t *testing.T) {
+	table := pressKeys(columnMenuTable(), keyMenu)
+	require.True(t, table.ModalActive())
+	require.Contains(t, table.View(), "1 PacketSize")
+
+	// Choose PacketSize, then Sort descending
+	table = pressKeys(table, keyDown, keyEnter)
+	require.True(t, table.ModalActive())
+	require.Contains(t, table.View(), "Sort descending")
+
+	table = pressKeys(table, keyDown, keyEnter)
+	require.False(t, table.ModalActive())
+	require.Equal(t, []string{"Hobnobs", "Tim Tams", "Penguins"}, columnValues(table.Rows(), 0))
+}
+
+func TestColumnMenuFilterByValue(t *testing.T) {
+	table := columnMenuTable()
+	table.OpenColumnMenu(1)
+
+	table = pressKeys(table, keyDown, keyDown, keyEnter)
+	require.False(t, table.ModalActive())
+	require.True(t, table.IsFiltered())
+	require.Equal(t, []string{"Tim Tams", "Penguins"}, columnValues(table.Rows(), 0))
+
+	col, ok := table.FilterColumn()
+	require.True(t, ok)
+	require.Equal(t, 1, col)
+
+	// The menu now offers to clear the filter
+	table.OpenColumnMenu(1)
+	require.Contains(t, table.View(), "Clear filter")
+
+	table = pressKeys(table, keyDown, keyDown, keyEnter)
+	require.False(t, table.IsFiltered())
+}
+
+func TestColumnMenuHideAndShow(t *testing.T) {
+	table := columnMenuTable()
+	table.OpenColumnMenu(1)
+
+	table = pressKeys(table, keyDown, keyDown, keyDown, keyDown, keyEnter)
+	require.Equal(t, []int{1}, table.HiddenColumns())
+	require.NotContains(t, table.View(), "PacketSize")
+
+	table.OpenColumnMenu(0)
+	table = pressKeys(table, keyDown, keyDown, keyDown, keyDown, keyDown, keyEnter)
+	require.Empty(t, table.HiddenColumns())
+	require.Contains(t, table.View(), "PacketSize")
+}
+
+func TestColumnMenuCancelled(t *testing.T) {
+	table := pressKeys(columnMenuTable(), keyMenu, keyEsc)
+	require.False(t, table.ModalActive())
+	require.Equal(t, []string{"Tim Tams", "Hobnobs", "Penguins"}, columnValues(table.Rows(), 0))
+}
+
+func TestAutoSizeColumn(t *testing.T) {
+	table := New(WithColumns([]Column{{Title: "Name", Width: 2}}), WithRows([]Row{{Data: []string{"Chocolate"}}}))
+	table.AutoSizeColumn(0)
+
+	require.Equal(t, 9, table.Columns()[0].Width)
+}
+
+func TestColumnAt(t *testing.T) {
+	table := New(WithColumns([]Column{{Title: "A", Width: 4}, {Title: "B", Width: 2}}))
+
+	// Each column is padded by one space either side
+	require.Equal(t, 0, table.ColumnAt(0))
+	require.Equal(t, 0, table.ColumnAt(5))
+	require.Equal(t, 1, table.ColumnAt(6))
+	require.Equal(t, 1, table.ColumnAt(9))
+	require.Equal(t, -1, table.ColumnAt(10))
+	require.Equal(t, -1, table.ColumnAt(-1))
+}
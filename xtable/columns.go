@@ -0,0 +1,89 @@
+package xtable
+
+import (
+	"github.com/mattn/go-runewidth"
+)
+
+// hiddenColumn records the width of a column hidden by HideColumn, so it can be shown again.
+type hiddenColumn struct {
+	index int
+	width int
+}
+
+// HideColumn hides the column at the given index. Its data is kept, and it can be shown again by ShowColumns.
+func (m *Model) HideColumn(index int) {
+	if index < 0 || index >= len(m.cols) || m.cols[index].Width <= 0 {
+		return
+	}
+
+	m.hidden = append(m.hidden, hiddenColumn{index: index, width: m.cols[index].Width})
+	m.cols[index].Width = 0
+	m.UpdateViewport()
+}
+
+// ShowColumns shows all columns hidden by HideColumn.
+func (m *Model) ShowColumns() {
+	for _, h := range m.hidden {
+		if h.index < len(m.cols) {
+			m.cols[h.index].Width = h.width
+		}
+	}
+
+	m.hidden = nil
+	m.UpdateViewport()
+}
+
+// HiddenColumns returns the indexes of the columns hidden by HideColumn, in the order they were hidden.
+func (m Model) HiddenColumns() []int {
+	hidden := make([]int, len(m.hidden))
+
+	for i, h := range m.hidden {
+		hidden[i] = h.index
+	}
+
+	return hidden
+}
+
+// AutoSizeColumn sets the width of the column at the given index to fit its title, including any sort indicator,
+// and the widest value in the column, including rows hidden by a filter. Hidden columns are not changed.
+func (m *Model) AutoSizeColumn(index int) {
+	if index < 0 || index >= len(m.cols) || m.cols[index].Width <= 0 {
+		return
+	}
+
+	width := runewidth.StringWidth(m.headerTitle(index))
+
+	for _, r := range m.AllRows() {
+		if index < len(r.Data) {
+			width = max(width, runewidth.StringWidth(m.renderCell(r.Data[index], r.Metadata, m.cols[index])))
+		}
+	}
+
+	m.cols[index].Width = max(width, 1)
+	m.UpdateViewport()
+}
+
+// ColumnAt returns the index of the column rendered at the given horizontal offset from the left edge of the table,
+// or -1 if there is no column there. This can be used to open the column menu (see OpenColumnMenu) for a header
+// that was clicked, given the position of the table on the screen.
+func (m Model) ColumnAt(x int) int {
+	frame := m.styles.Header.GetHorizontalFrameSize()
+
+	for i, col := range m.cols {
+		if col.Width <= 0 {
+			continue
+		}
+
+		if x < col.Width+frame {
+			if x < 0 {
+				return -1
+			}
+
+			return i
+		}
+
+		x -= col.Width + frame
+	}
+
+	return -1
+}
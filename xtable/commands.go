@@ -0,0 +1,48 @@
+package xtable
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// commandMsg carries an operation to be applied to a table by its Update method.
+type commandMsg struct {
+	id    int
+	apply func(m *Model)
+}
+
+// command returns a command delivering an operation to the table with the given ID.
+func command(tableID int, apply func(m *Model)) tea.Cmd {
+	return func() tea.Msg {
+		return commandMsg{id: tableID, apply: apply}
+	}
+}
+
+// SetRowsCmd returns a command that sets the rows of the table with the given ID, as SetRows.
+// Commands such as this allow background commands and sibling components to manipulate a table
+// through the message flow, without a pointer to its Model. The table's Update must receive the message.
+func SetRowsCmd(tableID int, rows []Row) tea.Cmd {
+	return command(tableID, func(m *Model) {
+		m.SetRows(rows)
+	})
+}
+
+// SortCmd returns a command that sorts the table with the given ID, as SortBy.
+func SortCmd(tableID, index int, order SortOrder, typeHint interface{}) tea.Cmd {
+	return command(tableID, func(m *Model) {
+		m.SortBy(index, order, typeHint)
+	})
+}
+
+// SetCursorCmd returns a command that selects a row of the table with the given ID, as SetCursor.
+func SetCursorCmd(tableID, n int) tea.Cmd {
+	return command(tableID, func(m *Model) {
+		m.SetCursor(n)
+	})
+}
+
+// RemoveByHashCmd returns a command that removes a row from the table with the given ID, as RemoveRowByHash.
+func RemoveByHashCmd(tableID int, hashCode uint64) tea.Cmd {
+	return command(tableID, func(m *Model) {
+		m.RemoveRowByHash(hashCode)
+	})
+}
@@ -0,0 +1,33 @@
+package xtable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommands(t *testing.T) {
+	table := importTable()
+	other := importTable()
+
+	table, _ = table.Update(SetRowsCmd(table.ID(), []Row{
+		importRow("Tim Tams", "8", 1),
+		importRow("Hobnobs", "10", 2),
+		importRow("Penguins", "6", 3),
+	})())
+	require.Len(t, table.Rows(), 3)
+
+	table, _ = table.Update(SortCmd(table.ID(), 1, SortAscending, SortNumeric)())
+	require.Equal(t, []string{"Penguins", "Tim Tams", "Hobnobs"}, columnValues(table.Rows(), 0))
+
+	table, _ = table.Update(SetCursorCmd(table.ID(), 2)())
+	require.Equal(t, 2, table.Cursor())
+
+	table, cmd := table.Update(RemoveByHashCmd(table.ID(), 1)())
+	require.Equal(t, []string{"Penguins", "Hobnobs"}, columnValues(table.Rows(), 0))
+	require.NotNil(t, cmd, "row removed event")
+
+	// Commands for another table are ignored
+	other, _ = other.Update(SetCursorCmd(table.ID(), 1)())
+	require.Equal(t, 0, other.Cursor())
+}
@@ -0,0 +1,61 @@
+package xtable
+
+// computedColumn is a column whose values are derived from the row metadata.
+type computedColumn struct {
+	title string
+	value func(meta Metadata) string
+}
+
+// WithComputedColumn adds a column whose values are computed from the metadata of each row, for values
+// derived from the source data that are not fields of it. value is called with nil for rows without metadata.
+//
+// Computed columns follow all other columns, in the order they are added. Values are recomputed whenever
+// rows are added, removed, replaced, sorted or filtered, or the table is updated by Refresh.
+// The column is initially wide enough for its title and the values of the initial rows.
+func WithComputedColumn(title string, value func(meta Metadata) string) Option {
+	return func(m *Model) {
+		m.computed = append(m.computed, computedColumn{title: title, value: value})
+	}
+}
+
+// addComputedColumns appends the computed columns to the table's columns and computes their values.
+func (m *Model) addComputedColumns() {
+	if len(m.computed) == 0 {
+		return
+	}
+
+	first := len(m.cols)
+
+	for _, c := range m.computed {
+		m.cols = append(m.cols, Column{Title: c.title, Width: len(c.title)})
+	}
+
+	m.computeCells(m.rows)
+
+	for _, r := range m.rows {
+		for i := first; i < len(m.cols); i++ {
+			m.cols[i].Width = max(m.cols[i].Width, len(r.Data[i]))
+		}
+	}
+}
+
+// computeCells sets the values of the computed columns of each row.
+func (m *Model) computeCells(rows []Row) {
+	if len(m.computed) == 0 {
+		return
+	}
+
+	first := len(m.cols) - len(m.computed)
+
+	for i, r := range rows {
+		// Copy rather than write into the row's existing data, which may be shared with the caller
+		data := make([]string, first, len(m.cols))
+		copy(data, r.Data)
+
+		for _, c := range m.computed {
+			data = append(data, c.value(r.Metadata))
+		}
+
+		rows[i].Data = data
+	}
+}
@@ -0,0 +1,39 @@
+package xtable
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputedColumn(t *testing.T) {
+	users := []boundUser{{1, "alice"}, {2, "bob"}}
+	upper := func(meta Metadata) string {
+		if meta == nil {
+			return "-"
+		}
+
+		return strings.ToUpper(meta.(boundUser).Name)
+	}
+
+	table := New(BindStructData(&users), WithComputedColumn("Upper", upper), WithRowNumbers())
+
+	cols := table.Columns()
+	require.Len(t, cols, 4)
+	require.Equal(t, "Upper", cols[3].Title)
+	require.Equal(t, []string{"ALICE", "BOB"}, columnValues(table.Rows(), 3))
+
+	// Recomputed on refresh
+	users[1].Name = "robert"
+	require.NoError(t, table.Refresh())
+	require.Equal(t, []string{"ALICE", "ROBERT"}, columnValues(table.Rows(), 3))
+
+	// Sortable like any other column
+	table.SortBy(3, SortDescending, SortString)
+	require.Equal(t, []string{"ROBERT", "ALICE"}, columnValues(table.Rows(), 3))
+
+	// Rows without metadata
+	table.SetRows([]Row{{Data: []string{"", "9", "zed"}}})
+	require.Equal(t, []string{"", "9", "zed", "-"}, table.Rows()[0].Data)
+}
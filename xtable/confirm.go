@@ -0,0 +1,115 @@
+package xtable
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fireflycons/bubbles/messagebox"
+)
+
+// confirmHandler is called with the selected row when a confirmation message box is dismissed.
+type confirmHandler func(m *Model, r Row, button messagebox.Button) tea.Cmd
+
+// confirmMsg carries the button that dismissed a confirmation message box.
+type confirmMsg struct {
+	id     int
+	button messagebox.Button
+}
+
+// confirmState holds the state of a confirmation message box for an operation on the selected row.
+type confirmState struct {
+	// The message box, which is active while being displayed
+	box messagebox.Model
+
+	// Index and identity of the row the operation applies to. The index is updated when the box is
+	// dismissed, in case rows have been added, removed or sorted since it was displayed.
+	index int
+	row   rowIdentity
+
+	// Performs the operation when the message box is dismissed
+	handler confirmHandler
+
+	// Performs an operation on the marked rows instead, when the message box is dismissed
+	bulk func(button messagebox.Button) tea.Cmd
+}
+
+// confirmAnchorX is the column of the left edge of a confirmation message box anchored to the selected row.
+const confirmAnchorX = 2
+
+// openConfirm displays a message box to confirm an operation on the selected row.
+// By default the message box is displayed adjacent to the selected row, unless overridden by opts.
+func (m *Model) openConfirm(message string, boxType messagebox.Type, opts []messagebox.Option, handler confirmHandler) {
+	if m.cursor < 0 || m.cursor >= len(m.rows) {
+		return
+	}
+
+	opts = append([]messagebox.Option{messagebox.WithRowAnchor(confirmAnchorX, m.SelectedRowScreenY()), m.confirmWidth()}, opts...)
+
+	m.confirm = confirmState{
+		box:     m.confirm.box.New(message, boxType, opts...),
+		index:   m.cursor,
+		row:     identify(m.rows[m.cursor]),
+		handler: handler,
+	}
+}
+
+// openBulkConfirm displays a message box to confirm an operation on the marked rows.
+// By default the message box is displayed adjacent to the selected row, unless overridden by opts.
+func (m *Model) openBulkConfirm(message string, boxType messagebox.Type, opts []messagebox.Option, bulk func(messagebox.Button) tea.Cmd) {
+	opts = append([]messagebox.Option{messagebox.WithRowAnchor(confirmAnchorX, m.SelectedRowScreenY()), m.confirmWidth()}, opts...)
+
+	m.confirm = confirmState{
+		box:  m.confirm.box.New(message, boxType, opts...),
+		bulk: bulk,
+	}
+}
+
+// confirmMaxWidth is the width of a confirmation message box, that of a message box by default,
+// when the table's view is wide enough.
+const confirmMaxWidth = 40
+
+// confirmWidth returns an option sizing a confirmation message box to fit within the table's view, as a message
+// box overlaid on the view is clipped to it. The box is moved left as necessary to fit.
+func (m Model) confirmWidth() messagebox.Option {
+	width := max(lipgloss.Width(m.headersView()), m.viewport.Width)
+	frame := messagebox.DefaultStyles().Border.GetHorizontalFrameSize()
+
+	return messagebox.WithWidth(min(confirmMaxWidth, width-frame))
+}
+
+// updateConfirm passes a message to the confirmation message box.
+// When the box is dismissed, the chosen button is returned to the table as confirmMsg.
+func (m *Model) updateConfirm(msg tea.Msg) tea.Cmd {
+	box, cmd := m.confirm.box.Update(msg)
+	m.confirm.box = box.(messagebox.Model)
+
+	if cmd == nil || m.confirm.box.IsActive() {
+		return cmd
+	}
+
+	id := m.id
+
+	return func() tea.Msg {
+		msg := cmd()
+		if button, ok := msg.(messagebox.Button); ok {
+			return confirmMsg{id: id, button: button}
+		}
+
+		return msg
+	}
+}
+
+// applyConfirm performs the confirmed operation on the row it was requested for,
+// unless that row has since been removed.
+func (m *Model) applyConfirm(msg confirmMsg) tea.Cmd {
+	if m.confirm.bulk != nil {
+		return m.confirm.bulk(msg.button)
+	}
+
+	m.confirm.index = m.locateRow(m.confirm.row, m.confirm.index)
+
+	if m.confirm.index == -1 || m.confirm.handler == nil {
+		return nil
+	}
+
+	return m.confirm.handler(m, m.rows[m.confirm.index], msg.button)
+}
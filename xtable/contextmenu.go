@@ -0,0 +1,135 @@
+package xtable
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fireflycons/bubbles/messagebox"
+)
+
+// contextMenuState holds the state of the context menu of the selected row.
+type contextMenuState struct {
+	// Returns the menu items for a row, set by WithContextMenu
+	items func(Row) []Action
+
+	// The menu, which runs from opening until an item is chosen or the menu is cancelled
+	seq     messagebox.Sequence
+	running bool
+
+	// Items offered, and the index and hash of the row they apply to
+	actions []Action
+	index   int
+	hash    uint64
+}
+
+// WithContextMenu enables the key bound to KeyMap.ContextMenu (by default m), which opens a menu of actions
+// on the selected row, anchored at the row. The items are returned by the given function for the row, and are
+// listed by name. The chosen item is performed as if launched by its key (see WithActions), with confirmation
+// if its Confirm function is set, so the handler receives the row with its metadata. The Key of an item is not used.
+//
+// To open the menu from a right click, select the row under the mouse (see RowAt) and call OpenContextMenu.
+// While the menu is open, the table's Update must receive all messages.
+func WithContextMenu(items func(Row) []Action) Option {
+	return func(m *Model) {
+		m.contextMenu.items = items
+	}
+}
+
+// OpenContextMenu opens the context menu for the selected row, if enabled by WithContextMenu
+// and the row has any items.
+func (m *Model) OpenContextMenu() {
+	if m.contextMenu.items == nil || m.cursor < 0 || m.cursor >= len(m.rows) || m.ModalActive() {
+		return
+	}
+
+	r := m.rows[m.cursor]
+	actions := m.contextMenu.items(r)
+
+	if len(actions) == 0 {
+		return
+	}
+
+	names := make([]string, len(actions))
+	for i, a := range actions {
+		names[i] = a.Name
+	}
+
+	m.contextMenu.actions = actions
+	m.contextMenu.index = m.cursor
+	m.contextMenu.hash = rowHash(r)
+
+	step := func(mb messagebox.Model, _ []tea.Msg) messagebox.Model {
+		return mb.NewList("", names, 0, messagebox.OK_CANCEL)
+	}
+
+	m.contextMenu.seq, _ = messagebox.NewSequence(
+		[]messagebox.Step{step},
+		messagebox.WithRowAnchor(confirmAnchorX, m.SelectedRowScreenY()),
+		messagebox.WithWidth(m.contextMenuWidth(names)),
+	).Start()
+	m.contextMenu.running = m.contextMenu.seq.IsActive()
+}
+
+// contextMenuWidth returns the width of a menu box wide enough for the item names.
+func (m Model) contextMenuWidth(names []string) int {
+	width := 0
+	for _, n := range names {
+		width = max(width, lipgloss.Width(n))
+	}
+
+	// Border, padding and cursor prefix of the list
+	return width + 6 //nolint:mnd
+}
+
+// RowAt returns the index of the visible row rendered on the given line of the table's view, counting from the
+// top of the headers, or -1 if there is no row there. This can be used to select the row that was right clicked,
+// given the position of the table on the screen.
+func (m Model) RowAt(y int) int {
+	line := y - lipgloss.Height(m.headersView())
+	if line < 0 || line >= m.viewport.Height {
+		return -1
+	}
+
+	index := line + m.start + m.viewport.YOffset
+	if index >= len(m.rows) {
+		return -1
+	}
+
+	return index
+}
+
+// updateContextMenu passes a message to the context menu.
+func (m *Model) updateContextMenu(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	m.contextMenu.seq, cmd = m.contextMenu.seq.Update(msg)
+
+	return cmd
+}
+
+// applyContextMenu performs the item chosen from the context menu on the row it was opened for,
+// unless that row has since been removed.
+func (m *Model) applyContextMenu(msg messagebox.SequenceDoneMsg) tea.Cmd {
+	m.contextMenu.running = false
+
+	chosen, ok := msg.Answers[0].(messagebox.Result[string])
+	if !ok || !chosen.Accepted() {
+		return nil
+	}
+
+	ind := m.contextMenu.index
+	if m.contextMenu.hash != 0 {
+		ind = m.GetRowByHash(m.contextMenu.hash)
+	}
+
+	if ind < 0 || ind >= len(m.rows) {
+		return nil
+	}
+
+	for _, a := range m.contextMenu.actions {
+		if a.Name == chosen.Value {
+			m.cursor = ind
+			return m.runAction(a)
+		}
+	}
+
+	return nil
+}
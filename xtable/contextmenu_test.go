@@ -0,0 +1,84 @@
+package xtable
+
+import (
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fireflycons/bubbles/messagebox"
+	"github.com/stretchr/testify/require"
+)
+
+var keyRowMenu = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")}
+
+func contextMenuTable(performed *[]string) Model {
+	record := func(name string) ActionHandler {
+		return func(_ *Model, r Row, button messagebox.Button) tea.Cmd {
+			*performed = append(*performed, fmt.Sprintf("%s %v %v", name, r.Data[0], button))
+			return nil
+		}
+	}
+
+	return New(
+		WithStructData([]rowData{
+			newRowData("Tim Tams", 8),
+			newRowData("Hobnobs", 10),
+		}),
+		WithFocused(true),
+		WithHeight(10),
+		WithWidth(60),
+		WithContextMenu(func(r Row) []Action {
+			return []Action{
+				{Name: "Open", Handler: record("open")},
+				{Name: "Delete", Handler: record("delete"), Confirm: func(r Row) string { return fmt.Sprintf("Delete %v?", r.Data[0]) }},
+			}
+		}),
+	)
+}
+
+func TestContextMenuPerformsAction(t *testing.T) {
+	var performed []string
+
+	table := pressKeys(contextMenuTable(&performed), keyDown, keyRowMenu)
+	require.True(t, table.ModalActive())
+	require.Contains(t, table.View(), "Delete")
+
+	table = pressKeys(table, keyEnter)
+	require.False(t, table.ModalActive())
+	require.Equal(t, []string{fmt.Sprintf("open Hobnobs %v", messagebox.MB_OK)}, performed)
+}
+
+func TestContextMenuConfirmsAction(t *testing.T) {
+	var performed []string
+
+	table := pressKeys(contextMenuTable(&performed), keyRowMenu, keyDown, keyEnter)
+	require.True(t, table.ModalActive())
+	require.Contains(t, table.View(), "Delete Tim Tams?")
+	require.Empty(t, performed)
+
+	table = pressKeys(table, keyEnter)
+	require.False(t, table.ModalActive())
+	require.Len(t, performed, 1)
+	require.Contains(t, performed[0], "delete Tim Tams")
+}
+
+func TestContextMenuCancel(t *testing.T) {
+	var performed []string
+
+	table := pressKeys(contextMenuTable(&performed), keyRowMenu, keyEsc)
+	require.False(t, table.ModalActive())
+	require.Empty(t, performed)
+}
+
+func TestRowAt(t *testing.T) {
+	var performed []string
+
+	table := contextMenuTable(&performed)
+	headers := lipgloss.Height(table.headersView())
+
+	require.Equal(t, -1, table.RowAt(0))
+	require.Equal(t, 0, table.RowAt(headers))
+	require.Equal(t, 1, table.RowAt(headers+1))
+	require.Equal(t, -1, table.RowAt(headers+2))
+}
@@ -0,0 +1,134 @@
+package xtable
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+
+	"github.com/mattn/go-runewidth"
+)
+
+type csvOptions struct {
+	header      bool
+	comma       rune
+	comment     rune
+	trimLeading bool
+	mode        ImportMode
+}
+
+// CSVOption is used to set options in FromCSV.
+type CSVOption func(*csvOptions)
+
+// WithCSVHeader takes the first record of the CSV data as a header row. With ImportReplace, the header
+// row sets the columns of the table after any row number column, with widths fitting the titles and values.
+// With other import modes,
+// the header row is skipped and the columns are unchanged.
+func WithCSVHeader() CSVOption {
+	return func(o *csvOptions) {
+		o.header = true
+	}
+}
+
+// WithCSVDelimiter sets the field delimiter, e.g. ';' or '\t'. The default is a comma.
+func WithCSVDelimiter(r rune) CSVOption {
+	return func(o *csvOptions) {
+		o.comma = r
+	}
+}
+
+// WithCSVComment causes lines beginning with the given character, e.g. '#', to be ignored.
+func WithCSVComment(r rune) CSVOption {
+	return func(o *csvOptions) {
+		o.comment = r
+	}
+}
+
+// WithCSVTrimLeadingSpace removes leading white space from fields, as written after the delimiter by some tools.
+func WithCSVTrimLeadingSpace() CSVOption {
+	return func(o *csvOptions) {
+		o.trimLeading = true
+	}
+}
+
+// WithCSVImportMode sets how the rows read are combined with the existing rows of the table.
+// The default is ImportReplace. See LoadRows.
+func WithCSVImportMode(mode ImportMode) CSVOption {
+	return func(o *csvOptions) {
+		o.mode = mode
+	}
+}
+
+// FromCSV creates the table rows from CSV data as described by RFC 4180, read with encoding/csv,
+// so that quoted fields may contain delimiters, double quotes and line breaks.
+// Records may have different numbers of fields; records with fewer fields than the table has columns
+// are padded with empty values. If the data cannot be read or parsed, an error is returned and the
+// table is unchanged.
+func (m *Model) FromCSV(r io.Reader, opts ...CSVOption) error {
+	o := &csvOptions{
+		comma: ',',
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	reader := csv.NewReader(r)
+	reader.Comma = o.comma
+	reader.Comment = o.comment
+	reader.TrimLeadingSpace = o.trimLeading
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return err
+	}
+
+	cols := m.dataColumns()
+
+	if o.header {
+		if len(records) == 0 {
+			return errors.New("csv: missing header row")
+		}
+
+		if o.mode == ImportReplace {
+			cols = csvColumns(records)
+		}
+
+		records = records[1:]
+	}
+
+	rows := make([]Row, len(records))
+	for i, record := range records {
+		for len(record) < len(cols) {
+			record = append(record, "")
+		}
+
+		rows[i] = Row{Data: record}
+	}
+
+	m.LoadRows(rows, o.mode)
+
+	if o.header && o.mode == ImportReplace {
+		m.setDataColumns(cols)
+	}
+
+	return nil
+}
+
+// csvColumns returns columns titled by the header row of CSV records, with widths fitting the titles and values.
+func csvColumns(records [][]string) []Column {
+	cols := make([]Column, len(records[0]))
+	for i, title := range records[0] {
+		cols[i] = Column{Title: title, Width: runewidth.StringWidth(title)}
+	}
+
+	for _, record := range records[1:] {
+		for i, value := range record {
+			if i < len(cols) {
+				cols[i].Width = max(cols[i].Width, runewidth.StringWidth(value))
+			}
+		}
+	}
+
+	return cols
+}
@@ -0,0 +1,102 @@
+package xtable
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromCSV(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		opts   []CSVOption
+		expect [][]string
+	}{
+		{
+			name:   "plain",
+			input:  "foo1,bar1\nfoo2,bar2\n",
+			expect: [][]string{{"foo1", "bar1"}, {"foo2", "bar2"}},
+		},
+		{
+			name:   "quoted fields",
+			input:  "\"foo,bar\",\"say \"\"hi\"\"\"\r\n\"multi\nline\",x\r\n",
+			expect: [][]string{{"foo,bar", `say "hi"`}, {"multi\nline", "x"}},
+		},
+		{
+			name:   "short records are padded",
+			input:  "a\nb,c,d\n",
+			expect: [][]string{{"a", ""}, {"b", "c", "d"}},
+		},
+		{
+			name:   "delimiter, comments and leading space",
+			input:  "# comment\nfoo; bar\n",
+			opts:   []CSVOption{WithCSVDelimiter(';'), WithCSVComment('#'), WithCSVTrimLeadingSpace()},
+			expect: [][]string{{"foo", "bar"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := valuesTable()
+			require.NoError(t, m.FromCSV(strings.NewReader(tt.input), tt.opts...))
+
+			got := make([][]string, len(m.Rows()))
+			for i, r := range m.Rows() {
+				got[i] = r.Data
+			}
+
+			require.Equal(t, tt.expect, got)
+		})
+	}
+}
+
+func TestFromCSVHeader(t *testing.T) {
+	m := valuesTable()
+	require.NoError(t, m.FromCSV(strings.NewReader("Name,Description\nfoo,\"a, b and c\"\n"), WithCSVHeader()))
+
+	require.Equal(t, []Column{{Title: "Name", Width: 4}, {Title: "Description", Width: 11}}, m.Columns())
+	require.Len(t, m.Rows(), 1)
+	require.Equal(t, []string{"foo", "a, b and c"}, m.Rows()[0].Data)
+
+	require.NoError(t, m.FromCSV(strings.NewReader("Name,Description\nbar,baz\n"), WithCSVHeader(), WithCSVImportMode(ImportAppend)))
+	require.Equal(t, "Name", m.Columns()[0].Title)
+	require.Len(t, m.Rows(), 2)
+	require.Equal(t, []string{"bar", "baz"}, m.Rows()[1].Data)
+}
+
+func TestFromCSVRowNumbers(t *testing.T) {
+	m := New(WithColumns([]Column{{Title: "Foo", Width: 10}, {Title: "Bar", Width: 10}}), WithRowNumbers())
+	require.NoError(t, m.FromCSV(strings.NewReader("x,1\ny,2\n")))
+	require.Equal(t, [][]string{{"1", "x", "1"}, {"2", "y", "2"}}, rowValues(m.Rows()))
+
+	require.NoError(t, m.FromCSV(strings.NewReader("A,B\nx,1\n"), WithCSVHeader()))
+	require.Equal(t, []string{"#", "A", "B"}, columnTitles(m.Columns()))
+	require.Equal(t, []int{0}, m.FindAll("x"))
+
+	var b strings.Builder
+	require.NoError(t, m.ExportCSV(&b))
+	require.Equal(t, "A,B\nx,1\n", b.String())
+}
+
+func TestFromCSVError(t *testing.T) {
+	m := valuesTable()
+	require.NoError(t, m.FromCSV(strings.NewReader("foo,bar\n")))
+
+	require.Error(t, m.FromCSV(strings.NewReader("\"unterminated,bar\nbaz")))
+	require.Len(t, m.Rows(), 1)
+	require.Equal(t, "Foo", m.Columns()[0].Title)
+
+	require.Error(t, m.FromCSV(strings.NewReader(""), WithCSVHeader()))
+}
+
+// columnTitles returns the titles of the columns.
+func columnTitles(cols []Column) []string {
+	titles := make([]string, len(cols))
+	for i, c := range cols {
+		titles[i] = c.Title
+	}
+
+	return titles
+}
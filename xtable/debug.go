@@ -0,0 +1,84 @@
+package xtable
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fireflycons/bubbles/messagebox"
+)
+
+// DebugState implements messagebox.DebugReporter, reporting the table's cursor, offset, sort, filter
+// and active modal for the debug overlay.
+func (m Model) DebugState() []messagebox.DebugField {
+	sort := "none"
+	if columns := m.SortState(); len(columns) > 0 {
+		parts := make([]string, len(columns))
+		for i, c := range columns {
+			parts[i] = fmt.Sprintf("%d asc", c.Index)
+			if c.Order == SortDescending {
+				parts[i] = fmt.Sprintf("%d desc", c.Index)
+			}
+		}
+
+		sort = strings.Join(parts, ", ")
+	}
+
+	filter := "none"
+	if m.IsFiltered() {
+		filter = strconv.Quote(m.filter.text)
+		if m.filter.byColumn || m.filter.inColumn {
+			filter = fmt.Sprintf("%s in column %d", filter, m.filter.column)
+		}
+
+		if m.filter.predicate != nil {
+			filter += " and predicate"
+		}
+	}
+
+	return []messagebox.DebugField{
+		{Name: "id", Value: strconv.Itoa(m.id)},
+		{Name: "focused", Value: strconv.FormatBool(m.focus)},
+		{Name: "size", Value: fmt.Sprintf("%dx%d", m.Width(), m.Height())},
+		{Name: "cursor", Value: strconv.Itoa(m.cursor)},
+		{Name: "window", Value: fmt.Sprintf("%d-%d", m.start, m.end)},
+		{Name: "offset", Value: strconv.Itoa(m.viewport.YOffset)},
+		{Name: "rows", Value: fmt.Sprintf("%d of %d", len(m.rows), len(m.AllRows()))},
+		{Name: "sort", Value: sort},
+		{Name: "filter", Value: filter},
+		{Name: "marked", Value: strconv.Itoa(len(m.marks))},
+		{Name: "modal", Value: m.modalName()},
+	}
+}
+
+// modalName returns the name of the active modal, for DebugState.
+func (m Model) modalName() string {
+	switch {
+	case m.confirm.box.IsActive():
+		return "confirm"
+	case m.columnMenu.running:
+		return "column menu"
+	case m.contextMenu.running:
+		return "context menu"
+	case m.rowForm.form.IsActive():
+		return "row form"
+	case m.edit.active:
+		return "cell edit"
+	}
+
+	return "none"
+}
+
+// DebugState implements messagebox.DebugReporter, reporting the focused table for the debug overlay.
+func (g TableGroup) DebugState() []messagebox.DebugField {
+	fields := []messagebox.DebugField{
+		{Name: "tables", Value: strconv.Itoa(len(g.Tables))},
+		{Name: "focused", Value: strconv.Itoa(g.current)},
+	}
+
+	if g.current >= 0 && g.current < len(g.Tables) {
+		fields = append(fields, messagebox.DebugField{Name: "focused id", Value: strconv.Itoa(g.Tables[g.current].ID())})
+	}
+
+	return fields
+}
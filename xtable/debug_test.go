@@ -0,0 +1,65 @@
+package xtable
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fireflycons/bubbles/messagebox"
+	"github.com/stretchr/testify/require"
+)
+
+func debugFields(fields []messagebox.DebugField) map[string]string {
+	values := map[string]string{}
+	for _, f := range fields {
+		values[f.Name] = f.Value
+	}
+
+	return values
+}
+
+func TestDebugState(t *testing.T) {
+	table := exportTable()
+	table.SortBy(2, SortDescending, SortNumeric)
+	table.SetCursor(1)
+	table.ToggleMark()
+
+	fields := debugFields(table.DebugState())
+	require.Equal(t, "1", fields["cursor"])
+	require.Equal(t, "2 desc", fields["sort"])
+	require.Equal(t, "none", fields["filter"])
+	require.Equal(t, "3 of 3", fields["rows"])
+	require.Equal(t, "1", fields["marked"])
+	require.Equal(t, "none", fields["modal"])
+
+	table = columnMenuTable()
+	table.OpenColumnMenu(0)
+	require.Equal(t, "column menu", debugFields(table.DebugState())["modal"])
+}
+
+func TestDebugOverlay(t *testing.T) {
+	table := exportTable()
+	debug := messagebox.NewDebug(
+		messagebox.WithDebugHistory(2),
+		messagebox.WithDebugStyles(messagebox.DebugStyles{}),
+	)
+
+	for _, msg := range []tea.Msg{RowsReplacedMsg{Rows: 1}, RowsReplacedMsg{Rows: 2}, RowsReplacedMsg{Rows: 3}} {
+		debug, _ = debug.Update(msg)
+	}
+
+	require.Equal(t, []string{
+		"xtable.RowsReplacedMsg {TableID:0 Rows:2}",
+		"xtable.RowsReplacedMsg {TableID:0 Rows:3}",
+	}, debug.Messages())
+
+	// Hidden until toggled
+	require.Equal(t, "content", debug.Render("content", messagebox.DebugReport("table", table)))
+
+	debug, _ = debug.Update(tea.KeyMsg{Type: tea.KeyF12})
+	require.True(t, debug.Visible())
+
+	view := debug.View(messagebox.DebugReport("table", table))
+	require.Contains(t, view, "table\n")
+	require.Contains(t, view, "cursor  0")
+	require.Contains(t, view, "Messages\n1 xtable.RowsReplacedMsg {TableID:0 Rows:2}")
+}
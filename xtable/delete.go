@@ -0,0 +1,87 @@
+package xtable
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fireflycons/bubbles/messagebox"
+)
+
+// RowDeletedMsg is sent when the user confirms deletion of a row
+// with the message box enabled by WithDeleteConfirmation.
+type RowDeletedMsg struct {
+	// ID of the table that sent the message
+	TableID int
+
+	// The deleted row
+	Row Row
+
+	// Metadata of the deleted row
+	Metadata Metadata
+
+	// Number of rows remaining in the table, including any hidden by a filter
+	Remaining int
+}
+
+// deleteConfirmState holds the settings of the delete confirmation workflow.
+type deleteConfirmState struct {
+	// Whether deletion with confirmation is enabled
+	enabled bool
+
+	// Returns the message to display for the row to delete
+	message func(Row) string
+
+	// Options for the message box
+	opts []messagebox.Option
+}
+
+// WithDeleteConfirmation enables deletion of the selected row from the keyboard. When the table is focused,
+// pressing a key bound to KeyMap.Delete displays a Yes/No message box containing the text returned
+// by message for the selected row. If Yes is chosen, the row is removed and RowDeletedMsg is sent.
+//
+// By default, the message box is displayed below the selected row. This can be overridden with boxOpts.
+// While the message box is displayed, the table's Update must receive all messages. Use ModalActive to determine this.
+func WithDeleteConfirmation(message func(Row) string, boxOpts ...messagebox.Option) Option {
+	return func(m *Model) {
+		m.deleteConfirm.enabled = true
+		m.deleteConfirm.message = message
+		m.deleteConfirm.opts = boxOpts
+	}
+}
+
+// confirmDelete displays the delete confirmation message box for the selected row.
+func (m *Model) confirmDelete() {
+	if m.cursor < 0 || m.cursor >= len(m.rows) {
+		return
+	}
+
+	message := "Delete selected row?"
+
+	if m.deleteConfirm.message != nil {
+		message = m.deleteConfirm.message(m.rows[m.cursor])
+	}
+
+	m.openConfirm(message, messagebox.YES_NO, m.deleteConfirm.opts, deleteRow)
+}
+
+// deleteRow removes the row if deletion was confirmed.
+func deleteRow(m *Model, r Row, button messagebox.Button) tea.Cmd {
+	if button != messagebox.MB_YES {
+		return nil
+	}
+
+	if r.Metadata != nil {
+		m.RemoveRow(r.Metadata)
+	} else {
+		m.RemoveRowByIndex(m.confirm.index)
+	}
+
+	deleted := RowDeletedMsg{
+		TableID:   m.id,
+		Row:       r,
+		Metadata:  r.Metadata,
+		Remaining: len(m.AllRows()),
+	}
+
+	return func() tea.Msg {
+		return deleted
+	}
+}
@@ -0,0 +1,160 @@
+package xtable
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteConfirmation(t *testing.T) {
+	hobnobs := newRowData("Hobnobs", 10)
+	table := New(
+		WithStructData([]rowData{newRowData("Tim Tams", 8), hobnobs}),
+		WithFocused(true),
+		WithDeleteConfirmation(func(r Row) string {
+			return "Delete " + r.Data[0] + "?"
+		}),
+	)
+	table.SetCursor(1)
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyDelete})
+	require.True(t, table.ModalActive())
+	require.Contains(t, table.View(), "Delete Hobnobs?")
+
+	table, cmd := table.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	require.False(t, table.ModalActive())
+
+	msgs := collectMsgs(cmd)
+	require.Equal(t, 1, len(msgs))
+	table, cmd = table.Update(msgs[0])
+
+	require.Equal(t, 1, len(table.Rows()))
+	require.Equal(t, -1, table.GetRow(hobnobs))
+
+	msgs = collectMsgs(cmd)
+	require.Equal(t, 1, len(msgs))
+	deleted := msgs[0].(RowDeletedMsg)
+	require.Equal(t, hobnobs, deleted.Metadata)
+	require.Equal(t, 1, deleted.Remaining)
+}
+
+func TestDeleteConfirmationDeclined(t *testing.T) {
+	table := New(
+		WithStructData([]rowData{newRowData("Hobnobs", 10)}),
+		WithFocused(true),
+		WithDeleteConfirmation(nil),
+	)
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyDelete})
+	require.True(t, table.ModalActive())
+
+	table, cmd := table.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	require.False(t, table.ModalActive())
+
+	table, cmd = table.Update(cmd())
+	require.Nil(t, collectMsgs(cmd))
+	require.Equal(t, 1, len(table.Rows()))
+}
+
+func TestDeleteConfirmationRowsChanged(t *testing.T) {
+	// Rows without metadata are identified by their data
+	newTable := func() Model {
+		table := New(
+			WithColumns([]Column{{Title: "Name", Width: 20}}),
+			WithRows([]Row{{Data: []string{"Tim Tams"}}, {Data: []string{"Hobnobs"}}, {Data: []string{"Penguins"}}}),
+			WithFocused(true),
+			WithDeleteConfirmation(nil),
+		)
+		table.SetCursor(1)
+
+		table, _ = table.Update(tea.KeyMsg{Type: tea.KeyDelete})
+		require.True(t, table.ModalActive())
+
+		return table
+	}
+
+	confirm := func(table Model) (Model, []tea.Msg) {
+		table, cmd := table.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+		table, cmd = table.Update(cmd())
+
+		return table, collectMsgs(cmd)
+	}
+
+	tests := []struct {
+		name     string
+		change   func(table *Model)
+		deleted  bool
+		expected []string
+	}{
+		{
+			name:     "row moved",
+			change:   func(table *Model) { table.SortBy(0, SortDescending, SortString) },
+			deleted:  true,
+			expected: []string{"Tim Tams", "Penguins"},
+		},
+		{
+			name:     "row removed",
+			change:   func(table *Model) { table.RemoveRowByIndex(1) },
+			expected: []string{"Tim Tams", "Penguins"},
+		},
+		{
+			name:     "row before removed",
+			change:   func(table *Model) { table.RemoveRowByIndex(0) },
+			deleted:  true,
+			expected: []string{"Penguins"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			table := newTable()
+			test.change(&table)
+
+			table, msgs := confirm(table)
+			require.ElementsMatch(t, test.expected, columnValues(table.Rows(), 0))
+
+			if !test.deleted {
+				require.Empty(t, msgs)
+				return
+			}
+
+			require.Len(t, msgs, 1)
+			require.Equal(t, "Hobnobs", msgs[0].(RowDeletedMsg).Row.Data[0])
+		})
+	}
+}
+
+func TestDeleteKeyDisabledByDefault(t *testing.T) {
+	table := New(WithStructData([]rowData{newRowData("Hobnobs", 10)}), WithFocused(true))
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyDelete})
+	require.False(t, table.ModalActive())
+}
+
+func TestDeleteConfirmationAnchoredAboveLastRow(t *testing.T) {
+	data := make([]rowData, 8)
+	for i := range data {
+		data[i] = newRowData("Biscuit "+strconv.Itoa(i), i)
+	}
+
+	table := New(WithStructData(data), WithFocused(true), WithWidth(60), WithHeight(8), WithDeleteConfirmation(nil))
+	table.GotoBottom()
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyDelete})
+
+	lines := strings.Split(table.View(), "\n")
+	rowLine := table.SelectedRowScreenY()
+	require.Contains(t, lines[rowLine], "Biscuit 7")
+
+	boxLine := -1
+	for i, l := range lines {
+		if strings.Contains(l, "Delete selected row?") {
+			boxLine = i
+		}
+	}
+
+	require.True(t, boxLine >= 0 && boxLine < rowLine)
+}
@@ -0,0 +1,248 @@
+package xtable
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// CellSetter is called when an edit to a cell is committed, to apply the new value to the row's metadata.
+// col is the index of the edited column. It returns the updated metadata, or an error if the value cannot
+// be applied, in which case the edit is not committed.
+type CellSetter func(meta Metadata, col int, value string) (Metadata, error)
+
+// CellEditedMsg is sent when an edit to a cell is committed.
+type CellEditedMsg struct {
+	// ID of the table that sent the message
+	TableID int
+
+	// The row containing the edited cell, after the edit
+	Row Row
+
+	// Metadata hash of the row, if it has metadata
+	Hash uint64
+
+	// Index of the edited column
+	Column int
+
+	// Cell value before and after the edit
+	OldValue string
+	NewValue string
+}
+
+// editState holds the state of inline cell editing.
+type editState struct {
+	// Whether editing is enabled
+	enabled bool
+
+	// Whether a cell is being edited
+	active bool
+
+	// Column being edited
+	col int
+
+	// Index and identity of the row being edited when the edit started
+	index int
+	row   rowIdentity
+
+	// Applies edits to row metadata, if set
+	setter CellSetter
+
+	// Editor for the cell
+	input textinput.Model
+
+	// Result of validating the last attempt to commit the edit
+	err error
+}
+
+// WithCellEditing enables inline editing of the cells of columns marked as Editable.
+// When the table is focused, pressing a key bound to KeyMap.Edit edits the first editable cell
+// of the selected row. KeyMap.AcceptEdit commits the edit and KeyMap.CancelEdit abandons it.
+//
+// If setter is not nil, it is called to apply a committed value to the row's metadata.
+// Edits are checked by any Validator set with WithValidator before being committed.
+func WithCellEditing(setter CellSetter) Option {
+	return func(m *Model) {
+		m.edit.enabled = true
+		m.edit.setter = setter
+	}
+}
+
+// setEditingEnabled enables or disables the key bindings for cell editing.
+func (km *KeyMap) setEditingEnabled(enabled bool) {
+	km.Edit.SetEnabled(enabled)
+	km.AcceptEdit.SetEnabled(enabled)
+	km.CancelEdit.SetEnabled(enabled)
+}
+
+// Editing returns true if a cell is being edited.
+func (m Model) Editing() bool {
+	return m.edit.active
+}
+
+// EditError returns the reason the last attempt to commit the current edit failed, or nil.
+func (m Model) EditError() error {
+	return m.edit.err
+}
+
+// StartEdit begins editing the cell of the selected row in column col. If col is negative,
+// the first editable column is edited. The returned command starts the editor's cursor blinking.
+// Nothing happens if editing is not enabled or the column is not editable.
+func (m *Model) StartEdit(col int) tea.Cmd {
+	if !m.edit.enabled || m.cursor < 0 || m.cursor >= len(m.rows) {
+		return nil
+	}
+
+	if col < 0 {
+		col = m.firstEditableColumn()
+	}
+
+	if col < 0 || col >= len(m.cols) || !m.cols[col].Editable {
+		return nil
+	}
+
+	input := textinput.New()
+	input.Prompt = ""
+	input.Width = max(m.cols[col].Width-1, 1)
+	input.SetValue(m.rows[m.cursor].Data[col])
+	input.CursorEnd()
+
+	m.edit.active = true
+	m.edit.col = col
+	m.edit.index = m.cursor
+	m.edit.row = identify(m.rows[m.cursor])
+	m.edit.err = nil
+	m.edit.input = input
+
+	cmd := m.edit.input.Focus()
+	m.UpdateViewport()
+
+	return cmd
+}
+
+// CancelEdit abandons the current edit.
+func (m *Model) CancelEdit() {
+	m.edit.active = false
+	m.edit.err = nil
+	m.UpdateViewport()
+}
+
+// updateEdit handles keys while a cell is being edited.
+func (m *Model) updateEdit(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.KeyMap.CancelEdit):
+		m.CancelEdit()
+		return nil
+
+	case key.Matches(msg, m.KeyMap.AcceptEdit):
+		return m.commitEdit()
+	}
+
+	var cmd tea.Cmd
+
+	m.edit.input, cmd = m.edit.input.Update(msg)
+	m.UpdateViewport()
+
+	return cmd
+}
+
+// commitEdit validates and applies the edited value to the row being edited, which may have moved since the edit
+// started, e.g. by a refresh. If the row has been removed or hidden by a filter, the edit is abandoned.
+// If the value is not valid, editing continues and the reason is available from EditError.
+func (m *Model) commitEdit() tea.Cmd {
+	ind := m.locateRow(m.edit.row, m.edit.index)
+	if ind == -1 || m.edit.col >= len(m.rows[ind].Data) {
+		m.CancelEdit()
+		return nil
+	}
+
+	col, value := m.edit.col, m.edit.input.Value()
+	old := m.rows[ind]
+
+	updated := Row{
+		Data:     make([]string, len(old.Data)),
+		Metadata: old.Metadata,
+	}
+	copy(updated.Data, old.Data)
+	updated.Data[col] = value
+
+	if m.edit.setter != nil {
+		meta, err := m.edit.setter(old.Metadata, col, value)
+		if err != nil {
+			m.edit.err = FieldError{Column: col, Err: err}
+			m.UpdateViewport()
+			return nil
+		}
+
+		updated.Metadata = meta
+
+		// Cells of struct data are converted from the updated metadata, as the rows were created,
+		// so that the edited value is formatted like the others
+		if m.schema != nil {
+			if r, err := m.rowFromFormValue(meta); err == nil {
+				updated.Data = r.Data
+			}
+		}
+	}
+
+	if err := m.ValidateRow(updated); err != nil {
+		m.edit.err = err
+		m.UpdateViewport()
+		return nil
+	}
+
+	m.edit.active = false
+	m.edit.err = nil
+	m.replaceRow(ind, updated)
+
+	msg := CellEditedMsg{
+		TableID:  m.id,
+		Row:      updated,
+		Hash:     rowHash(updated),
+		Column:   col,
+		OldValue: old.Data[col],
+		NewValue: value,
+	}
+
+	return func() tea.Msg {
+		return msg
+	}
+}
+
+// replaceRow replaces the visible row at index, and its counterpart in the complete set of rows if filtered.
+func (m *Model) replaceRow(index int, r Row) {
+	m.rows[index] = r
+
+	if m.filter.match != nil {
+		m.filter.all[m.filter.index[index]] = r
+	}
+
+	hash, hasHash := m.selectedHash()
+	m.refilter()
+	m.restoreCursor(hash, hasHash)
+}
+
+// firstEditableColumn returns the index of the first editable column, or -1 if there are none.
+func (m Model) firstEditableColumn() int {
+	for i, c := range m.cols {
+		if c.Editable {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// isEditing returns true if the given cell is being edited.
+func (m Model) isEditing(row, col int) bool {
+	return m.edit.active && row == m.cursor && col == m.edit.col
+}
+
+// editView renders the cell editor.
+func (m Model) editView() string {
+	if m.edit.err != nil {
+		return m.styles.Invalid.Render(m.edit.input.View())
+	}
+
+	return m.edit.input.View()
+}
@@ -0,0 +1,152 @@
+package xtable
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+)
+
+type editableRowData struct {
+	Name       string `xtable:"Name,editable"`
+	PacketSize int    `xtable:"Size,editable"`
+}
+
+func (r editableRowData) GetHashCode() uint64 {
+	return uint64(len(r.Name))
+}
+
+func typeKeys(table Model, s string) Model {
+	for _, r := range s {
+		table, _ = table.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+
+	return table
+}
+
+func newEditableTable(opts ...Option) Model {
+	return New(append([]Option{
+		WithFocused(true),
+		WithStructData([]editableRowData{{Name: "Hobnobs", PacketSize: 10}}),
+		WithCellEditing(func(meta Metadata, col int, value string) (Metadata, error) {
+			r := meta.(editableRowData)
+			switch col {
+			case 0:
+				r.Name = value
+			case 1:
+				n, err := strconv.Atoi(value)
+				if err != nil {
+					return nil, err
+				}
+				r.PacketSize = n
+			}
+
+			return r, nil
+		}),
+	}, opts...)...)
+}
+
+func TestCellEditing(t *testing.T) {
+	table := newEditableTable()
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyF2})
+	require.True(t, table.Editing())
+
+	table = typeKeys(table, " Dark")
+	table, cmd := table.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.False(t, table.Editing())
+	require.Equal(t, "Hobnobs Dark", table.SelectedRow().Data[0])
+	require.Equal(t, "Hobnobs Dark", table.SelectedRow().Metadata.(editableRowData).Name)
+
+	msgs := collectMsgs(cmd)
+	require.Equal(t, 1, len(msgs))
+	edited := msgs[0].(CellEditedMsg)
+	require.Equal(t, 0, edited.Column)
+	require.Equal(t, "Hobnobs", edited.OldValue)
+	require.Equal(t, "Hobnobs Dark", edited.NewValue)
+}
+
+func TestCellEditingCancel(t *testing.T) {
+	table := newEditableTable()
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	table = typeKeys(table, "xyz")
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	require.False(t, table.Editing())
+	require.Equal(t, "Hobnobs", table.SelectedRow().Data[0])
+}
+
+func TestCellEditingInvalid(t *testing.T) {
+	errTooBig := errors.New("too big")
+
+	table := newEditableTable(WithValidator(ValidatorFunc(func(r Row) error {
+		if r.Metadata.(editableRowData).PacketSize > 100 {
+			return ValidationErrors{{Column: 1, Err: errTooBig}}
+		}
+
+		return nil
+	})))
+
+	table.StartEdit(1)
+	require.True(t, table.Editing())
+
+	// Setter rejects value
+	table = typeKeys(table, "x")
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.True(t, table.Editing())
+	require.Error(t, table.EditError())
+
+	// Validator rejects value
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	table = typeKeys(table, "00")
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.True(t, table.Editing())
+	require.ErrorIs(t, table.EditError().(ValidationErrors)[0], errTooBig)
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.False(t, table.Editing())
+	require.Equal(t, "100", table.SelectedRow().Data[1])
+}
+
+func TestCellEditingDisabled(t *testing.T) {
+	table := New(
+		WithFocused(true),
+		WithStructData([]editableRowData{{Name: "Hobnobs", PacketSize: 10}}),
+	)
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.False(t, table.Editing())
+	require.Nil(t, table.StartEdit(0))
+}
+
+func TestCellEditingRowRemoved(t *testing.T) {
+	table := newEditableTable()
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyF2})
+	table = typeKeys(table, " Dark")
+	require.False(t, table.RemoveSelectedRow())
+
+	table, cmd := table.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.False(t, table.Editing())
+	require.Nil(t, collectMsgs(cmd))
+	require.Empty(t, table.AllRows())
+}
+
+func TestCellEditingRowMoved(t *testing.T) {
+	table := newEditableTable()
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyF2})
+	table = typeKeys(table, " Dark")
+
+	// A row inserted before the edited row, e.g. by a streamed update, moves it down
+	biscuit := editableRowData{Name: "Tim Tams", PacketSize: 8}
+	table.rows = append([]Row{{Data: []string{biscuit.Name, "8"}, Metadata: biscuit}}, table.rows...)
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.False(t, table.Editing())
+	require.Equal(t, []string{"Tim Tams", "Hobnobs Dark"}, columnValues(table.Rows(), 0))
+}
@@ -0,0 +1,138 @@
+package xtable
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// RowAddedMsg is sent when a row is added to the table.
+type RowAddedMsg struct {
+	// ID of the table that sent the message
+	TableID int
+
+	// The added row
+	Row Row
+
+	// Metadata hash of the added row, if it has metadata
+	Hash uint64
+}
+
+// RowRemovedMsg is sent when a row is removed from the table.
+type RowRemovedMsg struct {
+	// ID of the table that sent the message
+	TableID int
+
+	// The removed row
+	Row Row
+
+	// Metadata hash of the removed row, if it has metadata
+	Hash uint64
+}
+
+// RowsReplacedMsg is sent when all rows of the table are replaced, e.g. by SetRows.
+type RowsReplacedMsg struct {
+	// ID of the table that sent the message
+	TableID int
+
+	// Number of rows now in the table
+	Rows int
+}
+
+// SortChangedMsg is sent when the table is sorted, or its sort is cleared, if enabled by WithSortEvents.
+type SortChangedMsg struct {
+	// ID of the table that sent the message
+	TableID int
+
+	// Column and order of the sort, or of its first column if sorted by several. Column is -1
+	// if the sort was cleared.
+	Column int
+	Order  SortOrder
+
+	// All columns of the sort, in priority order, as returned by SortState
+	Columns []SortColumn
+}
+
+// WithRowEvents enables row lifecycle messages. When enabled, RowAddedMsg, RowRemovedMsg and
+// RowsReplacedMsg are queued by the methods that add, remove and replace rows, and are delivered
+// by the command returned from the next call to Update or FlushEvents.
+func WithRowEvents() Option {
+	return func(m *Model) {
+		m.rowEvents = true
+	}
+}
+
+// ID returns the unique ID of the table, which identifies it in the messages it sends.
+func (m Model) ID() int {
+	return m.id
+}
+
+// FlushEvents returns a command that delivers, in order, the row lifecycle and sort messages queued since the
+// last flush, or nil if there are none. Update does this automatically, so this need only be called
+// after modifying the table outside of its Update method, e.g. in the owning model's Update.
+func (m *Model) FlushEvents() tea.Cmd {
+	if len(m.events) == 0 {
+		return nil
+	}
+
+	cmds := make([]tea.Cmd, len(m.events))
+	for i, ev := range m.events {
+		ev := ev
+		cmds[i] = func() tea.Msg {
+			return ev
+		}
+	}
+
+	m.events = nil
+
+	return tea.Sequence(cmds...)
+}
+
+// WithSortEvents enables SortChangedMsg, so that the owning model can persist the sort or show it elsewhere
+// without polling the table. As for row events, the message is queued when the table is sorted and delivered
+// by the command returned from the next call to Update or FlushEvents.
+func WithSortEvents() Option {
+	return func(m *Model) {
+		m.sortEvents = true
+	}
+}
+
+// emitSortChanged queues SortChangedMsg for the current sort, if enabled.
+func (m *Model) emitSortChanged() {
+	if !m.sortEvents {
+		return
+	}
+
+	msg := SortChangedMsg{TableID: m.id, Column: -1, Columns: m.SortState()}
+
+	if index, order, ok := m.SortedBy(); ok {
+		msg.Column = index
+		msg.Order = order
+	}
+
+	m.events = append(m.events, msg)
+}
+
+// emit queues a row lifecycle message, if enabled.
+func (m *Model) emit(msg tea.Msg) {
+	if m.rowEvents {
+		m.events = append(m.events, msg)
+	}
+}
+
+// emitRowAdded queues a RowAddedMsg.
+func (m *Model) emitRowAdded(r Row) {
+	m.emit(RowAddedMsg{TableID: m.id, Row: r, Hash: rowHash(r)})
+}
+
+// emitRowRemoved queues a RowRemovedMsg.
+func (m *Model) emitRowRemoved(r Row) {
+	m.emit(RowRemovedMsg{TableID: m.id, Row: r, Hash: rowHash(r)})
+}
+
+// rowHash returns the metadata hash of a row, or zero if it has no metadata.
+func rowHash(r Row) uint64 {
+	if r.Metadata == nil {
+		return 0
+	}
+
+	return r.Metadata.GetHashCode()
+}
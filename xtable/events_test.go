@@ -0,0 +1,91 @@
+package xtable
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+)
+
+// collectMsgs executes a command, expanding sequences and batches, and returns the resulting messages.
+func collectMsgs(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+
+	msg := cmd()
+	if msg == nil {
+		return nil
+	}
+
+	// tea.BatchMsg and tea's unexported sequenceMsg are both slices of tea.Cmd
+	v := reflect.ValueOf(msg)
+	if v.Kind() == reflect.Slice && v.Type().Elem() == reflect.TypeOf(tea.Cmd(nil)) {
+		msgs := []tea.Msg{}
+		for i := 0; i < v.Len(); i++ {
+			msgs = append(msgs, collectMsgs(v.Index(i).Interface().(tea.Cmd))...)
+		}
+
+		return msgs
+	}
+
+	return []tea.Msg{msg}
+}
+
+func TestRowEvents(t *testing.T) {
+	hobnobs := newRowData("Hobnobs", 10)
+
+	table := New(
+		WithRowEvents(),
+		WithStructData([]rowData{
+			newRowData("Chocolate Digestives", 12),
+			newRowData("Tim Tams", 8),
+			hobnobs,
+		}),
+	)
+
+	require.True(t, table.RemoveRow(hobnobs))
+	table.SetRows([]Row{})
+
+	msgs := collectMsgs(table.FlushEvents())
+	require.Equal(t, []tea.Msg{
+		RowRemovedMsg{TableID: table.ID(), Row: Row{Data: []string{"Hobnobs", "10"}, Metadata: hobnobs}, Hash: hobnobs.GetHashCode()},
+		RowsReplacedMsg{TableID: table.ID(), Rows: 0},
+	}, msgs)
+
+	// Queue is drained
+	require.Nil(t, table.FlushEvents())
+}
+
+func TestRowEventsDisabled(t *testing.T) {
+	table := New(WithStructData([]rowData{newRowData("Tim Tams", 8)}))
+	table.RemoveSelectedRow()
+	require.Nil(t, table.FlushEvents())
+}
+
+func TestSortEvents(t *testing.T) {
+	table := New(
+		WithSortEvents(),
+		WithFocused(true),
+		WithQuickSortKeys(),
+		WithStructData([]rowData{newRowData("Tim Tams", 8), newRowData("Hobnobs", 10)}),
+	)
+
+	_, cmd := table.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}, Alt: true})
+	require.Equal(t, []tea.Msg{
+		SortChangedMsg{
+			TableID: table.ID(),
+			Column:  1,
+			Order:   SortDescending,
+			Columns: []SortColumn{{Index: 1, Order: SortDescending, TypeHint: SortNumeric}},
+		},
+	}, collectMsgs(cmd))
+
+	table.SortBy(0, SortAscending, SortString)
+	table.ClearSort()
+
+	msgs := collectMsgs(table.FlushEvents())
+	require.Len(t, msgs, 2)
+	require.Equal(t, SortChangedMsg{TableID: table.ID(), Column: -1}, msgs[1])
+}
@@ -0,0 +1,257 @@
+package xtable
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ExportScope selects the rows written by the exporters.
+type ExportScope int
+
+const (
+	// ExportVisible exports the rows currently shown, i.e. those matching any active filter.
+	ExportVisible ExportScope = iota
+
+	// ExportAll exports all rows, including any hidden by a filter.
+	ExportAll
+
+	// ExportSelected exports the rows marked with multi-select (see WithMultiSelect),
+	// or the selected row if none are marked.
+	ExportSelected
+)
+
+type exportOptions struct {
+	scope ExportScope
+}
+
+// ExportOption is used to set options in the exporters.
+type ExportOption func(*exportOptions)
+
+// WithExportScope sets which rows are exported. The default is ExportVisible.
+func WithExportScope(s ExportScope) ExportOption {
+	return func(o *exportOptions) {
+		o.scope = s
+	}
+}
+
+// ExportCSV writes the table as CSV, with a header line of column titles.
+// The row number column is not exported.
+func (m Model) ExportCSV(w io.Writer, opts ...ExportOption) error {
+	cols, rows := m.exportData(opts)
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(m.exportTitles(cols)); err != nil {
+		return err
+	}
+
+	for _, r := range rows {
+		if err := cw.Write(exportCells(r, cols)); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// ExportJSON writes the table as a JSON array with an object for each row, whose keys are the
+// column titles in column order. The row number column is not exported.
+func (m Model) ExportJSON(w io.Writer, opts ...ExportOption) error {
+	cols, rows := m.exportData(opts)
+	titles := m.exportTitles(cols)
+	bw := bufio.NewWriter(w)
+
+	bw.WriteString("[")
+
+	for i, r := range rows {
+		if i > 0 {
+			bw.WriteString(",")
+		}
+
+		bw.WriteString("\n  ")
+		bw.Write(exportObject(titles, exportCells(r, cols)))
+	}
+
+	if len(rows) > 0 {
+		bw.WriteString("\n")
+	}
+
+	bw.WriteString("]\n")
+
+	return bw.Flush()
+}
+
+// ToJSON returns the table as a JSON array, in the order the rows are shown. Each row is written as the JSON
+// encoding of its Metadata, e.g. the struct or map it was created from, or if it has none, as an object
+// whose keys are the column titles, as written by ExportJSON. By default, only the rows shown by any active
+// filter are included; pass WithExportScope(ExportAll) for all rows.
+// An error is returned if the Metadata of a row cannot be encoded.
+func (m Model) ToJSON(opts ...ExportOption) ([]byte, error) {
+	cols, rows := m.exportData(opts)
+	titles := m.exportTitles(cols)
+	values := make([]json.RawMessage, len(rows))
+
+	for i, r := range rows {
+		if r.Metadata == nil {
+			values[i] = exportObject(titles, exportCells(r, cols))
+			continue
+		}
+
+		v, err := json.Marshal(r.Metadata)
+		if err != nil {
+			return nil, err
+		}
+
+		values[i] = v
+	}
+
+	return json.Marshal(values)
+}
+
+// ExportMarkdown writes the table as a Markdown (GitHub flavoured) table, with column alignment following
+// the alignment of the table's columns. The row number column is not exported.
+func (m Model) ExportMarkdown(w io.Writer, opts ...ExportOption) error {
+	cols, rows := m.exportData(opts)
+	bw := bufio.NewWriter(w)
+
+	writeLine := func(cells []string) {
+		bw.WriteString("|")
+
+		for _, c := range cells {
+			bw.WriteString(" " + markdownEscape(c) + " |")
+		}
+
+		bw.WriteString("\n")
+	}
+
+	writeLine(m.exportTitles(cols))
+
+	bw.WriteString("|")
+
+	for _, c := range cols {
+		switch m.cols[c].Align {
+		case lipgloss.Right:
+			bw.WriteString(" ---: |")
+		case lipgloss.Center:
+			bw.WriteString(" :---: |")
+		default:
+			bw.WriteString(" --- |")
+		}
+	}
+
+	bw.WriteString("\n")
+
+	for _, r := range rows {
+		writeLine(exportCells(r, cols))
+	}
+
+	return bw.Flush()
+}
+
+// ToMarkdown returns the table as a Markdown table, as written by ExportMarkdown, e.g. for copying
+// to the clipboard or pasting into an issue. By default, only the rows shown by any active filter are included;
+// pass WithExportScope(ExportAll) for all rows.
+func (m Model) ToMarkdown(opts ...ExportOption) string {
+	var b strings.Builder
+
+	// Writing to a strings.Builder cannot fail
+	_ = m.ExportMarkdown(&b, opts...)
+
+	return b.String()
+}
+
+// exportData returns the indexes of the exported columns, and the rows in the requested scope.
+func (m Model) exportData(opts []ExportOption) ([]int, []Row) {
+	o := &exportOptions{}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	cols := make([]int, 0, len(m.cols))
+
+	for i := range m.cols {
+		if i == 0 && m.rowNumbers {
+			continue
+		}
+
+		cols = append(cols, i)
+	}
+
+	switch o.scope {
+	case ExportAll:
+		return cols, m.AllRows()
+	case ExportSelected:
+		if marked := m.MarkedRows(); len(marked) > 0 {
+			return cols, marked
+		}
+
+		if m.cursor >= 0 && m.cursor < len(m.rows) {
+			return cols, []Row{m.rows[m.cursor]}
+		}
+
+		return cols, nil
+	}
+
+	return cols, m.rows
+}
+
+// exportTitles returns the titles of the exported columns.
+func (m Model) exportTitles(cols []int) []string {
+	titles := make([]string, len(cols))
+
+	for i, c := range cols {
+		titles[i] = m.cols[c].Title
+	}
+
+	return titles
+}
+
+// exportCells returns the values of the exported columns of a row.
+func exportCells(r Row, cols []int) []string {
+	cells := make([]string, len(cols))
+
+	for i, c := range cols {
+		if c < len(r.Data) {
+			cells[i] = r.Data[c]
+		}
+	}
+
+	return cells
+}
+
+// exportObject returns a JSON object of the values of a row, whose keys are the column titles in column order.
+func exportObject(titles, cells []string) []byte {
+	var b bytes.Buffer
+
+	b.WriteString("{")
+
+	for i, cell := range cells {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+
+		// Marshalling a string cannot fail
+		k, _ := json.Marshal(titles[i])
+		v, _ := json.Marshal(cell)
+		b.Write(k)
+		b.WriteString(": ")
+		b.Write(v)
+	}
+
+	b.WriteString("}")
+
+	return b.Bytes()
+}
+
+// markdownEscape escapes characters that would break a Markdown table cell.
+func markdownEscape(s string) string {
+	return strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>").Replace(s)
+}
@@ -0,0 +1,99 @@
+package xtable
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func exportTable() Model {
+	return New(
+		WithStructData([]rowData{
+			newRowData("Chocolate Digestives", 12),
+			newRowData("Tim Tams", 8),
+			newRowData("Hob|nobs", 10),
+		}),
+		WithRowNumbers(),
+		WithMultiSelect(),
+	)
+}
+
+func TestExportCSV(t *testing.T) {
+	table := exportTable()
+
+	var b bytes.Buffer
+	require.NoError(t, table.ExportCSV(&b))
+	require.Equal(t, "Name,PacketSize\nChocolate Digestives,12\nTim Tams,8\nHob|nobs,10\n", b.String())
+}
+
+func TestExportJSON(t *testing.T) {
+	table := exportTable()
+	table.SetCursor(1)
+
+	var b bytes.Buffer
+	require.NoError(t, table.ExportJSON(&b, WithExportScope(ExportSelected)))
+	require.Equal(t, "[\n  {\"Name\": \"Tim Tams\", \"PacketSize\": \"8\"}\n]\n", b.String())
+}
+
+func TestExportMarkdownMarkedRows(t *testing.T) {
+	table := exportTable()
+	table.SetCursor(2)
+	require.True(t, table.ToggleMark())
+	table.SetCursor(0)
+	require.True(t, table.ToggleMark())
+
+	var b bytes.Buffer
+	require.NoError(t, table.ExportMarkdown(&b, WithExportScope(ExportSelected)))
+	require.Equal(t, "| Name | PacketSize |\n| --- | ---: |\n| Chocolate Digestives | 12 |\n| Hob\\|nobs | 10 |\n", b.String())
+}
+
+func TestExportScopes(t *testing.T) {
+	table := exportTable()
+	table.filter.match = table.textMatcher("tim")
+	table.filter.all = table.rows
+	table.refilter()
+
+	_, rows := table.exportData(nil)
+	require.Len(t, rows, 1)
+
+	_, rows = table.exportData([]ExportOption{WithExportScope(ExportAll)})
+	require.Len(t, rows, 3)
+}
+
+func TestToMarkdown(t *testing.T) {
+	table := exportTable()
+	table.filter.match = table.textMatcher("tim")
+	table.filter.all = table.rows
+	table.refilter()
+
+	require.Equal(t, "| Name | PacketSize |\n| --- | ---: |\n| Tim Tams | 8 |\n", table.ToMarkdown())
+	require.Contains(t, table.ToMarkdown(WithExportScope(ExportAll)), "| Hob\\|nobs | 10 |\n")
+}
+
+func TestToJSON(t *testing.T) {
+	table := exportTable()
+	table.SortBy(2, SortDescending, SortNumeric)
+
+	b, err := table.ToJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+		{"Name": "Chocolate Digestives", "PacketSize": 12},
+		{"Name": "Hob|nobs", "PacketSize": 10},
+		{"Name": "Tim Tams", "PacketSize": 8}
+	]`, string(b))
+
+	table = New(WithColumns([]Column{{Title: "Foo", Width: 10}, {Title: "Bar", Width: 10}}))
+	table.SetRows([]Row{{Data: []string{"a", "1"}}, {Data: []string{"b", "2"}, Metadata: MapRow{"foo": "b"}}})
+	table.filter.match = table.textMatcher("b")
+	table.filter.all = table.rows
+	table.refilter()
+
+	b, err = table.ToJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"foo": "b"}]`, string(b))
+
+	b, err = table.ToJSON(WithExportScope(ExportAll))
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"Foo": "a", "Bar": "1"}, {"foo": "b"}]`, string(b))
+}
@@ -0,0 +1,505 @@
+package xtable
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DefaultFilterDebounce is the default time to wait after the last change
+// to the filter text before the filter is evaluated.
+const DefaultFilterDebounce = 150 * time.Millisecond
+
+// filterCancelCheckInterval is the number of rows matched between checks
+// for cancellation of a background filter run.
+const filterCancelCheckInterval = 1024
+
+// filterState holds the state of row filtering.
+type filterState struct {
+	// Current filter text
+	text string
+
+	// Predicate for the active filter, or nil when no filter is active
+	match func(Row) bool
+
+	// Complete set of rows while a filter is active
+	all []Row
+
+	// Index into all of each visible row
+	index []int
+
+	// Incremented when the filter text changes, so stale results can be discarded
+	seq int
+
+	// Incremented when the complete set of rows changes, so results computed
+	// against an earlier set of rows can be detected
+	gen int
+
+	// Cancels the background filter run, if any
+	cancel context.CancelFunc
+
+	// Debounce interval. Zero means DefaultFilterDebounce
+	debounce time.Duration
+
+	// Whether the text is matched case sensitively, as when promoted from Find
+	caseSensitive bool
+
+	// Whether the text is matched exactly against the value of one column, set by SetColumnFilter
+	byColumn bool
+	column   int
+
+	// Predicate set by Filter, which rows must also satisfy
+	predicate func(Row) bool
+
+	// Whether the text is matched fuzzily, set by WithFuzzyFilter
+	fuzzy bool
+
+	// Regular expression the text was compiled to, when promoted from FindRegexp
+	regexp *regexp.Regexp
+
+	// Whether the text is only matched in column, when promoted from FindInColumn
+	inColumn bool
+}
+
+// filterDebounceMsg fires when the filter text has not changed for the debounce interval.
+type filterDebounceMsg struct {
+	id  int
+	seq int
+}
+
+// filterResultMsg carries the result of a background filter run.
+type filterResultMsg struct {
+	id    int
+	seq   int
+	gen   int
+	match func(Row) bool
+	index []int
+}
+
+// WithFilterDebounce sets the time to wait after the last change to the filter text
+// before the filter is evaluated. The default is DefaultFilterDebounce.
+func WithFilterDebounce(d time.Duration) Option {
+	return func(m *Model) {
+		m.filter.debounce = d
+	}
+}
+
+// SetFilterText sets the text used to filter the rows. Only rows containing the text
+// in any cell (case insensitive) are shown. Pass an empty string to remove the filter.
+//
+// The filter is not applied immediately. Evaluation is debounced so that it can be called
+// on every keystroke of a filter input, and the rows are then matched in a background command
+// so that the UI remains responsive with very large tables. Any run made stale by a subsequent
+// change to the filter text is cancelled. The returned command must be returned to Bubble Tea,
+// and the resulting messages passed to Update.
+func (m *Model) SetFilterText(text string) tea.Cmd {
+	m.filter.text = text
+	m.filter.caseSensitive = false
+	m.filter.byColumn = false
+	m.filter.inColumn = false
+	m.filter.regexp = nil
+	m.filter.seq++
+	m.findPromoted = false
+	m.cancelFilterRun()
+
+	if text == "" {
+		m.applyMatcher()
+		return nil
+	}
+
+	debounce := m.filter.debounce
+	if debounce <= 0 {
+		debounce = DefaultFilterDebounce
+	}
+
+	id, seq := m.id, m.filter.seq
+
+	return tea.Tick(debounce, func(time.Time) tea.Msg {
+		return filterDebounceMsg{id: id, seq: seq}
+	})
+}
+
+// FilterText returns the current filter text.
+func (m Model) FilterText() string {
+	return m.filter.text
+}
+
+// SetColumnFilter filters the rows to those whose value in the column at the given index is exactly value,
+// e.g. the value in the selected row. As with SetFilterText, the filter is evaluated in the background,
+// and the returned command must be returned to Bubble Tea. Pass an empty value to remove the filter.
+func (m *Model) SetColumnFilter(index int, value string) tea.Cmd {
+	cmd := m.SetFilterText(value)
+
+	if value != "" {
+		m.filter.byColumn = true
+		m.filter.column = index
+	}
+
+	return cmd
+}
+
+// FilterColumn returns the index of the column filtered by SetColumnFilter, and false if the rows are
+// not filtered by a column.
+func (m Model) FilterColumn() (int, bool) {
+	return m.filter.column, m.filter.byColumn
+}
+
+// Filter hides the rows for which the predicate returns false, e.g. to show only errors, while keeping
+// all rows in the table for when the filter is cleared. Unlike SetFilterText, the filter is applied
+// immediately. If filter text is also set, rows must match both. The predicate is re-evaluated as rows
+// are added or replaced. Pass nil, or call ClearFilter, to remove it.
+func (m *Model) Filter(predicate func(Row) bool) {
+	m.filter.predicate = predicate
+
+	// Any filter run in progress was started with the previous predicate
+	m.filter.seq++
+	m.cancelFilterRun()
+
+	m.applyMatcher()
+}
+
+// ClearFilter removes the predicate set by Filter. Any filter text remains in effect.
+func (m *Model) ClearFilter() {
+	m.Filter(nil)
+}
+
+// applyMatcher immediately filters the rows by the current filter text and predicate, or shows all rows
+// if there is neither.
+func (m *Model) applyMatcher() {
+	match := m.matcher()
+	if match == nil {
+		m.removeFilter()
+		return
+	}
+
+	// Rows without metadata are followed by position
+	hash, hasHash := m.selectedHash()
+	selected := m.allIndex(m.cursor)
+
+	if m.filter.match == nil {
+		m.filter.all = m.rows
+	}
+
+	m.filter.match = match
+	m.filter.gen++
+	m.filter.index, _ = matchRows(context.Background(), m.filter.all, match)
+	m.filter.index = m.withPinned(m.filter.index)
+	m.rows = pickRows(m.filter.all, m.filter.index)
+
+	m.selectPosition(selected, hasHash)
+	m.restoreCursor(hash, hasHash)
+	m.rowsChanged()
+}
+
+// IsFiltered returns true if a filter is currently applied to the rows.
+func (m Model) IsFiltered() bool {
+	return m.filter.match != nil
+}
+
+// filterCmd returns a command to match the rows against the current filter text in the background.
+func (m *Model) filterCmd(seq int) tea.Cmd {
+	if seq != m.filter.seq || m.filter.text == "" {
+		// Filter text has since changed
+		return nil
+	}
+
+	m.cancelFilterRun()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.filter.cancel = cancel
+
+	// The rows are copied, as sorting and removing rows reorder the table's slice in place while the filter runs
+	id, gen, rows := m.id, m.filter.gen, append([]Row(nil), m.AllRows()...)
+	match := m.matcher()
+
+	return func() tea.Msg {
+		index, err := matchRows(ctx, rows, match)
+		if err != nil {
+			// Cancelled by a later change
+			return nil
+		}
+
+		return filterResultMsg{id: id, seq: seq, gen: gen, match: match, index: index}
+	}
+}
+
+// applyFilterResult applies the result of a background filter run, unless it is stale.
+func (m *Model) applyFilterResult(msg filterResultMsg) {
+	if msg.seq != m.filter.seq {
+		return
+	}
+
+	m.filter.cancel = nil
+
+	// Rows without metadata are followed by position
+	hash, hasHash := m.selectedHash()
+	selected := m.allIndex(m.cursor)
+
+	if m.filter.match == nil {
+		m.filter.all = m.rows
+	}
+
+	m.filter.match = msg.match
+
+	if msg.gen == m.filter.gen {
+		m.filter.index = msg.index
+	} else {
+		// Rows changed while the filter was running
+		m.filter.index, _ = matchRows(context.Background(), m.filter.all, m.filter.match)
+	}
+
+	m.filter.index = m.withPinned(m.filter.index)
+	m.rows = pickRows(m.filter.all, m.filter.index)
+
+	m.selectPosition(selected, hasHash)
+	m.restoreCursor(hash, hasHash)
+	m.rowsChanged()
+}
+
+// selectPosition moves the cursor to the row at the given index in the complete set of rows, if it is visible,
+// unless the selected row had metadata, in which case it is restored by hash.
+func (m *Model) selectPosition(selected int, hasHash bool) {
+	if hasHash || selected < 0 {
+		return
+	}
+
+	if i := m.visibleIndex(selected); i >= 0 {
+		m.cursor = i
+	}
+}
+
+// removeFilter shows all rows.
+func (m *Model) removeFilter() {
+	if m.filter.match == nil {
+		return
+	}
+
+	hash, hasHash := m.selectedHash()
+	selected := m.allIndex(m.cursor)
+
+	m.rows = m.filter.all
+	m.filter.all = nil
+	m.filter.index = nil
+	m.filter.match = nil
+	m.filter.gen++
+
+	m.selectPosition(selected, hasHash)
+	m.restoreCursor(hash, hasHash)
+	m.rowsChanged()
+}
+
+// restoreCursor moves the cursor to the row followed by FollowRow, or back to the row with the given hash
+// following a change in the visible rows, or clamps it to the available rows, and scrolls it into view.
+func (m *Model) restoreCursor(hash uint64, hasHash bool) {
+	switch {
+	case m.follow.active && m.selectHash(m.follow.hash):
+	case hasHash && m.selectHash(hash):
+	default:
+		m.cursor = clamp(m.cursor, 0, len(m.rows)-1)
+	}
+
+	m.RenumberRows()
+	m.UpdateViewport()
+	m.scrollToCursor()
+}
+
+// scrollToCursor scrolls the viewport, if necessary, so that the selected row is visible.
+func (m *Model) scrollToCursor() {
+	line := m.cursor - m.start
+
+	switch {
+	case line < m.viewport.YOffset:
+		m.viewport.SetYOffset(line)
+	case line >= m.viewport.YOffset+m.viewport.Height:
+		m.viewport.SetYOffset(line - m.viewport.Height + 1)
+	}
+}
+
+// allIndex converts the index of a visible row to its index in the complete set of rows.
+// It returns -1 if the index is out of range.
+func (m Model) allIndex(i int) int {
+	if i < 0 || i >= len(m.rows) {
+		return -1
+	}
+
+	if m.filter.match != nil {
+		return m.filter.index[i]
+	}
+
+	return i
+}
+
+// visibleIndex converts an index in the complete set of rows to the index of the visible row.
+// It returns -1 if the row is hidden by a filter.
+func (m Model) visibleIndex(i int) int {
+	if m.filter.match == nil {
+		return i
+	}
+
+	if j := sort.SearchInts(m.filter.index, i); j < len(m.filter.index) && m.filter.index[j] == i {
+		return j
+	}
+
+	return -1
+}
+
+// cancelFilterRun cancels any background filter run.
+func (m *Model) cancelFilterRun() {
+	if m.filter.cancel != nil {
+		m.filter.cancel()
+		m.filter.cancel = nil
+	}
+}
+
+// setAllRows replaces the complete set of rows, re-applying any active filter.
+func (m *Model) setAllRows(rows []Row) {
+	if m.filter.match != nil {
+		m.filter.all = rows
+	} else {
+		m.rows = rows
+	}
+
+	// The replacement rows are in their original order
+	m.unsorted = nil
+
+	m.emit(RowsReplacedMsg{TableID: m.id, Rows: len(rows)})
+	m.refilter()
+}
+
+// refilter must be called whenever the complete set of rows is modified.
+// It recomputes any computed columns, moves pinned rows to the top, and synchronously re-evaluates
+// the visible rows if a filter is active.
+func (m *Model) refilter() {
+	m.filter.gen++
+	m.computeCells(m.AllRows())
+	m.pinToTop()
+
+	if m.filter.match != nil {
+		m.filter.index, _ = matchRows(context.Background(), m.filter.all, m.filter.match)
+		m.filter.index = m.withPinned(m.filter.index)
+		m.rows = pickRows(m.filter.all, m.filter.index)
+	}
+
+	m.rowsChanged()
+}
+
+// matcher returns a predicate for the current filter text combined with the predicate set by Filter,
+// or nil if neither is set.
+func (m Model) matcher() func(Row) bool {
+	var text func(Row) bool
+
+	switch {
+	case m.filter.text == "":
+	case m.filter.byColumn:
+		text = columnMatcher(m.filter.column, m.filter.text)
+	default:
+		text = m.textMatcher(m.filter.text)
+	}
+
+	predicate := m.filter.predicate
+
+	switch {
+	case text == nil:
+		return predicate
+	case predicate == nil:
+		return text
+	}
+
+	return func(r Row) bool {
+		return predicate(r) && text(r)
+	}
+}
+
+// columnMatcher returns a predicate that matches rows whose value in the given column is the given text.
+func columnMatcher(col int, text string) func(Row) bool {
+	return func(r Row) bool {
+		return col < len(r.Data) && r.Data[col] == text
+	}
+}
+
+// textMatcher returns a predicate that matches rows containing the given text
+// in any cell, ignoring case unless promoted from Find, or fuzzily if enabled by WithFuzzyFilter.
+// Text promoted from FindRegexp is matched as a regular expression, and text promoted from FindInColumn
+// only in that column. The row number column is not considered.
+func (m Model) textMatcher(text string) func(Row) bool {
+	switch {
+	case m.filter.regexp != nil:
+		return m.cellMatcher(m.filter.regexp.MatchString)
+	case m.filter.caseSensitive:
+		return m.cellMatcher(func(cell string) bool {
+			return strings.Contains(cell, text)
+		})
+	case m.filter.fuzzy:
+		return m.fuzzyMatcher(text)
+	}
+
+	text = strings.ToLower(text)
+
+	return m.cellMatcher(func(cell string) bool {
+		return strings.Contains(strings.ToLower(cell), text)
+	})
+}
+
+// cellMatcher returns a predicate that matches rows with any cell for which match returns true,
+// or if the filter is promoted from FindInColumn, the cell in that column. The row number column is not considered.
+func (m Model) cellMatcher(match func(cell string) bool) func(Row) bool {
+	if m.filter.inColumn {
+		col := m.filter.column
+
+		return func(r Row) bool {
+			return col < len(r.Data) && match(r.Data[col])
+		}
+	}
+
+	skipRowNumbers := m.rowNumbers
+
+	return func(r Row) bool {
+		for i := range r.Data {
+			// The row number cell isn't read, as it is renumbered in place while a filter runs in the background
+			if i == 0 && skipRowNumbers {
+				continue
+			}
+
+			if match(r.Data[i]) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// matchRows returns the indexes of the rows matching the predicate.
+// An error is returned if the context is cancelled.
+func matchRows(ctx context.Context, rows []Row, match func(Row) bool) ([]int, error) {
+	index := []int{}
+
+	for i, r := range rows {
+		if i%filterCancelCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+
+		if match(r) {
+			index = append(index, i)
+		}
+	}
+
+	return index, nil
+}
+
+// pickRows returns the rows at the given indexes.
+func pickRows(rows []Row, index []int) []Row {
+	picked := make([]Row, len(index))
+
+	for i, ind := range index {
+		picked[i] = rows[ind]
+	}
+
+	return picked
+}
@@ -0,0 +1,182 @@
+package xtable
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+)
+
+// runFilter drives the debounced filter through to completion,
+// as Bubble Tea would.
+func runFilter(t *testing.T, table Model, cmd tea.Cmd) Model {
+	t.Helper()
+	require.NotNil(t, cmd)
+
+	table, cmd = table.Update(cmd())
+	require.NotNil(t, cmd)
+
+	table, _ = table.Update(cmd())
+	return table
+}
+
+func TestSetFilterText(t *testing.T) {
+	table := New(
+		WithFilterDebounce(time.Millisecond),
+		WithStructData([]rowData{
+			newRowData("Chocolate Digestives", 12),
+			newRowData("Tim Tams", 8),
+			newRowData("Hobnobs", 10),
+			newRowData("Peanut Butter Cookie", 8),
+		}),
+	)
+
+	table.SetCursor(2)
+
+	table = runFilter(t, table, table.SetFilterText("OB"))
+	require.True(t, table.IsFiltered())
+	require.Equal(t, 1, len(table.Rows()))
+	require.Equal(t, 4, len(table.AllRows()))
+
+	// Selection follows the row
+	require.Equal(t, 0, table.Cursor())
+	require.Equal(t, "Hobnobs", table.SelectedRow().Data[0])
+
+	// Removing the filter restores all rows and selection
+	require.Nil(t, table.SetFilterText(""))
+	require.False(t, table.IsFiltered())
+	require.Equal(t, 4, len(table.Rows()))
+	require.Equal(t, 2, table.Cursor())
+}
+
+func TestSetFilterTextDiscardsStaleRuns(t *testing.T) {
+	table := New(
+		WithFilterDebounce(time.Millisecond),
+		WithStructData([]rowData{
+			newRowData("Chocolate Digestives", 12),
+			newRowData("Tim Tams", 8),
+			newRowData("Hobnobs", 10),
+		}),
+	)
+
+	stale := table.SetFilterText("Tim")
+	current := table.SetFilterText("Hob")
+
+	// Debounce for the superseded text does nothing
+	table, cmd := table.Update(stale())
+	require.Nil(t, cmd)
+	require.False(t, table.IsFiltered())
+
+	table = runFilter(t, table, current)
+	require.Equal(t, 1, len(table.Rows()))
+	require.Equal(t, "Hobnobs", table.Rows()[0].Data[0])
+}
+
+func TestSortWhileFiltering(t *testing.T) {
+	data := make([]rowData, 2000)
+	for i := range data {
+		data[i] = newRowData("Biscuit "+strconv.Itoa(i), i%50)
+	}
+
+	table := New(WithFilterDebounce(time.Millisecond), WithStructData(data), WithRowNumbers())
+
+	table, cmd := table.Update(table.SetFilterText("Biscuit 1")())
+	require.NotNil(t, cmd)
+
+	// Run the filter in the background, as Bubble Tea does, while the table is sorted
+	result := make(chan tea.Msg)
+	go func() {
+		result <- cmd()
+	}()
+
+	for i := 0; i < 10; i++ {
+		table.SortBy(2, SortOrder(i%2 == 1), SortNumeric)
+	}
+
+	table, cmd = table.Update(<-result)
+	if cmd != nil {
+		table = runFilter(t, table, cmd)
+	}
+
+	for _, r := range table.Rows() {
+		require.Contains(t, r.Data[1], "Biscuit 1")
+	}
+}
+
+func TestFilteredMutations(t *testing.T) {
+	table := New(
+		WithFilterDebounce(time.Millisecond),
+		WithStructData([]rowData{
+			newRowData("Chocolate Digestives", 12),
+			newRowData("Tim Tams", 8),
+			newRowData("Hobnobs", 10),
+			newRowData("Peanut Butter Cookie", 8),
+		}),
+	)
+
+	table = runFilter(t, table, table.SetFilterText("o"))
+	require.Equal(t, 3, len(table.Rows()))
+
+	table.SortBy(1, SortAscending, SortNumeric)
+	require.Equal(t, []string{"Peanut Butter Cookie", "Hobnobs", "Chocolate Digestives"}, columnValues(table.Rows(), 0))
+
+	require.True(t, table.RemoveRowByIndex(1))
+	require.Equal(t, 2, len(table.Rows()))
+	require.Equal(t, 3, len(table.AllRows()))
+	require.Equal(t, -1, table.GetRowByHash(newRowData("Hobnobs", 10).GetHashCode()))
+}
+
+func TestFilterPredicate(t *testing.T) {
+	table := New(
+		WithFilterDebounce(time.Millisecond),
+		WithStructData([]rowData{
+			newRowData("Chocolate Digestives", 12),
+			newRowData("Tim Tams", 8),
+			newRowData("Hobnobs", 10),
+			newRowData("Peanut Butter Cookie", 8),
+		}),
+	)
+
+	table.Filter(func(r Row) bool { return r.Data[1] != "8" })
+	require.True(t, table.IsFiltered())
+	require.Equal(t, []string{"Chocolate Digestives", "Hobnobs"}, columnValues(table.Rows(), 0))
+	require.Len(t, table.AllRows(), 4)
+
+	// Filter text narrows the rows further, and removing it leaves the predicate
+	table = runFilter(t, table, table.SetFilterText("hob"))
+	require.Equal(t, []string{"Hobnobs"}, columnValues(table.Rows(), 0))
+
+	require.Nil(t, table.SetFilterText(""))
+	require.Equal(t, []string{"Chocolate Digestives", "Hobnobs"}, columnValues(table.Rows(), 0))
+
+	// New rows are filtered by the predicate
+	table.LoadRows([]Row{{Data: []string{"Arnott's", "8"}}, {Data: []string{"Scotch Finger", "11"}}}, ImportAppend)
+	require.Equal(t, []string{"Chocolate Digestives", "Hobnobs", "Scotch Finger"}, columnValues(table.Rows(), 0))
+
+	table.ClearFilter()
+	require.False(t, table.IsFiltered())
+	require.Len(t, table.Rows(), 6)
+}
+
+func TestFilterPredicateKeepsText(t *testing.T) {
+	table := New(
+		WithFilterDebounce(time.Millisecond),
+		WithStructData([]rowData{
+			newRowData("Tim Tams", 8),
+			newRowData("Hobnobs", 10),
+			newRowData("Penguins", 8),
+		}),
+	)
+
+	table = runFilter(t, table, table.SetFilterText("n"))
+	require.Len(t, table.Rows(), 2)
+
+	table.Filter(func(r Row) bool { return r.Data[1] == "8" })
+	require.Equal(t, []string{"Penguins"}, columnValues(table.Rows(), 0))
+
+	table.ClearFilter()
+	require.Equal(t, []string{"Hobnobs", "Penguins"}, columnValues(table.Rows(), 0))
+	require.Equal(t, "n", table.FilterText())
+}
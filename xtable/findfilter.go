@@ -0,0 +1,135 @@
+package xtable
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// WithFindFilterToggle enables the key bound to KeyMap.FindFilter (by default &), which promotes the
+// text of the last Find to a filter showing only the matching rows, or if already promoted, demotes it
+// back to a search of all rows. The selected match remains selected in either direction.
+func WithFindFilterToggle() Option {
+	return func(m *Model) {
+		m.findFilter = true
+	}
+}
+
+// WithCaseInsensitiveFind makes Find ignore case, so that searching for "yes" finds "Yes".
+// The text is then also matched ignoring case when promoted to a filter by PromoteFind.
+func WithCaseInsensitiveFind() Option {
+	return func(m *Model) {
+		m.findIgnoreCase = true
+	}
+}
+
+// SetCaseInsensitiveFind sets whether Find ignores case, e.g. when toggled by the user. See WithCaseInsensitiveFind.
+func (m *Model) SetCaseInsensitiveFind(ignoreCase bool) {
+	m.findIgnoreCase = ignoreCase
+}
+
+// WithFindWrap makes Find, FindPrev, FindRegexp and RepeatFind wrap around the ends of the table, continuing
+// a search from the start of the table when the end is reached, or from the end when searching backwards.
+func WithFindWrap() Option {
+	return func(m *Model) {
+		m.findWrap = true
+	}
+}
+
+// SetFindWrap sets whether searches wrap around the ends of the table. See WithFindWrap.
+func (m *Model) SetFindWrap(wrap bool) {
+	m.findWrap = wrap
+}
+
+// FindText returns the text of the last call to Find, or the source text of the regular expression
+// of the last call to FindRegexp.
+func (m Model) FindText() string {
+	return m.findText
+}
+
+// ClearFind forgets the last search, so that its matches are no longer highlighted and RepeatFind does nothing.
+// A filter applied by PromoteFind is not removed.
+func (m *Model) ClearFind() {
+	m.findText = ""
+	m.findRegexp = nil
+	m.findMatch = nil
+	m.findPattern = nil
+	m.findInColumn = false
+	m.UpdateViewport()
+}
+
+// highlightMatches renders the text of a cell matching the last search with Styles.Match.
+func (m Model) highlightMatches(value string) string {
+	if m.findPattern == nil {
+		return value
+	}
+
+	matches := m.findPattern.FindAllStringIndex(value, -1)
+	if len(matches) == 0 {
+		return value
+	}
+
+	var b strings.Builder
+
+	last := 0
+
+	for _, match := range matches {
+		if match[0] == match[1] {
+			// Empty matches, e.g. of ^, have nothing to highlight
+			continue
+		}
+
+		b.WriteString(value[last:match[0]])
+		b.WriteString(m.styles.Match.Render(value[match[0]:match[1]]))
+		last = match[1]
+	}
+
+	b.WriteString(value[last:])
+
+	return b.String()
+}
+
+// PromoteFind filters the rows to those matching the text of the last call to Find, matching case
+// as Find does, or the regular expression of the last call to FindRegexp. The text of FindInColumn
+// is only matched in its column. The selected row
+// remains selected. As with SetFilterText, the filter is
+// evaluated in the background, and the returned command must be returned to Bubble Tea.
+// It returns nil if Find has not been called.
+func (m *Model) PromoteFind() tea.Cmd {
+	if m.findText == "" {
+		return nil
+	}
+
+	cmd := m.SetFilterText(m.findText)
+	m.filter.caseSensitive = !m.findIgnoreCase
+	m.filter.regexp = m.findRegexp
+	m.filter.inColumn = m.findInColumn
+	m.filter.column = m.findColumn
+	m.findPromoted = true
+
+	return cmd
+}
+
+// DemoteFind removes a filter applied by PromoteFind, showing all rows with the selected row still selected.
+func (m *Model) DemoteFind() {
+	if !m.findPromoted {
+		return
+	}
+
+	m.SetFilterText("")
+}
+
+// FindPromoted returns true if the rows are filtered by PromoteFind.
+func (m Model) FindPromoted() bool {
+	return m.findPromoted
+}
+
+// toggleFindFilter promotes or demotes the last Find.
+func (m *Model) toggleFindFilter() tea.Cmd {
+	if m.findPromoted {
+		m.DemoteFind()
+		return nil
+	}
+
+	return m.PromoteFind()
+}
@@ -0,0 +1,265 @@
+package xtable
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindFilterToggle(t *testing.T) {
+	table := New(
+		WithFilterDebounce(time.Millisecond),
+		WithFindFilterToggle(),
+		WithFocused(true),
+		WithColumns([]Column{{Title: "Name", Width: 20}}),
+		WithRows([]Row{
+			{Data: []string{"Tim Tams"}},
+			{Data: []string{"Hobnobs"}},
+			{Data: []string{"timber"}},
+			{Data: []string{"Jammie Dodgers"}},
+			{Data: []string{"Tim Tam Slam"}},
+		}),
+	)
+
+	require.Nil(t, table.PromoteFind(), "nothing to promote before Find")
+
+	require.True(t, table.Find("Tim", 0))
+	require.True(t, table.Find("Tim", table.Cursor()))
+	require.Equal(t, 4, table.Cursor())
+
+	amp := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'&'}}
+
+	table, cmd := table.Update(amp)
+	table = runFilter(t, table, cmd)
+	require.True(t, table.FindPromoted())
+	require.Equal(t, []string{"Tim Tams", "Tim Tam Slam"}, columnValues(table.Rows(), 0), "matches are case sensitive, as Find")
+	require.Equal(t, "Tim Tam Slam", table.SelectedRow().Data[0])
+
+	table, cmd = table.Update(amp)
+	require.Nil(t, cmd)
+	require.False(t, table.FindPromoted())
+	require.Len(t, table.Rows(), 5)
+	require.Equal(t, 4, table.Cursor())
+}
+
+func TestFindFilterToggleDisabled(t *testing.T) {
+	table := New(WithFocused(true), WithColumns([]Column{{Title: "Name", Width: 20}}))
+	require.False(t, table.KeyMap.FindFilter.Enabled())
+}
+
+func TestFindRegexp(t *testing.T) {
+	table := New(
+		WithFilterDebounce(time.Millisecond),
+		WithColumns([]Column{{Title: "Name", Width: 20}}),
+		WithRows([]Row{
+			{Data: []string{"Tim Tams"}},
+			{Data: []string{"Hobnobs"}},
+			{Data: []string{"timber"}},
+			{Data: []string{"Tim Tam Slam"}},
+		}),
+	)
+
+	re := regexp.MustCompile(`(?i)^tim\w*$`)
+	require.True(t, table.FindRegexp(re, -1))
+	require.Equal(t, 2, table.Cursor())
+	require.Equal(t, re.String(), table.FindText())
+	require.False(t, table.FindRegexp(re, table.Cursor()))
+
+	table = runFilter(t, table, table.PromoteFind())
+	require.Equal(t, []string{"timber"}, columnValues(table.Rows(), 0))
+
+	// The promoted expression is saved and restored as a regular expression
+	require.True(t, table.SaveFilter("timber"))
+	require.True(t, table.FilterPresets()[0].Regexp)
+	table.SetFilterText("")
+
+	cmd, ok := table.ApplyFilter("timber")
+	require.True(t, ok)
+	table = runFilter(t, table, cmd)
+	require.Equal(t, []string{"timber"}, columnValues(table.Rows(), 0))
+
+	// A plain Find is not a regular expression
+	table.SetFilterText("")
+	require.True(t, table.Find("Tam", -1))
+	table = runFilter(t, table, table.PromoteFind())
+	require.Equal(t, []string{"Tim Tams", "Tim Tam Slam"}, columnValues(table.Rows(), 0))
+}
+
+func TestCaseInsensitiveFind(t *testing.T) {
+	table := New(
+		WithFilterDebounce(time.Millisecond),
+		WithCaseInsensitiveFind(),
+		WithColumns([]Column{{Title: "Answer", Width: 20}}),
+		WithRows([]Row{
+			{Data: []string{"No"}},
+			{Data: []string{"Yes"}},
+			{Data: []string{"maybe"}},
+			{Data: []string{"YES!"}},
+		}),
+	)
+
+	require.True(t, table.Find("yes", -1))
+	require.Equal(t, 1, table.Cursor())
+	require.True(t, table.Find("yes", table.Cursor()))
+	require.Equal(t, 3, table.Cursor())
+
+	table = runFilter(t, table, table.PromoteFind())
+	require.Equal(t, []string{"Yes", "YES!"}, columnValues(table.Rows(), 0))
+
+	table.SetFilterText("")
+	table.SetCaseInsensitiveFind(false)
+	require.False(t, table.Find("yes", -1))
+}
+
+func TestFindPrev(t *testing.T) {
+	table := New(
+		WithColumns([]Column{{Title: "Name", Width: 20}}),
+		WithRows([]Row{
+			{Data: []string{"Tim Tams"}},
+			{Data: []string{"Hobnobs"}},
+			{Data: []string{"Tim Tam Slam"}},
+			{Data: []string{"Digestives"}},
+			{Data: []string{"Tim Tams"}},
+		}),
+	)
+
+	table.SetCursor(4)
+	require.True(t, table.FindPrev("Tim", table.Cursor()))
+	require.Equal(t, 2, table.Cursor())
+	require.True(t, table.RepeatFind(true))
+	require.Equal(t, 0, table.Cursor())
+	require.False(t, table.RepeatFind(true), "no wraparound by default")
+
+	require.True(t, table.RepeatFind(false))
+	require.Equal(t, 2, table.Cursor())
+
+	table.SetFindWrap(true)
+	require.True(t, table.RepeatFind(false))
+	require.Equal(t, 4, table.Cursor())
+	require.True(t, table.RepeatFind(false), "wraps to the start")
+	require.Equal(t, 0, table.Cursor())
+	require.True(t, table.RepeatFind(true), "wraps to the end")
+	require.Equal(t, 4, table.Cursor())
+
+	re := regexp.MustCompile(`^(Hobnobs|Digestives)$`)
+	require.True(t, table.FindRegexp(re, table.Cursor()))
+	require.Equal(t, 1, table.Cursor())
+	require.True(t, table.RepeatFind(true))
+	require.Equal(t, 3, table.Cursor())
+}
+
+func TestRepeatFindWithoutSearch(t *testing.T) {
+	table := New(WithColumns([]Column{{Title: "Name", Width: 20}}), WithRows([]Row{{Data: []string{"a"}}}))
+	require.False(t, table.RepeatFind(false))
+}
+
+func TestFindAll(t *testing.T) {
+	table := New(
+		WithColumns([]Column{{Title: "Name", Width: 20}, {Title: "Maker", Width: 20}}),
+		WithRows([]Row{
+			{Data: []string{"Tim Tams", "Arnott's"}},
+			{Data: []string{"Hobnobs", "McVitie's"}},
+			{Data: []string{"Tim Tam Slam", "Arnott's"}},
+			{Data: []string{"Digestives", "McVitie's"}},
+		}),
+	)
+
+	table.SetCursor(1)
+	require.Equal(t, []int{0, 2}, table.FindAll("Arnott"))
+	require.Equal(t, []int{1, 3}, table.FindAll("McVitie"), "one index per row")
+	require.Empty(t, table.FindAll("Oreo"))
+	require.Equal(t, 1, table.Cursor(), "cursor is not moved")
+
+	require.Empty(t, table.FindAll("tim"))
+	table.SetCaseInsensitiveFind(true)
+	require.Equal(t, []int{0, 2}, table.FindAll("tim"))
+}
+
+func TestFindSkipsRowNumbers(t *testing.T) {
+	table := New(
+		WithColumns([]Column{{Title: "Name", Width: 20}, {Title: "Pack", Width: 10}}),
+		WithRows([]Row{
+			{Data: []string{"Tim Tams", "200g"}},
+			{Data: []string{"Hobnobs", "300g"}},
+			{Data: []string{"Digestives", "400g"}},
+		}),
+		WithRowNumbers(),
+	)
+
+	require.Equal(t, []int{0}, table.FindAll("2"))
+	require.Empty(t, table.FindAll("1"))
+	require.False(t, table.Find("1", -1))
+	require.False(t, table.FindInColumn(0, "3", -1))
+	require.True(t, table.FindInColumn(2, "3", -1))
+	require.Equal(t, 1, table.Cursor())
+}
+
+func TestHighlightMatches(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI)
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	table := New(
+		WithColumns([]Column{{Title: "Name", Width: 20}}),
+		WithRows([]Row{{Data: []string{"Tim Tams"}}, {Data: []string{"Hobnobs"}}}),
+		WithStyles(Styles{Match: red}),
+		WithRowNumbers(),
+	)
+
+	require.NotContains(t, table.renderRow(0), "\x1b[31m")
+
+	table.SetCaseInsensitiveFind(true)
+	require.True(t, table.Find("tam", -1))
+	require.Contains(t, table.renderRow(0), "Tim "+red.Render("Tam")+"s")
+	require.NotContains(t, table.renderRow(1), "\x1b[31m")
+	require.NotContains(t, table.RenderReport(), "\x1b[31m", "reports do not show matches")
+
+	require.True(t, table.FindRegexp(regexp.MustCompile(`b.`), -1))
+	require.Contains(t, table.renderRow(1), "Ho"+red.Render("bn")+"o"+red.Render("bs"))
+
+	table.ClearFind()
+	require.NotContains(t, table.renderRow(1), "\x1b[31m")
+	require.False(t, table.RepeatFind(false))
+}
+
+func TestFindInColumn(t *testing.T) {
+	table := New(
+		WithFilterDebounce(time.Millisecond),
+		WithColumns([]Column{{Title: "Name", Width: 20}, {Title: "Email", Width: 30}}),
+		WithRows([]Row{
+			{Data: []string{"Tim", "tim@example.com"}},
+			{Data: []string{"Ann", "ann@example.com"}},
+			{Data: []string{"Jo", "tim.jo@example.com"}},
+			{Data: []string{"Tim", "t@example.org"}},
+		}),
+	)
+
+	require.True(t, table.FindInColumn(1, "tim", -1))
+	require.Equal(t, 0, table.Cursor())
+	require.True(t, table.RepeatFind(false))
+	require.Equal(t, 2, table.Cursor())
+	require.False(t, table.RepeatFind(false), "names are not searched")
+	require.False(t, table.FindInColumn(5, "tim", -1))
+
+	require.True(t, table.FindInColumn(0, "Tim", -1))
+	table = runFilter(t, table, table.PromoteFind())
+	require.Equal(t, []string{"tim@example.com", "t@example.org"}, columnValues(table.Rows(), 1))
+
+	require.True(t, table.SaveFilter("tims"))
+	preset := table.FilterPresets()[0]
+	require.True(t, preset.InColumn)
+	require.Equal(t, 0, preset.Column)
+
+	table.SetFilterText("")
+	require.True(t, table.FindInColumn(1, "example.org", -1))
+	cmd, ok := table.ApplyFilter("tims")
+	require.True(t, ok)
+	table = runFilter(t, table, cmd)
+	require.Equal(t, []string{"Tim", "Tim"}, columnValues(table.Rows(), 0))
+}
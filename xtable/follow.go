@@ -0,0 +1,39 @@
+package xtable
+
+// followState holds the identity of the row pinned by FollowRow.
+type followState struct {
+	// Whether a row is being followed
+	active bool
+
+	// Metadata hash of the followed row
+	hash uint64
+}
+
+// FollowRow pins the selection to the row identified by the metadata hash value. The row is selected now if present,
+// and is selected again whenever the rows change through refresh, sorting or filtering, even if the user has moved
+// the cursor in the meantime or the row was temporarily absent. This continues until Unfollow is called.
+//
+// It returns false if the row is not currently visible, in which case it will be selected once it appears.
+func (m *Model) FollowRow(hash uint64) bool {
+	m.follow = followState{active: true, hash: hash}
+
+	if !m.selectHash(hash) {
+		return false
+	}
+
+	m.UpdateViewport()
+	m.scrollToCursor()
+
+	return true
+}
+
+// Unfollow releases the row pinned by FollowRow.
+func (m *Model) Unfollow() {
+	m.follow = followState{}
+}
+
+// Following returns the metadata hash of the row pinned by FollowRow.
+// The boolean result is false if no row is being followed.
+func (m Model) Following() (uint64, bool) {
+	return m.follow.hash, m.follow.active
+}
@@ -0,0 +1,38 @@
+package xtable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFollowRow(t *testing.T) {
+	hobnobs := newRowData("Hobnobs", 10)
+	timTams := newRowData("Tim Tams", 8)
+	digestives := newRowData("Chocolate Digestives", 12)
+
+	table := New(WithStructData([]rowData{digestives, timTams, hobnobs}))
+
+	require.True(t, table.FollowRow(hobnobs.GetHashCode()))
+	require.Equal(t, 2, table.Cursor())
+
+	// User moves away, but a sort re-selects the followed row
+	table.SetCursor(0)
+	table.SortBy(0, SortAscending, SortString)
+	require.Equal(t, "Hobnobs", table.SelectedRow().Data[0])
+
+	// Followed row is absent, then returns
+	table.SetRows([]Row{table.Rows()[0], table.Rows()[2]})
+	require.Equal(t, "Tim Tams", table.SelectedRow().Data[0])
+	table.SetRows(append(table.Rows(), Row{Data: []string{"Hobnobs", "10"}, Metadata: hobnobs}))
+	require.Equal(t, "Hobnobs", table.SelectedRow().Data[0])
+
+	hash, ok := table.Following()
+	require.True(t, ok)
+	require.Equal(t, hobnobs.GetHashCode(), hash)
+
+	table.Unfollow()
+	table.SetCursor(0)
+	table.SortBy(0, SortDescending, SortString)
+	require.Equal(t, "Chocolate Digestives", table.SelectedRow().Data[0])
+}
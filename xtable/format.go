@@ -0,0 +1,170 @@
+package xtable
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ByteSize is a number of bytes, shown in struct data in binary units, e.g. 1.2 GiB, by its built-in formatter.
+type ByteSize int64
+
+// formatter converts values of a type to cell strings, and optionally orders the strings it produces.
+type formatter struct {
+	format func(v interface{}) string
+	less   func(a, b string) bool
+}
+
+var (
+	formattersMu sync.RWMutex
+	formatters   = map[reflect.Type]formatter{
+		reflect.TypeOf(time.Duration(0)): {
+			format: func(v interface{}) string { return formatDuration(v.(time.Duration)) },
+			less:   durationLess,
+		},
+		reflect.TypeOf(ByteSize(0)): {
+			format: func(v interface{}) string { return formatBytes(float64(v.(ByteSize))) },
+			less:   bytesLess,
+		},
+	}
+)
+
+// RegisterFormatter registers a function converting struct fields of type T to cell strings in the tables of
+// all models created by WithStructData or BindStructData, e.g. for enumerations or units. Registering a type that
+// already has a formatter replaces it, including the built-in formatters of time.Duration, e.g. 3m12s, and ByteSize.
+// A "format" tag option takes precedence. Fields of type *T are formatted as T, and are empty if nil.
+//
+// Columns are sorted by the formatted strings, unless the column's Less is set.
+func RegisterFormatter[T any](f func(T) string) {
+	formattersMu.Lock()
+	defer formattersMu.Unlock()
+
+	formatters[typeOf[T]()] = formatter{format: func(v interface{}) string { return f(v.(T)) }}
+}
+
+// WithFormatter registers a function converting struct fields of type T to cell strings for this table only.
+// It takes precedence over a formatter of the same type registered with RegisterFormatter, and must precede
+// WithStructData or BindStructData.
+func WithFormatter[T any](f func(T) string) Option {
+	return func(m *Model) {
+		if m.formatters == nil {
+			m.formatters = map[reflect.Type]formatter{}
+		}
+
+		m.formatters[typeOf[T]()] = formatter{format: func(v interface{}) string { return f(v.(T)) }}
+	}
+}
+
+// typeOf returns the type T, which may be an interface.
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// formatterFor finds the formatter for a field type, registered for the table or for all tables.
+func (m Model) formatterFor(t reflect.Type) (formatter, bool) {
+	if f, ok := m.formatters[t]; ok {
+		return f, true
+	}
+
+	formattersMu.RLock()
+	defer formattersMu.RUnlock()
+
+	f, ok := formatters[t]
+	return f, ok
+}
+
+// fieldFormatter is the formatter applied to a column of struct data.
+type fieldFormatter struct {
+	formatter
+
+	// Whether the formatter is for the type pointed to by the field
+	deref bool
+}
+
+// resolveFormatter finds the formatter for a field of the given type, or of the type it points to.
+func resolveFormatter(lookup func(reflect.Type) (formatter, bool), t reflect.Type) (*fieldFormatter, bool) {
+	if f, ok := lookup(t); ok {
+		return &fieldFormatter{formatter: f}, true
+	}
+
+	if t.Kind() == reflect.Ptr {
+		if f, ok := lookup(t.Elem()); ok {
+			return &fieldFormatter{formatter: f, deref: true}, true
+		}
+	}
+
+	return nil, false
+}
+
+// apply formats the value of a field.
+func (f fieldFormatter) apply(val reflect.Value) string {
+	if f.deref {
+		if val.IsNil() {
+			return ""
+		}
+
+		val = val.Elem()
+	}
+
+	return f.format(val.Interface())
+}
+
+// formatDuration formats a duration rounded to the nearest second, or millisecond if shorter, e.g. 3m12s.
+func formatDuration(d time.Duration) string {
+	if d > -time.Second && d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+
+	return d.Round(time.Second).String()
+}
+
+// durationLess orders durations formatted by formatDuration. Other values are compared as strings.
+func durationLess(a, b string) bool {
+	da, errA := time.ParseDuration(a)
+	db, errB := time.ParseDuration(b)
+
+	if errA != nil || errB != nil {
+		return a < b
+	}
+
+	return da < db
+}
+
+// bytesLess orders sizes formatted by formatBytes. Other values are compared as strings.
+func bytesLess(a, b string) bool {
+	na, okA := parseBytes(a)
+	nb, okB := parseBytes(b)
+
+	if !okA || !okB {
+		return a < b
+	}
+
+	return na < nb
+}
+
+// parseBytes parses a size formatted by formatBytes.
+func parseBytes(s string) (float64, bool) {
+	number, unit, ok := strings.Cut(s, " ")
+	if !ok {
+		return 0, false
+	}
+
+	n, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	if unit == "B" {
+		return n, true
+	}
+
+	exp := strings.IndexByte("KMGTPE", unit[0])
+	if exp < 0 || unit[1:] != "iB" {
+		return 0, false
+	}
+
+	return n * math.Pow(1024, float64(exp+1)), true
+}
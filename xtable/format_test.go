@@ -0,0 +1,103 @@
+package xtable
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+)
+
+type temperature float64
+
+type jobRowData struct {
+	Name     string
+	Elapsed  time.Duration
+	Size     ByteSize
+	Timeout  *time.Duration
+	Temp     temperature `xtable:"Temp,editable"`
+	Exact    ByteSize    `xtable:"Exact,format=%d"`
+	Priority int
+}
+
+func (r jobRowData) GetHashCode() uint64 {
+	return uint64(len(r.Name))
+}
+
+func TestBuiltInFormatters(t *testing.T) {
+	timeout := 90 * time.Second
+	data := []jobRowData{
+		{Name: "build", Elapsed: 3*time.Minute + 12*time.Second + 300*time.Millisecond, Size: 1288490189, Timeout: &timeout, Exact: 2048},
+		{Name: "test", Elapsed: 45 * time.Second, Size: 512},
+		{Name: "lint", Elapsed: 1500 * time.Microsecond, Size: 3 << 20},
+	}
+
+	table := New(WithStructData(data, "Name", "Elapsed", "Size", "Timeout", "Exact"))
+
+	require.Equal(t, []string{"build", "3m12s", "1.2 GiB", "1m30s", "2048"}, table.rows[0].Data)
+	require.Equal(t, []string{"test", "45s", "512 B", "", "0"}, table.rows[1].Data)
+	require.Equal(t, "2ms", table.rows[2].Data[1])
+
+	table.SortBy(1, SortAscending, table.cols[1].SortHint)
+	require.Equal(t, []string{"2ms", "45s", "3m12s"}, columnValues(table.Rows(), 1))
+
+	table.SortBy(2, SortAscending, table.cols[2].SortHint)
+	require.Equal(t, []string{"512 B", "3.0 MiB", "1.2 GiB"}, columnValues(table.Rows(), 2))
+}
+
+func TestRegisterFormatter(t *testing.T) {
+	formattersMu.RLock()
+	saved := formatters
+	formattersMu.RUnlock()
+
+	formattersMu.Lock()
+	formatters = map[reflect.Type]formatter{}
+	for k, v := range saved {
+		formatters[k] = v
+	}
+	formattersMu.Unlock()
+
+	t.Cleanup(func() {
+		formattersMu.Lock()
+		formatters = saved
+		formattersMu.Unlock()
+	})
+
+	RegisterFormatter(func(p int) string { return "P" + strconv.Itoa(p) })
+	RegisterFormatter(func(t temperature) string { return fmt.Sprintf("%.1f°C", float64(t)) })
+
+	data := []jobRowData{{Name: "build", Priority: 1, Temp: 21.5}}
+
+	table := New(WithStructData(data, "Name", "Priority", "Temp"))
+	require.Equal(t, []string{"build", "P1", "21.5°C"}, table.rows[0].Data)
+
+	table = New(
+		WithFormatter(func(p int) string { return strconv.Itoa(p) + "!" }),
+		WithStructData(data, "Name", "Priority"),
+	)
+	require.Equal(t, []string{"build", "1!"}, table.rows[0].Data, "table formatters take precedence")
+}
+
+func TestFormatterAppliedToCellEdits(t *testing.T) {
+	table := New(
+		WithFocused(true),
+		WithFormatter(func(t temperature) string { return fmt.Sprintf("%.1f°C", float64(t)) }),
+		WithStructData([]jobRowData{{Name: "build", Temp: 20}}, "Name", "Temp"),
+		WithCellEditing(func(meta Metadata, _ int, value string) (Metadata, error) {
+			r := meta.(jobRowData)
+			f, err := strconv.ParseFloat(value, 64)
+			r.Temp = temperature(f)
+
+			return r, err
+		}),
+	)
+	require.Equal(t, "20.0°C", table.rows[0].Data[1])
+
+	table.StartEdit(1)
+	table.edit.input.SetValue("22.25")
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, "22.2°C", table.rows[0].Data[1])
+}
@@ -0,0 +1,42 @@
+package xtable
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// WithFuzzyFilter matches filter text fuzzily, as in the filtering of bubbles/list: a row matches if any cell
+// contains all the characters of the text in the same order, though not necessarily adjacent and ignoring case,
+// so that "hbn" matches "Hobnobs". Matching rows are shown in table order. This does not apply to filter text
+// promoted from Find, or set by SetColumnFilter.
+func WithFuzzyFilter() Option {
+	return func(m *Model) {
+		m.filter.fuzzy = true
+	}
+}
+
+// fuzzyMatcher returns a predicate that matches rows with any cell fuzzily matching the given text.
+// The row number column is not considered.
+func (m Model) fuzzyMatcher(text string) func(Row) bool {
+	text = strings.ToLower(text)
+
+	return m.cellMatcher(func(cell string) bool {
+		return fuzzyContains(strings.ToLower(cell), text)
+	})
+}
+
+// fuzzyContains returns true if s contains all the runes of pattern in order.
+func fuzzyContains(s, pattern string) bool {
+	for _, r := range s {
+		if pattern == "" {
+			return true
+		}
+
+		p, size := utf8.DecodeRuneInString(pattern)
+		if r == p {
+			pattern = pattern[size:]
+		}
+	}
+
+	return pattern == ""
+}
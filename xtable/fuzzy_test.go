@@ -0,0 +1,40 @@
+package xtable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFuzzyFilter(t *testing.T) {
+	table := New(
+		WithFilterDebounce(time.Millisecond),
+		WithFuzzyFilter(),
+		WithRowNumbers(),
+		WithStructData([]rowData{
+			newRowData("Chocolate Digestives", 12),
+			newRowData("Tim Tams", 8),
+			newRowData("Hobnobs", 10),
+			newRowData("Peanut Butter Cookie", 8),
+		}),
+	)
+
+	table = runFilter(t, table, table.SetFilterText("HBN"))
+	require.Equal(t, []string{"Hobnobs"}, columnValues(table.Rows(), 1))
+
+	table = runFilter(t, table, table.SetFilterText("tie"))
+	require.Equal(t, []string{"Chocolate Digestives", "Peanut Butter Cookie"}, columnValues(table.Rows(), 1))
+
+	// Characters must be in order
+	table = runFilter(t, table, table.SetFilterText("sbh"))
+	require.Empty(t, table.Rows())
+}
+
+func TestFuzzyContains(t *testing.T) {
+	require.True(t, fuzzyContains("hobnobs", ""))
+	require.True(t, fuzzyContains("hobnobs", "hbs"))
+	require.True(t, fuzzyContains("crème brûlée", "cbé"))
+	require.False(t, fuzzyContains("hobnobs", "hbx"))
+	require.False(t, fuzzyContains("", "h"))
+}
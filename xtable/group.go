@@ -0,0 +1,166 @@
+package xtable
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// GroupKeyMap defines the keybindings for moving focus between the tables of a TableGroup.
+type GroupKeyMap struct {
+	Next key.Binding
+	Prev key.Binding
+}
+
+// DefaultGroupKeyMap returns a default set of keybindings for a TableGroup.
+func DefaultGroupKeyMap() GroupKeyMap {
+	return GroupKeyMap{
+		Next: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "next table"),
+		),
+		Prev: key.NewBinding(
+			key.WithKeys("shift+tab"),
+			key.WithHelp("shift+tab", "previous table"),
+		),
+	}
+}
+
+// TableGroup manages focus for a program with several tables. One table at a time has focus,
+// and focus is cycled between them with the keys of the group's KeyMap. Tables without focus are
+// blurred, and optionally rendered with distinct styles.
+type TableGroup struct {
+	// The tables, in focus order
+	Tables []Model
+
+	KeyMap GroupKeyMap
+
+	// Index of the focused table
+	current int
+
+	// Styles of each table when focused
+	focusedStyles []Styles
+
+	// Styles applied to tables without focus, if set
+	unfocusedStyles *Styles
+}
+
+// GroupOption is used to set options in NewTableGroup.
+type GroupOption func(*TableGroup)
+
+// WithUnfocusedStyles sets the styles applied to tables without focus.
+// Each table has its own styles restored when it gains focus.
+func WithUnfocusedStyles(s Styles) GroupOption {
+	return func(g *TableGroup) {
+		g.unfocusedStyles = &s
+	}
+}
+
+// WithGroupKeyMap sets the keybindings for moving focus between tables.
+func WithGroupKeyMap(km GroupKeyMap) GroupOption {
+	return func(g *TableGroup) {
+		g.KeyMap = km
+	}
+}
+
+// NewTableGroup creates a group of tables, of which the first has focus.
+func NewTableGroup(tables []Model, opts ...GroupOption) TableGroup {
+	g := TableGroup{
+		Tables:        tables,
+		KeyMap:        DefaultGroupKeyMap(),
+		focusedStyles: make([]Styles, len(tables)),
+	}
+
+	for _, opt := range opts {
+		opt(&g)
+	}
+
+	for i := range tables {
+		g.focusedStyles[i] = tables[i].styles
+	}
+
+	g.SetFocus(0)
+
+	return g
+}
+
+// Focused returns the index of the table with focus.
+func (g TableGroup) Focused() int {
+	return g.current
+}
+
+// FocusedTable returns the table with focus.
+func (g *TableGroup) FocusedTable() *Model {
+	return &g.Tables[g.current]
+}
+
+// SetFocus gives focus to the table at index i, blurring the others.
+func (g *TableGroup) SetFocus(i int) {
+	if len(g.Tables) == 0 {
+		return
+	}
+
+	g.current = clamp(i, 0, len(g.Tables)-1)
+
+	for j := range g.Tables {
+		t := &g.Tables[j]
+
+		if j == g.current {
+			t.Focus()
+			t.SetStyles(g.focusedStyles[j])
+			continue
+		}
+
+		t.Blur()
+
+		if g.unfocusedStyles != nil {
+			t.SetStyles(*g.unfocusedStyles)
+		}
+	}
+}
+
+// Update moves focus between the tables on the keys of the group's KeyMap. Other key messages are
+// passed to the table with focus, and all other messages are passed to every table.
+// While the focused table is editing a cell or displaying a modal, it receives all key messages.
+func (g TableGroup) Update(msg tea.Msg) (TableGroup, tea.Cmd) {
+	if len(g.Tables) == 0 {
+		return g, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		focused := g.FocusedTable()
+
+		if !focused.Editing() && !focused.ModalActive() {
+			switch {
+			case key.Matches(msg, g.KeyMap.Next):
+				g.SetFocus((g.current + 1) % len(g.Tables))
+				return g, nil
+			case key.Matches(msg, g.KeyMap.Prev):
+				g.SetFocus((g.current + len(g.Tables) - 1) % len(g.Tables))
+				return g, nil
+			}
+		}
+
+		var cmd tea.Cmd
+
+		*focused, cmd = focused.Update(msg)
+		return g, cmd
+	}
+
+	cmds := make([]tea.Cmd, len(g.Tables))
+
+	for i := range g.Tables {
+		g.Tables[i], cmds[i] = g.Tables[i].Update(msg)
+	}
+
+	return g, tea.Batch(cmds...)
+}
+
+// ShortHelp implements the KeyMap interface.
+func (km GroupKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{km.Next, km.Prev}
+}
+
+// FullHelp implements the KeyMap interface.
+func (km GroupKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{km.Next, km.Prev}}
+}
@@ -0,0 +1,39 @@
+package xtable
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableGroup(t *testing.T) {
+	unfocused := DefaultStyles()
+	unfocused.Selected = lipgloss.NewStyle().Faint(true)
+
+	g := NewTableGroup([]Model{
+		New(WithStructData([]rowData{newRowData("Hobnobs", 10), newRowData("Tim Tams", 8)})),
+		New(WithStructData([]rowData{newRowData("Jaffa Cakes", 10), newRowData("Bourbons", 12)})),
+	}, WithUnfocusedStyles(unfocused))
+
+	require.Equal(t, 0, g.Focused())
+	require.True(t, g.Tables[0].Focused())
+	require.False(t, g.Tables[1].Focused())
+	require.Equal(t, unfocused, g.Tables[1].styles)
+
+	g, _ = g.Update(tea.KeyMsg{Type: tea.KeyTab})
+	require.Equal(t, 1, g.Focused())
+	require.False(t, g.Tables[0].Focused())
+	require.True(t, g.Tables[1].Focused())
+	require.Equal(t, DefaultStyles(), g.Tables[1].styles)
+	require.Equal(t, unfocused, g.Tables[0].styles)
+
+	// Keys go to the focused table only
+	g, _ = g.Update(tea.KeyMsg{Type: tea.KeyDown})
+	require.Equal(t, 0, g.Tables[0].Cursor())
+	require.Equal(t, 1, g.Tables[1].Cursor())
+
+	g, _ = g.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	require.Equal(t, 0, g.Focused())
+}
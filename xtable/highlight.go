@@ -0,0 +1,87 @@
+package xtable
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// highlightKey identifies a highlighted cell. Cells of rows with metadata are identified by the row's hash,
+// so that the highlight moves with the row, and other cells by the row's index.
+type highlightKey struct {
+	hash  uint64
+	index int
+	col   int
+}
+
+// highlight is the style applied to a highlighted cell.
+type highlight struct {
+	style lipgloss.Style
+
+	// Distinguishes this highlight from a later one of the same cell, which it must not clear
+	seq int
+}
+
+// highlightExpiredMsg signals that a cell highlight is to be cleared.
+type highlightExpiredMsg struct {
+	id  int
+	key highlightKey
+	seq int
+}
+
+// HighlightCell applies style to the cell at the given row (an index into the visible rows) and column
+// for duration d, to draw attention to it, e.g. a value that has just crossed a threshold. If the row has
+// metadata, the highlight stays with it if the row moves, e.g. by sorting. Highlighting a cell again
+// replaces its style and restarts the duration.
+//
+// The returned command clears the highlight when the duration has elapsed, and must be returned to Bubble Tea.
+// It returns nil if the cell does not exist.
+func (m *Model) HighlightCell(row, col int, style lipgloss.Style, d time.Duration) tea.Cmd {
+	if row < 0 || row >= len(m.rows) || col < 0 || col >= len(m.cols) {
+		return nil
+	}
+
+	if m.highlights == nil {
+		m.highlights = map[highlightKey]highlight{}
+	}
+
+	m.highlightSeq++
+	key := m.highlightKey(row, m.rows[row], col)
+	m.highlights[key] = highlight{style: style, seq: m.highlightSeq}
+	m.UpdateViewport()
+
+	id, seq := m.id, m.highlightSeq
+
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return highlightExpiredMsg{id: id, key: key, seq: seq}
+	})
+}
+
+// highlightKey returns the key of a cell in a visible row.
+func (m Model) highlightKey(r int, row Row, col int) highlightKey {
+	if row.Metadata != nil {
+		return highlightKey{hash: row.Metadata.GetHashCode(), index: -1, col: col}
+	}
+
+	return highlightKey{index: r, col: col}
+}
+
+// cellHighlight returns the highlight style of a cell in a visible row, if it is highlighted.
+func (m Model) cellHighlight(r int, row Row, col int) (lipgloss.Style, bool) {
+	if len(m.highlights) == 0 || r < 0 {
+		return lipgloss.Style{}, false
+	}
+
+	h, ok := m.highlights[m.highlightKey(r, row, col)]
+
+	return h.style, ok
+}
+
+// clearHighlight removes a highlight, unless the cell has since been highlighted again.
+func (m *Model) clearHighlight(msg highlightExpiredMsg) {
+	if h, ok := m.highlights[msg.key]; ok && h.seq == msg.seq {
+		delete(m.highlights, msg.key)
+		m.UpdateViewport()
+	}
+}
@@ -0,0 +1,43 @@
+package xtable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHighlightCell(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI)
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+
+	table := New(
+		WithStructData([]rowData{newRowData("Hobnobs", 10), newRowData("Tim Tams", 8)}),
+		WithStyles(Styles{}),
+	)
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+
+	require.Nil(t, table.HighlightCell(5, 0, red, time.Millisecond))
+
+	cmd := table.HighlightCell(1, 1, red, time.Millisecond)
+	require.NotNil(t, cmd)
+	require.Contains(t, table.renderRow(1), "\x1b[31m")
+	require.NotContains(t, table.RenderReport(), "\x1b[31m", "reports do not show highlights")
+
+	// Highlight follows the row
+	table.SortBy(0, SortDescending, SortString)
+	require.Contains(t, table.renderRow(0), "\x1b[31m")
+	require.NotContains(t, table.renderRow(1), "\x1b[31m")
+
+	// A later highlight of the same cell is not cleared by the first expiry
+	expired := cmd()
+	cmd2 := table.HighlightCell(0, 1, red, time.Millisecond)
+	table, _ = table.Update(expired)
+	require.Contains(t, table.renderRow(0), "\x1b[31m")
+
+	table, _ = table.Update(cmd2())
+	require.NotContains(t, table.renderRow(0), "\x1b[31m")
+}
@@ -0,0 +1,66 @@
+package xtable
+
+// DefaultSearchHistoryLimit is the number of search terms kept in the history, unless set by WithSearchHistoryLimit.
+const DefaultSearchHistoryLimit = 50
+
+// WithSearchHistory sets the history of search terms, oldest first, e.g. as persisted from an earlier session.
+func WithSearchHistory(terms ...string) Option {
+	return func(m *Model) {
+		m.SetSearchHistory(terms)
+	}
+}
+
+// WithSearchHistoryLimit sets the number of search terms kept in the history. The oldest are discarded first.
+func WithSearchHistoryLimit(limit int) Option {
+	return func(m *Model) {
+		m.searchHistoryLimit = limit
+		m.SetSearchHistory(m.searchHistory)
+	}
+}
+
+// SetSearchHistory replaces the history of search terms, oldest first.
+func (m *Model) SetSearchHistory(terms []string) {
+	m.searchHistory = nil
+
+	for _, term := range terms {
+		m.AddSearchHistory(term)
+	}
+}
+
+// SearchHistory returns the history of search terms, oldest first, for persisting or for recall in the
+// application's search prompt, e.g. with messagebox.WithHistory. Terms are added by Find, FindPrev,
+// FindInColumn and FindRegexp.
+func (m Model) SearchHistory() []string {
+	return append([]string(nil), m.searchHistory...)
+}
+
+// AddSearchHistory adds a term to the history of search terms, e.g. filter text when the application's filter
+// input is submitted, as filter text set by SetFilterText on every keystroke is not added. A term already in the
+// history is moved to the end, and empty terms are ignored.
+func (m *Model) AddSearchHistory(term string) {
+	if term == "" {
+		return
+	}
+
+	limit := m.searchHistoryLimit
+	if limit <= 0 {
+		limit = DefaultSearchHistoryLimit
+	}
+
+	// Copied so as not to modify a slice shared with a copy of the table
+	history := make([]string, 0, len(m.searchHistory)+1)
+
+	for _, t := range m.searchHistory {
+		if t != term {
+			history = append(history, t)
+		}
+	}
+
+	history = append(history, term)
+
+	if len(history) > limit {
+		history = history[len(history)-limit:]
+	}
+
+	m.searchHistory = history
+}
@@ -0,0 +1,51 @@
+package xtable
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchHistory(t *testing.T) {
+	table := New(
+		WithColumns([]Column{{Title: "Name", Width: 20}}),
+		WithRows([]Row{{Data: []string{"Tim Tams"}}, {Data: []string{"Hobnobs"}}}),
+		WithSearchHistory("Digestives"),
+	)
+
+	table.Find("Tim", -1)
+	table.FindPrev("Hob", -1)
+	table.FindRegexp(regexp.MustCompile(`b.`), -1)
+	table.FindAll("Jaffa")
+	table.RepeatFind(false)
+	require.Equal(t, []string{"Digestives", "Tim", "Hob", "b."}, table.SearchHistory())
+
+	table.Find("Tim", -1)
+	table.AddSearchHistory("")
+	table.AddSearchHistory("Oreo")
+	require.Equal(t, []string{"Digestives", "Hob", "b.", "Tim", "Oreo"}, table.SearchHistory(), "repeated terms move to the end")
+
+	copied := table
+	copied.AddSearchHistory("Jaffa")
+	require.NotContains(t, table.SearchHistory(), "Jaffa")
+}
+
+func TestSearchHistoryLimit(t *testing.T) {
+	table := New(
+		WithSearchHistory("a", "b", "c"),
+		WithSearchHistoryLimit(2),
+	)
+	require.Equal(t, []string{"b", "c"}, table.SearchHistory())
+
+	table.AddSearchHistory("d")
+	require.Equal(t, []string{"c", "d"}, table.SearchHistory())
+
+	table = New()
+	for i := 0; i < DefaultSearchHistoryLimit+1; i++ {
+		table.AddSearchHistory(string(rune('A' + i)))
+	}
+
+	require.Len(t, table.SearchHistory(), DefaultSearchHistoryLimit)
+	require.Equal(t, "B", table.SearchHistory()[0])
+}
@@ -0,0 +1,44 @@
+package xtable
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Hooks are optional callbacks that allow behaviour to be added to a table
+// without wrapping the model. Any hook may be nil.
+//
+// Hooks receive a pointer to the table, which they may modify. Hooks should avoid
+// calling methods that add, remove or reorder rows from within OnRowsChanged,
+// as that would invoke OnRowsChanged again.
+type Hooks struct {
+	// OnBeforeRender is called by View before the table is rendered. The hook receives a copy
+	// of the table, so changes made with setters such as SetStyles apply only to the current render.
+	OnBeforeRender func(m *Model)
+
+	// OnKey is called by Update for each key message received while the table is focused,
+	// before the table's own key handling. If handled is true, the table does no further
+	// processing of the key and cmd is returned from Update.
+	OnKey func(m *Model, msg tea.KeyMsg) (handled bool, cmd tea.Cmd)
+
+	// OnRowsChanged is called after rows are added, removed, replaced, reordered or filtered.
+	OnRowsChanged func(m *Model)
+}
+
+// WithHooks sets the extension hooks for the table.
+func WithHooks(h Hooks) Option {
+	return func(m *Model) {
+		m.hooks = h
+	}
+}
+
+// SetHooks sets the extension hooks for the table.
+func (m *Model) SetHooks(h Hooks) {
+	m.hooks = h
+}
+
+// rowsChanged invokes the OnRowsChanged hook.
+func (m *Model) rowsChanged() {
+	if m.hooks.OnRowsChanged != nil {
+		m.hooks.OnRowsChanged(m)
+	}
+}
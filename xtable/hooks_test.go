@@ -0,0 +1,56 @@
+package xtable
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHooks(t *testing.T) {
+	var changes, renders int
+
+	table := New(
+		WithFocused(true),
+		WithStructData([]rowData{
+			newRowData("Chocolate Digestives", 12),
+			newRowData("Tim Tams", 8),
+			newRowData("Hobnobs", 10),
+		}),
+		WithHooks(Hooks{
+			OnBeforeRender: func(m *Model) {
+				renders++
+				m.SetColumns([]Column{{Title: "Biscuit", Width: 20}, {Title: "Size", Width: 4}})
+			},
+			OnKey: func(m *Model, msg tea.KeyMsg) (bool, tea.Cmd) {
+				if msg.String() == "x" {
+					m.RemoveSelectedRow()
+					return true, nil
+				}
+
+				return false, nil
+			},
+			OnRowsChanged: func(m *Model) {
+				changes++
+			},
+		}),
+	)
+
+	require.Contains(t, ansi.Strip(table.View()), "Biscuit")
+	require.Equal(t, 1, renders)
+
+	// Render hook doesn't persist changes
+	require.Equal(t, "Name", table.Columns()[0].Title)
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	require.Equal(t, 2, len(table.Rows()))
+	require.Equal(t, 1, changes)
+
+	table.SortBy(1, SortAscending, SortNumeric)
+	require.Equal(t, 2, changes)
+
+	// Unhandled keys still processed by the table
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyDown})
+	require.Equal(t, 1, table.Cursor())
+}
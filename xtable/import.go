@@ -0,0 +1,70 @@
+package xtable
+
+// ImportMode determines how loaded rows are combined with the existing rows of the table.
+type ImportMode int
+
+const (
+	// ImportReplace replaces all rows of the table with the loaded rows, as SetRows.
+	ImportReplace ImportMode = iota
+
+	// ImportAppend adds the loaded rows after the existing rows, without changing the selected row.
+	ImportAppend
+
+	// ImportMerge reconciles the loaded rows with the existing rows by metadata hash. An existing row with
+	// the same hash as a loaded row is updated in place, keeping its position in the table, and other loaded rows
+	// are added at the end. Loaded rows without metadata cannot be matched, so are always added.
+	ImportMerge
+)
+
+// LoadRows combines rows loaded from an external source, e.g. an import dialog, file or query, with the
+// rows of the table according to mode, so that recurring imports do not require reconciliation by the application.
+// Any active filter is re-applied, and the selected row remains selected if it is still present.
+// If row events are enabled, RowAddedMsg is queued for each added row, or RowsReplacedMsg for ImportReplace.
+// The row number cell should not be included in the rows' data, as it is added by the table if row numbers
+// are enabled.
+func (m *Model) LoadRows(rows []Row, mode ImportMode) {
+	switch mode {
+	case ImportAppend:
+		m.appendRows(rows)
+	case ImportMerge:
+		m.mergeRows(m.numberRows(rows))
+	default:
+		m.SetRows(m.numberRows(rows))
+	}
+}
+
+// mergeRows updates existing rows matching the metadata hash of the given rows, and appends the rest.
+func (m *Model) mergeRows(rows []Row) {
+	all := append([]Row{}, m.AllRows()...)
+
+	byHash := make(map[uint64]int, len(all))
+	for i, r := range all {
+		if r.Metadata != nil {
+			byHash[r.Metadata.GetHashCode()] = i
+		}
+	}
+
+	for _, r := range rows {
+		if r.Metadata != nil {
+			if i, ok := byHash[r.Metadata.GetHashCode()]; ok {
+				all[i] = r
+				continue
+			}
+		}
+
+		m.emitRowAdded(r)
+		all = append(all, r)
+	}
+
+	hash, hasHash := m.selectedHash()
+
+	if m.filter.match != nil {
+		m.filter.all = all
+	} else {
+		m.rows = all
+	}
+
+	m.refilter()
+	m.RenumberRows()
+	m.restoreCursor(hash, hasHash)
+}
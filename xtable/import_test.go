@@ -0,0 +1,91 @@
+package xtable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func importTable() Model {
+	return New(
+		WithStructData([]rowData{
+			{Name: "Tim Tams", PacketSize: 8, hash: 1},
+			{Name: "Hobnobs", PacketSize: 10, hash: 2},
+		}),
+		WithRowEvents(),
+	)
+}
+
+func importRow(name, size string, hash uint64) Row {
+	return Row{Data: []string{name, size}, Metadata: rowData{Name: name, hash: hash}}
+}
+
+// rowValues returns the data of the rows.
+func rowValues(rows []Row) [][]string {
+	values := make([][]string, len(rows))
+	for i, r := range rows {
+		values[i] = r.Data
+	}
+
+	return values
+}
+
+func TestLoadRowsReplace(t *testing.T) {
+	table := importTable()
+	table.LoadRows([]Row{importRow("Penguins", "6", 3)}, ImportReplace)
+
+	require.Equal(t, []string{"Penguins"}, columnValues(table.Rows(), 0))
+}
+
+func TestLoadRowsAppend(t *testing.T) {
+	table := importTable()
+	table.SetCursor(1)
+	table.LoadRows([]Row{importRow("Penguins", "6", 3)}, ImportAppend)
+
+	require.Equal(t, []string{"Tim Tams", "Hobnobs", "Penguins"}, columnValues(table.Rows(), 0))
+	require.Equal(t, 1, table.Cursor())
+}
+
+func TestLoadRowsMerge(t *testing.T) {
+	table := importTable()
+	table.SetCursor(0)
+	table.FlushEvents()
+
+	table.LoadRows([]Row{
+		importRow("Penguins", "6", 3),
+		importRow("Tim Tams", "9", 1),
+		{Data: []string{"Oreos", "14"}},
+	}, ImportMerge)
+
+	require.Equal(t, []string{"Tim Tams", "Hobnobs", "Penguins", "Oreos"}, columnValues(table.Rows(), 0))
+	require.Equal(t, "9", table.Rows()[0].Data[1])
+	require.Equal(t, 0, table.Cursor())
+	require.Len(t, table.events, 2)
+}
+
+func TestLoadRowsRowNumbers(t *testing.T) {
+	table := New(
+		WithStructData([]rowData{{Name: "Tim Tams", PacketSize: 8, hash: 1}, {Name: "Hobnobs", PacketSize: 10, hash: 2}}),
+		WithRowNumbers(),
+	)
+
+	table.LoadRows([]Row{importRow("Penguins", "6", 3), importRow("Tim Tams", "9", 1)}, ImportMerge)
+	require.Equal(t, [][]string{{"1", "Tim Tams", "9"}, {"2", "Hobnobs", "10"}, {"3", "Penguins", "6"}}, rowValues(table.Rows()))
+
+	table.LoadRows([]Row{importRow("Oreos", "14", 4)}, ImportAppend)
+	require.Equal(t, []string{"4", "Oreos", "14"}, table.Rows()[3].Data)
+
+	table.LoadRows([]Row{importRow("Bourbons", "16", 5)}, ImportReplace)
+	require.Equal(t, [][]string{{"1", "Bourbons", "16"}}, rowValues(table.Rows()))
+
+	require.NoError(t, table.FromValuesWithOptions("Jaffa Cakes,12", ",", WithValuesImportMode(ImportAppend)))
+	require.Equal(t, []string{"2", "Jaffa Cakes", "12"}, table.Rows()[1].Data)
+}
+
+func TestFromValuesImportMode(t *testing.T) {
+	table := New(WithColumns([]Column{{Title: "A", Width: 5}, {Title: "B", Width: 5}}))
+	require.NoError(t, table.FromValuesWithOptions("a,1", ","))
+	require.NoError(t, table.FromValuesWithOptions("b,2", ",", WithValuesImportMode(ImportAppend)))
+
+	require.Equal(t, []string{"a", "b"}, columnValues(table.Rows(), 0))
+}
@@ -0,0 +1,70 @@
+package xtable
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type jsonOptions struct {
+	keys []string
+	mode ImportMode
+}
+
+// JSONOption is used to set options in FromJSON.
+type JSONOption func(*jsonOptions)
+
+// WithJSONKeys sets the keys of the objects shown as columns, in that order. By default, with ImportReplace
+// the columns are the union of the keys of all objects in alphabetical order, and with other import modes
+// the keys are the titles of the table's columns.
+func WithJSONKeys(keys ...string) JSONOption {
+	return func(o *jsonOptions) {
+		o.keys = keys
+	}
+}
+
+// WithJSONImportMode sets how the rows read are combined with the existing rows of the table.
+// The default is ImportReplace. See LoadRows.
+func WithJSONImportMode(mode ImportMode) JSONOption {
+	return func(o *jsonOptions) {
+		o.mode = mode
+	}
+}
+
+// FromJSON creates the table rows from a JSON array of objects, e.g. the output of an API or a command with
+// JSON output. With ImportReplace, the columns of the table are replaced by the keys of the objects, after any
+// row number column. With other import modes, the columns are unchanged.
+// Values are converted as for WithMapData: numbers keep their precision and are right aligned, nested
+// objects and arrays are shown as JSON, and row Metadata is the object as a MapRow.
+// If the data cannot be decoded, an error is returned and the table is unchanged.
+func (m *Model) FromJSON(r io.Reader, opts ...JSONOption) error {
+	o := &jsonOptions{}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	var data []map[string]interface{}
+	if err := decoder.Decode(&data); err != nil {
+		return err
+	}
+
+	keys := o.keys
+	if len(keys) == 0 && o.mode != ImportReplace {
+		for _, col := range m.dataColumns() {
+			keys = append(keys, col.Title)
+		}
+	}
+
+	cols, rows := m.mapTable(data, keys)
+
+	m.LoadRows(rows, o.mode)
+
+	if o.mode == ImportReplace {
+		m.setDataColumns(cols)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package xtable
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromJSON(t *testing.T) {
+	input := `[
+		{"name": "build", "runs": 12, "size": 12345678901234567890, "tags": ["ci", "go"]},
+		{"name": "test", "runs": 9.5, "owner": {"login": "ann"}},
+		{"name": "lint", "owner": null}
+	]`
+
+	m := valuesTable()
+	require.NoError(t, m.FromJSON(strings.NewReader(input)))
+
+	titles := []string{}
+	for _, c := range m.Columns() {
+		titles = append(titles, c.Title)
+	}
+
+	require.Equal(t, []string{"name", "owner", "runs", "size", "tags"}, titles)
+	require.Equal(t, []string{"build", "", "12", "12345678901234567890", `["ci","go"]`}, m.Rows()[0].Data)
+	require.Equal(t, []string{"test", `{"login":"ann"}`, "9.5", "", ""}, m.Rows()[1].Data)
+	require.Equal(t, SortNumeric, m.Columns()[2].SortHint)
+	require.Equal(t, lipgloss.Right, m.Columns()[2].Align)
+	require.Equal(t, SortString, m.Columns()[1].SortHint)
+	require.Equal(t, "lint", m.Rows()[2].Metadata.(MapRow)["name"])
+
+	require.NoError(t, m.FromJSON(strings.NewReader(input), WithJSONKeys("runs", "name")))
+	require.Equal(t, "runs", m.Columns()[0].Title)
+	require.Len(t, m.Columns(), 2)
+	require.Equal(t, []string{"9.5", "test"}, m.Rows()[1].Data)
+}
+
+func TestFromJSONImportMode(t *testing.T) {
+	m := valuesTable()
+	require.NoError(t, m.FromJSON(strings.NewReader(`[{"name": "build", "runs": 12}]`)))
+	require.NoError(t, m.FromJSON(strings.NewReader(`[{"runs": 9, "name": "test", "owner": "ann"}]`), WithJSONImportMode(ImportAppend)))
+
+	require.Equal(t, []string{"name", "runs"}, columnTitles(m.Columns()))
+	require.Equal(t, [][]string{{"build", "12"}, {"test", "9"}}, rowValues(m.Rows()))
+
+	// Rows are merged by the hash of their object
+	require.NoError(t, m.FromJSON(strings.NewReader(`[{"name": "lint"}, {"name": "test", "owner": "ann", "runs": 9}]`), WithJSONImportMode(ImportMerge)))
+	require.Equal(t, []string{"build", "test", "lint"}, columnValues(m.Rows(), 0))
+}
+
+func TestFromJSONRowNumbers(t *testing.T) {
+	m := New(WithColumns([]Column{{Title: "Foo", Width: 10}}), WithRowNumbers())
+	require.NoError(t, m.FromJSON(strings.NewReader(`[{"name": "build", "runs": 12}, {"name": "test", "runs": 9}]`)))
+
+	require.Equal(t, []string{"#", "name", "runs"}, columnTitles(m.Columns()))
+	require.Equal(t, [][]string{{"1", "build", "12"}, {"2", "test", "9"}}, rowValues(m.Rows()))
+	require.Equal(t, []int{1}, m.FindAll("test"))
+
+	var b strings.Builder
+	require.NoError(t, m.ExportCSV(&b))
+	require.Equal(t, "name,runs\nbuild,12\ntest,9\n", b.String())
+}
+
+func TestFromJSONError(t *testing.T) {
+	m := valuesTable()
+
+	require.Error(t, m.FromJSON(strings.NewReader(`{"name": "not an array"}`)))
+	require.Error(t, m.FromJSON(strings.NewReader(`[{"name": `)))
+	require.Equal(t, "Foo", m.Columns()[0].Title)
+}
@@ -0,0 +1,132 @@
+package xtable
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// MapRow is the metadata of rows created by WithMapData, holding the map the row was created from.
+type MapRow map[string]interface{}
+
+// GetHashCode implements Metadata, hashing the keys and values of the map.
+func (r MapRow) GetHashCode() uint64 {
+	keys := make([]string, 0, len(r))
+	for k := range r {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	hasher := fnv.New64a()
+	for _, k := range keys {
+		fmt.Fprintf(hasher, "%q=%v;", k, r[k])
+	}
+
+	return hasher.Sum64()
+}
+
+// WithMapData creates a table from a slice of maps, e.g. decoded JSON objects with no fixed schema.
+//
+//   - The columns are the keys given, in that order, or if none are given, the union of the keys of all maps in
+//     alphabetical order.
+//   - Values are converted to strings as for struct data, including by formatters registered for their types,
+//     and missing and nil values are empty.
+//   - Columns whose values are all numbers are right aligned and sorted numerically.
+//   - Row Metadata is the map as a MapRow.
+func WithMapData(data []map[string]interface{}, keys ...string) Option {
+	return func(m *Model) {
+		m.cols, m.rows = m.mapTable(data, keys)
+	}
+}
+
+// mapTable returns the columns and rows of a table of the maps, as described for WithMapData.
+func (m Model) mapTable(data []map[string]interface{}, keys []string) ([]Column, []Row) {
+	if len(keys) == 0 {
+		keys = mapKeys(data)
+	}
+
+	cols := make([]Column, len(keys))
+	rows := make([]Row, len(data))
+
+	numeric := make([]bool, len(keys))
+	for i, k := range keys {
+		cols[i] = Column{Title: k, Width: len(k)}
+		numeric[i] = true
+	}
+
+	for r, values := range data {
+		cells := make([]string, len(keys))
+
+		for i, k := range keys {
+			v, ok := values[k]
+			if !ok || v == nil {
+				continue
+			}
+
+			cells[i] = m.formatValue(v)
+			numeric[i] = numeric[i] && isNumericValue(v)
+			cols[i].Width = max(cols[i].Width, len(cells[i]))
+		}
+
+		rows[r] = Row{Data: cells, Metadata: MapRow(values)}
+	}
+
+	for i := range cols {
+		cols[i].Align, cols[i].SortHint = lipgloss.Left, SortString
+		if numeric[i] {
+			cols[i].Align, cols[i].SortHint = lipgloss.Right, SortNumeric
+		}
+	}
+
+	return cols, rows
+}
+
+// mapKeys returns the union of the keys of the maps, in alphabetical order.
+func mapKeys(data []map[string]interface{}) []string {
+	seen := map[string]bool{}
+	keys := []string{}
+
+	for _, values := range data {
+		for k := range values {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// isNumericValue returns true for values of numeric types, including numbers decoded by FromJSON.
+func isNumericValue(v interface{}) bool {
+	if _, ok := v.(json.Number); ok {
+		return true
+	}
+
+	return isNumericKind(reflect.TypeOf(v).Kind())
+}
+
+// formatValue converts a value to a cell string, by the formatter registered for its type, if any.
+func (m Model) formatValue(v interface{}) string {
+	if f, ok := m.formatterFor(reflect.TypeOf(v)); ok {
+		return f.format(v)
+	}
+
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		// Nested JSON objects and arrays are shown as JSON
+		if b, err := json.Marshal(v); err == nil {
+			return string(b)
+		}
+	}
+
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,38 @@
+package xtable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMapData(t *testing.T) {
+	data := []map[string]interface{}{
+		{"name": "build", "elapsed": 3 * time.Second, "runs": 12.0},
+		{"name": "test", "runs": 9.0, "owner": nil},
+		{"name": "lint", "runs": "many", "owner": "ann"},
+	}
+
+	table := New(WithMapData(data))
+
+	titles := []string{}
+	for _, c := range table.cols {
+		titles = append(titles, c.Title)
+	}
+
+	require.Equal(t, []string{"elapsed", "name", "owner", "runs"}, titles)
+	require.Equal(t, []string{"3s", "build", "", "12"}, table.rows[0].Data)
+	require.Equal(t, []string{"", "test", "", "9"}, table.rows[1].Data)
+	require.Equal(t, lipgloss.Right, table.cols[0].Align)
+	require.Equal(t, SortString, table.cols[3].SortHint, "not all runs are numbers")
+	require.Equal(t, MapRow(data[2]), table.rows[2].Metadata)
+	require.NotEqual(t, table.rows[0].Metadata.GetHashCode(), table.rows[1].Metadata.GetHashCode())
+
+	table = New(WithMapData(data[:2], "runs", "name"))
+	require.Equal(t, "runs", table.cols[0].Title)
+	require.Equal(t, []string{"9", "test"}, table.rows[1].Data)
+	require.Equal(t, SortNumeric, table.cols[0].SortHint)
+	require.Equal(t, lipgloss.Right, table.cols[0].Align)
+}
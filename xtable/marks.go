@@ -0,0 +1,68 @@
+package xtable
+
+// WithMultiSelect enables marking of several rows, e.g. to export or act on them together. When the table
+// is focused, the key bound to KeyMap.ToggleMark (by default x) marks or unmarks the selected row.
+// Marked rows are rendered with the Marked style. Only rows with metadata can be marked, as marks are
+// kept by metadata hash, so that they follow the rows through sorting, filtering and refresh.
+func WithMultiSelect() Option {
+	return func(m *Model) {
+		m.multiSelect = true
+	}
+}
+
+// ToggleMark marks the selected row, or unmarks it if already marked.
+// It returns false if there is no selected row, or it has no metadata.
+func (m *Model) ToggleMark() bool {
+	r := m.SelectedRow()
+	if r.Metadata == nil {
+		return false
+	}
+
+	hash := r.Metadata.GetHashCode()
+	m.MarkRow(hash, !m.marks[hash])
+
+	return true
+}
+
+// MarkRow marks or unmarks the row with the given metadata hash.
+func (m *Model) MarkRow(hash uint64, marked bool) {
+	if m.marks == nil {
+		m.marks = map[uint64]bool{}
+	}
+
+	if marked {
+		m.marks[hash] = true
+	} else {
+		delete(m.marks, hash)
+	}
+
+	m.UpdateViewport()
+}
+
+// ClearMarks unmarks all rows.
+func (m *Model) ClearMarks() {
+	m.marks = nil
+	m.UpdateViewport()
+}
+
+// IsMarked returns true if the row is marked.
+func (m Model) IsMarked(r Row) bool {
+	return r.Metadata != nil && m.marks[r.Metadata.GetHashCode()]
+}
+
+// MarkedRows returns the marked rows in table order, including any hidden by an active filter.
+func (m Model) MarkedRows() []Row {
+	if len(m.marks) == 0 {
+		return nil
+	}
+
+	marked := []Row{}
+
+	for _, r := range m.AllRows() {
+		if m.IsMarked(r) {
+			marked = append(marked, r)
+		}
+	}
+
+	return marked
+}
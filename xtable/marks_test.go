@@ -0,0 +1,37 @@
+package xtable
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToggleMarkKey(t *testing.T) {
+	table := exportTable()
+	table.Focus()
+	table.SetCursor(1)
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	require.True(t, table.IsMarked(table.SelectedRow()))
+	require.Len(t, table.MarkedRows(), 1)
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	require.False(t, table.IsMarked(table.SelectedRow()))
+	require.Empty(t, table.MarkedRows())
+}
+
+func TestMarksFollowSort(t *testing.T) {
+	table := exportTable()
+	table.SetCursor(0)
+	require.True(t, table.ToggleMark())
+
+	table.SortBy(2, SortDescending, SortNumeric)
+
+	marked := table.MarkedRows()
+	require.Len(t, marked, 1)
+	require.Equal(t, "Chocolate Digestives", marked[0].Data[1])
+
+	table.ClearMarks()
+	require.Empty(t, table.MarkedRows())
+}
@@ -0,0 +1,61 @@
+package xtable
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ModalActive returns true if a modal form or message box opened by the table is being displayed.
+// While this is so, the table's Update must receive all messages.
+func (m Model) ModalActive() bool {
+	return m.rowForm.form.IsActive() || m.confirm.box.IsActive() || m.columnMenu.running || m.contextMenu.running
+}
+
+// updateModal passes a message to the active modal form or message box.
+func (m *Model) updateModal(msg tea.Msg) tea.Cmd {
+	if m.confirm.box.IsActive() {
+		return m.updateConfirm(msg)
+	}
+
+	if m.columnMenu.running {
+		return m.updateColumnMenu(msg)
+	}
+
+	if m.contextMenu.running {
+		return m.updateContextMenu(msg)
+	}
+
+	return m.updateRowForm(msg)
+}
+
+// renderModal overlays the active modal form or message box, if any, on the table view.
+func (m Model) renderModal(content string) string {
+	if m.confirm.box.IsActive() {
+		// Follow the selected row if it has moved since the box was opened
+		return m.confirm.box.MoveAnchor(confirmAnchorX, m.SelectedRowScreenY()).Render(content)
+	}
+
+	if m.columnMenu.running {
+		return m.columnMenu.seq.Render(content)
+	}
+
+	if m.contextMenu.running {
+		return m.contextMenu.seq.Render(content)
+	}
+
+	return m.rowForm.form.Render(content)
+}
+
+// modalKeyBindings returns the key bindings of the active message box or menu, if any.
+func (m Model) modalKeyBindings() []key.Binding {
+	switch {
+	case m.confirm.box.IsActive():
+		return m.confirm.box.KeyBindings()
+	case m.columnMenu.running:
+		return m.columnMenu.seq.KeyBindings()
+	case m.contextMenu.running:
+		return m.contextMenu.seq.KeyBindings()
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package xtable
+
+// PinRow pins the row with the given metadata hash to the top of the table, where it stays regardless
+// of sorting and filtering. Rows pinned earlier are placed above those pinned later. The last pinned row is
+// rendered with the Pinned style, separating the pinned rows from the others.
+// It returns false if no row has the hash.
+func (m *Model) PinRow(hash uint64) bool {
+	if !m.hasRowWithHash(hash) {
+		return false
+	}
+
+	for _, h := range m.pins {
+		if h == hash {
+			return true
+		}
+	}
+
+	m.pins = append(m.pins, hash)
+	m.repin()
+
+	return true
+}
+
+// UnpinRow unpins the row with the given metadata hash. The row remains in place until the table is next sorted.
+// It returns false if the row was not pinned.
+func (m *Model) UnpinRow(hash uint64) bool {
+	for i, h := range m.pins {
+		if h == hash {
+			m.pins = removeIndex(m.pins, i)
+			m.repin()
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// PinnedRows returns the hashes of the pinned rows, in the order they were pinned.
+func (m Model) PinnedRows() []uint64 {
+	return append([]uint64(nil), m.pins...)
+}
+
+// hasRowWithHash returns true if any row, including one hidden by a filter, has the hash.
+func (m Model) hasRowWithHash(hash uint64) bool {
+	for _, r := range m.AllRows() {
+		if r.Metadata != nil && r.Metadata.GetHashCode() == hash {
+			return true
+		}
+	}
+
+	return false
+}
+
+// repin re-evaluates the visible rows after the pinned rows change, keeping the selection.
+func (m *Model) repin() {
+	hash, hasHash := m.selectedHash()
+	m.refilter()
+	m.restoreCursor(hash, hasHash)
+}
+
+// pinToTop moves the pinned rows to the start of the complete set of rows, in pin order,
+// leaving the order of the other rows unchanged. It sets the number of pinned rows present.
+func (m *Model) pinToTop() {
+	m.pinned = 0
+
+	if len(m.pins) == 0 {
+		return
+	}
+
+	rows := m.AllRows()
+	order := make(map[uint64]int, len(m.pins))
+
+	for i, h := range m.pins {
+		order[h] = i
+	}
+
+	pinned := make([]Row, len(m.pins))
+	found := make([]bool, len(m.pins))
+	others := make([]Row, 0, len(rows))
+
+	for _, r := range rows {
+		if r.Metadata != nil {
+			if i, ok := order[r.Metadata.GetHashCode()]; ok && !found[i] {
+				pinned[i], found[i] = r, true
+				continue
+			}
+		}
+
+		others = append(others, r)
+	}
+
+	n := 0
+	for i := range pinned {
+		if found[i] {
+			rows[n] = pinned[i]
+			n++
+		}
+	}
+
+	copy(rows[n:], others)
+	m.pinned = n
+}
+
+// withPinned adds the indexes of the pinned rows, which are first in the complete set of rows,
+// to the indexes of the rows matching a filter, so that they are visible regardless of the filter.
+func (m Model) withPinned(index []int) []int {
+	if m.pinned == 0 {
+		return index
+	}
+
+	merged := make([]int, 0, len(index)+m.pinned)
+
+	for i := 0; i < m.pinned; i++ {
+		merged = append(merged, i)
+	}
+
+	for _, i := range index {
+		if i >= m.pinned {
+			merged = append(merged, i)
+		}
+	}
+
+	return merged
+}
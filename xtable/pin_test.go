@@ -0,0 +1,60 @@
+package xtable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPinRow(t *testing.T) {
+	hobnobs := newRowData("Hobnobs", 10)
+	timTams := newRowData("Tim Tams", 8)
+	digestives := newRowData("Chocolate Digestives", 12)
+	cookie := newRowData("Peanut Butter Cookie", 8)
+
+	table := New(
+		WithFilterDebounce(time.Millisecond),
+		WithStructData([]rowData{digestives, timTams, hobnobs, cookie}),
+	)
+	table.SetCursor(1)
+
+	require.False(t, table.PinRow(12345))
+	require.True(t, table.PinRow(hobnobs.GetHashCode()))
+	require.True(t, table.PinRow(cookie.GetHashCode()))
+	require.Equal(t, []uint64{hobnobs.GetHashCode(), cookie.GetHashCode()}, table.PinnedRows())
+
+	require.Equal(t, []string{"Hobnobs", "Peanut Butter Cookie", "Chocolate Digestives", "Tim Tams"}, columnValues(table.Rows(), 0))
+	require.Equal(t, "Tim Tams", table.SelectedRow().Data[0], "selection follows the row")
+
+	// Sorting does not move pinned rows
+	table.SortBy(0, SortDescending, SortString)
+	require.Equal(t, []string{"Hobnobs", "Peanut Butter Cookie", "Tim Tams", "Chocolate Digestives"}, columnValues(table.Rows(), 0))
+
+	// Pinned rows are not hidden by a filter
+	table = runFilter(t, table, table.SetFilterText("tim"))
+	require.Equal(t, []string{"Hobnobs", "Peanut Butter Cookie", "Tim Tams"}, columnValues(table.Rows(), 0))
+	require.Len(t, table.AllRows(), 4)
+
+	require.True(t, table.UnpinRow(hobnobs.GetHashCode()))
+	require.False(t, table.UnpinRow(hobnobs.GetHashCode()))
+	require.Equal(t, []string{"Peanut Butter Cookie", "Tim Tams"}, columnValues(table.Rows(), 0))
+}
+
+func TestPinnedSeparator(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI)
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+
+	hobnobs := newRowData("Hobnobs", 10)
+	table := New(
+		WithStructData([]rowData{newRowData("Tim Tams", 8), hobnobs, newRowData("Chocolate Digestives", 12)}),
+		WithStyles(Styles{Pinned: lipgloss.NewStyle().Underline(true)}),
+	)
+	table.PinRow(hobnobs.GetHashCode())
+
+	require.Contains(t, table.renderRow(0), "\x1b[4m")
+	require.NotContains(t, table.renderRow(1), "\x1b[4m")
+}
@@ -0,0 +1,147 @@
+package xtable
+
+import (
+	"regexp"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// FilterPreset is a named filter definition, saved by SaveFilter and recalled by ApplyFilter.
+// Presets can be persisted by the application, e.g. as JSON, and restored with WithFilterPresets.
+type FilterPreset struct {
+	Name string `json:"name"`
+
+	// Text the rows are filtered by
+	Text string `json:"text"`
+
+	// Whether the text is matched case sensitively, as for a filter promoted from Find
+	CaseSensitive bool `json:"caseSensitive,omitempty"`
+
+	// Whether the text is a regular expression, as for a filter promoted from FindRegexp
+	Regexp bool `json:"regexp,omitempty"`
+
+	// Whether the text is only matched in Column, as for a filter promoted from FindInColumn
+	InColumn bool `json:"inColumn,omitempty"`
+	Column   int  `json:"column,omitempty"`
+}
+
+// WithFilterPresets sets the saved filter presets, e.g. as persisted from an earlier session.
+func WithFilterPresets(presets ...FilterPreset) Option {
+	return func(m *Model) {
+		m.SetFilterPresets(presets)
+	}
+}
+
+// SetFilterPresets replaces the saved filter presets.
+func (m *Model) SetFilterPresets(presets []FilterPreset) {
+	m.presets = nil
+
+	for _, p := range presets {
+		m.savePreset(p)
+	}
+}
+
+// FilterPresets returns the saved filter presets, ordered by name, for persisting.
+func (m Model) FilterPresets() []FilterPreset {
+	return append([]FilterPreset(nil), m.presets...)
+}
+
+// Filters returns the names of the saved filter presets, in order.
+func (m Model) Filters() []string {
+	names := make([]string, len(m.presets))
+
+	for i, p := range m.presets {
+		names[i] = p.Name
+	}
+
+	return names
+}
+
+// SaveFilter saves the current filter under the given name, replacing any preset with the same name.
+// It returns false if no filter text is set.
+func (m *Model) SaveFilter(name string) bool {
+	if m.filter.text == "" {
+		return false
+	}
+
+	p := FilterPreset{
+		Name:          name,
+		Text:          m.filter.text,
+		CaseSensitive: m.filter.caseSensitive,
+		Regexp:        m.filter.regexp != nil,
+		InColumn:      m.filter.inColumn,
+	}
+
+	if p.InColumn {
+		p.Column = m.filter.column
+	}
+
+	m.savePreset(p)
+
+	return true
+}
+
+// ApplyFilter applies the saved filter preset with the given name. As with SetFilterText, the filter is evaluated
+// in the background, and the returned command must be returned to Bubble Tea. ok is false if there is no such preset,
+// or its regular expression is invalid.
+func (m *Model) ApplyFilter(name string) (cmd tea.Cmd, ok bool) {
+	i, found := m.presetIndex(name)
+	if !found {
+		return nil, false
+	}
+
+	p := m.presets[i]
+
+	var re *regexp.Regexp
+	if p.Regexp {
+		var err error
+		if re, err = regexp.Compile(p.Text); err != nil {
+			return nil, false
+		}
+	}
+
+	cmd = m.SetFilterText(p.Text)
+	m.filter.caseSensitive = p.CaseSensitive
+	m.filter.regexp = re
+	m.filter.inColumn = p.InColumn
+	m.filter.column = p.Column
+
+	return cmd, true
+}
+
+// DeleteFilter deletes the saved filter preset with the given name. It returns false if there is no such preset.
+func (m *Model) DeleteFilter(name string) bool {
+	i, found := m.presetIndex(name)
+	if !found {
+		return false
+	}
+
+	m.presets = append(m.presets[:i:i], m.presets[i+1:]...)
+
+	return true
+}
+
+// savePreset inserts or replaces a preset, keeping the presets ordered by name.
+// The slice is copied so as not to modify one shared with a copy of the table.
+func (m *Model) savePreset(p FilterPreset) {
+	i, found := m.presetIndex(p.Name)
+	presets := make([]FilterPreset, 0, len(m.presets)+1)
+	presets = append(presets, m.presets[:i]...)
+	presets = append(presets, p)
+
+	if found {
+		i++
+	}
+
+	m.presets = append(presets, m.presets[i:]...)
+}
+
+// presetIndex finds the preset with the given name, or the position at which it would be inserted.
+func (m Model) presetIndex(name string) (int, bool) {
+	i := sort.Search(len(m.presets), func(i int) bool {
+		return m.presets[i].Name >= name
+	})
+
+	return i, i < len(m.presets) && m.presets[i].Name == name
+}
@@ -0,0 +1,61 @@
+package xtable
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterPresets(t *testing.T) {
+	table := New(
+		WithFilterDebounce(time.Millisecond),
+		WithStructData([]rowData{
+			newRowData("Chocolate Digestives", 12),
+			newRowData("Tim Tams", 8),
+			newRowData("Hobnobs", 10),
+		}),
+	)
+
+	require.False(t, table.SaveFilter("empty"))
+
+	table = runFilter(t, table, table.SetFilterText("ob"))
+	require.True(t, table.SaveFilter("nobs"))
+
+	table.Find("Tim", -1)
+	table = runFilter(t, table, table.PromoteFind())
+	require.True(t, table.SaveFilter("aussie"))
+
+	require.Equal(t, []string{"aussie", "nobs"}, table.Filters())
+
+	// Replacing keeps one preset per name
+	require.True(t, table.SaveFilter("aussie"))
+	require.Len(t, table.Filters(), 2)
+
+	cmd, ok := table.ApplyFilter("nobs")
+	require.True(t, ok)
+	table = runFilter(t, table, cmd)
+	require.Equal(t, []string{"Hobnobs"}, columnValues(table.Rows(), 0))
+
+	_, ok = table.ApplyFilter("missing")
+	require.False(t, ok)
+
+	// Round trip through JSON into another table
+	b, err := json.Marshal(table.FilterPresets())
+	require.NoError(t, err)
+
+	var presets []FilterPreset
+	require.NoError(t, json.Unmarshal(b, &presets))
+
+	other := New(WithFilterPresets(presets...), WithStructData([]rowData{newRowData("Tim Tams", 8), newRowData("tim", 1)}), WithFilterDebounce(time.Millisecond))
+	cmd, ok = other.ApplyFilter("aussie")
+	require.True(t, ok)
+	other = runFilter(t, other, cmd)
+	require.Equal(t, []string{"Tim Tams"}, columnValues(other.Rows(), 0), "case sensitivity is preserved")
+
+	require.True(t, other.DeleteFilter("aussie"))
+	require.False(t, other.DeleteFilter("aussie"))
+	require.Equal(t, []string{"nobs"}, other.Filters())
+	require.Len(t, table.Filters(), 2, "copies do not share presets")
+}
@@ -0,0 +1,108 @@
+package xtable
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// RefreshErrorMsg is sent when the fetch function supplied to WithRefresh returns an error.
+// The table retains its current rows and the refresh loop continues.
+type RefreshErrorMsg struct {
+	Err error
+}
+
+// refreshTickMsg signals that it is time to fetch new rows.
+type refreshTickMsg struct {
+	id int
+}
+
+// refreshResultMsg carries the result of a fetch back to the table.
+type refreshResultMsg struct {
+	id   int
+	rows []Row
+	err  error
+}
+
+// WithRefresh configures the table to periodically replace its rows with the result of calling fetch.
+// fetch is called in a tea.Cmd, so it may block (e.g. to call an API) without stalling the UI.
+//
+// The refresh loop is started by the command returned from Init. The selected row is preserved
+// across refreshes where the row has Metadata whose hash is still present in the new rows.
+// Errors returned from fetch are sent to the owning model as RefreshErrorMsg.
+func WithRefresh(interval time.Duration, fetch func() ([]Row, error)) Option {
+	return func(m *Model) {
+		m.refreshInterval = interval
+		m.refreshFetch = fetch
+	}
+}
+
+// refreshTick returns a command that fires the next refresh, or nil if refresh is not configured.
+func (m Model) refreshTick() tea.Cmd {
+	if m.refreshFetch == nil || m.refreshInterval <= 0 {
+		return nil
+	}
+
+	id := m.id
+
+	return tea.Tick(m.refreshInterval, func(time.Time) tea.Msg {
+		return refreshTickMsg{id: id}
+	})
+}
+
+// refreshFetchCmd returns a command that calls the fetch function.
+func (m Model) refreshFetchCmd() tea.Cmd {
+	if m.refreshFetch == nil {
+		return nil
+	}
+
+	id, fetch := m.id, m.refreshFetch
+
+	return func() tea.Msg {
+		rows, err := fetch()
+		return refreshResultMsg{id: id, rows: rows, err: err}
+	}
+}
+
+// applyRefresh replaces the rows with fetched data and schedules the next refresh.
+func (m Model) applyRefresh(msg refreshResultMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		err := msg.err
+		return m, tea.Batch(
+			func() tea.Msg {
+				return RefreshErrorMsg{Err: err}
+			},
+			m.refreshTick(),
+		)
+	}
+
+	hash, hasHash := m.selectedHash()
+
+	if m.rowNumbers {
+		prependRowNumbers(msg.rows)
+	}
+
+	m.setAllRows(msg.rows)
+	m.restoreCursor(hash, hasHash)
+
+	return m, m.refreshTick()
+}
+
+// selectedHash returns the metadata hash of the selected row, if it has metadata.
+func (m Model) selectedHash() (uint64, bool) {
+	if r := m.SelectedRow(); r.Metadata != nil {
+		return r.Metadata.GetHashCode(), true
+	}
+
+	return 0, false
+}
+
+// selectHash moves the cursor to the row with the given hash. If no such row exists, the cursor is not moved.
+func (m *Model) selectHash(hash uint64) bool {
+	if ind := m.GetRowByHash(hash); ind != -1 {
+		m.cursor = ind
+		return true
+	}
+
+	return false
+}
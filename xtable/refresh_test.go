@@ -0,0 +1,60 @@
+package xtable
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefresh(t *testing.T) {
+	fetched := []Row{
+		{Data: []string{"Tim Tams", "8"}, Metadata: newRowData("Tim Tams", 8)},
+		{Data: []string{"Hobnobs", "10"}, Metadata: newRowData("Hobnobs", 10)},
+		{Data: []string{"Chocolate Digestives", "12"}, Metadata: newRowData("Chocolate Digestives", 12)},
+	}
+
+	var fetchErr error
+
+	table := New(
+		WithStructData([]rowData{
+			newRowData("Chocolate Digestives", 12),
+			newRowData("Tim Tams", 8),
+		}),
+		WithRefresh(time.Second, func() ([]Row, error) {
+			return fetched, fetchErr
+		}),
+	)
+
+	require.NotNil(t, table.Init())
+
+	// Tick for another table is ignored
+	_, cmd := table.Update(refreshTickMsg{id: table.id + 1})
+	require.Nil(t, cmd)
+
+	// Tick causes a fetch
+	_, cmd = table.Update(refreshTickMsg{id: table.id})
+	require.NotNil(t, cmd)
+	msg := cmd()
+	require.IsType(t, refreshResultMsg{}, msg)
+
+	// Result replaces rows and keeps selection on the same logical row
+	table, cmd = table.Update(msg)
+	require.NotNil(t, cmd)
+	require.Equal(t, 3, len(table.Rows()))
+	require.Equal(t, 2, table.Cursor())
+	require.Equal(t, "Chocolate Digestives", table.SelectedRow().Data[0])
+
+	// Errors leave rows alone
+	fetchErr = errors.New("boom")
+	_, cmd = table.Update(refreshTickMsg{id: table.id})
+	table, cmd = table.Update(cmd())
+	require.NotNil(t, cmd)
+	require.Equal(t, 3, len(table.Rows()))
+}
+
+func TestRefreshNotConfigured(t *testing.T) {
+	table := New()
+	require.Nil(t, table.Init())
+}
@@ -0,0 +1,102 @@
+package xtable
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+type reportOptions struct {
+	width    int
+	border   *lipgloss.Border
+	allRows  bool
+	noHeader bool
+}
+
+// ReportOption is used to set options in RenderReport.
+type ReportOption func(*reportOptions)
+
+// WithReportWidth sets the width of the report, including any border. Lines wider than this are truncated.
+// By default, the report is as wide as its columns.
+func WithReportWidth(w int) ReportOption {
+	return func(o *reportOptions) {
+		o.width = w
+	}
+}
+
+// WithReportBorder draws the given border around the report, with a line separating the header from the rows.
+func WithReportBorder(b lipgloss.Border) ReportOption {
+	return func(o *reportOptions) {
+		o.border = &b
+	}
+}
+
+// WithReportAllRows includes rows hidden by an active filter in the report.
+func WithReportAllRows() ReportOption {
+	return func(o *reportOptions) {
+		o.allRows = true
+	}
+}
+
+// WithReportNoHeader omits the column headers from the report.
+func WithReportNoHeader() ReportOption {
+	return func(o *reportOptions) {
+		o.noHeader = true
+	}
+}
+
+// RenderReport renders the complete table for writing to a file or printing. All rows are rendered,
+// independent of the height of the viewport, and without the styling of the selected row or any cell being edited.
+// Column header and cell styles are applied, so to produce plain text, set styles without colours
+// or strip the escape sequences from the result.
+func (m Model) RenderReport(opts ...ReportOption) string {
+	o := &reportOptions{}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	rows := m.rows
+	if o.allRows {
+		rows = m.AllRows()
+	}
+
+	lines := make([]string, 0, len(rows)+1)
+
+	if !o.noHeader {
+		lines = append(lines, m.headersView())
+	}
+
+	for _, row := range rows {
+		lines = append(lines, m.renderCells(-1, row))
+	}
+
+	width := o.width
+	if o.border != nil && width > 0 {
+		// Leave room for the left and right edges
+		width = max(width-2, 0) //nolint:mnd
+	}
+
+	if width > 0 {
+		for i, l := range lines {
+			lines[i] = ansi.Truncate(l, width, "")
+		}
+	}
+
+	if o.border == nil {
+		return strings.Join(lines, "\n")
+	}
+
+	if !o.noHeader {
+		inner := 0
+		for _, l := range lines {
+			inner = max(inner, ansi.StringWidth(l))
+		}
+
+		separator := strings.Repeat(o.border.Top, inner)
+		lines = append([]string{lines[0], separator}, lines[1:]...)
+	}
+
+	return lipgloss.NewStyle().Border(*o.border).Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,62 @@
+package xtable
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/require"
+)
+
+func reportTable() Model {
+	rows := make([]Row, 30)
+	for i := range rows {
+		rows[i] = Row{Data: []string{fmt.Sprintf("row%02d", i), "value"}}
+	}
+
+	return New(
+		WithColumns([]Column{{Title: "Name", Width: 8}, {Title: "Value", Width: 8}}),
+		WithRows(rows),
+		WithHeight(5),
+		WithStyles(Styles{}),
+	)
+}
+
+func TestRenderReportAllRows(t *testing.T) {
+	table := reportTable()
+	table.SetCursor(3)
+
+	lines := strings.Split(table.RenderReport(), "\n")
+
+	require.Len(t, lines, 31)
+	require.Equal(t, "Name    Value   ", lines[0])
+	require.Equal(t, "row00   value   ", lines[1])
+	require.Equal(t, "row29   value   ", lines[30])
+}
+
+func TestRenderReportRespectsFilter(t *testing.T) {
+	table := reportTable()
+	table.filter.text = "row1"
+	table.filter.match = table.textMatcher("row1")
+	table.setAllRows(table.rows)
+
+	require.Len(t, strings.Split(table.RenderReport(), "\n"), 11)
+	require.Len(t, strings.Split(table.RenderReport(WithReportAllRows()), "\n"), 31)
+}
+
+func TestRenderReportBorderAndWidth(t *testing.T) {
+	table := reportTable()
+
+	lines := strings.Split(table.RenderReport(WithReportBorder(lipgloss.NormalBorder()), WithReportWidth(12)), "\n")
+
+	// Top border, header, separator, 30 rows, bottom border
+	require.Len(t, lines, 34)
+	require.Equal(t, "│Name    Va│", lines[1])
+	require.Equal(t, "│──────────│", lines[2])
+
+	for _, l := range lines {
+		require.Equal(t, 12, ansi.StringWidth(l))
+	}
+}
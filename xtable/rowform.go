@@ -0,0 +1,169 @@
+package xtable
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fireflycons/bubbles/form"
+)
+
+// RowEditedMsg is sent when a row has been updated from the form opened by EditSelectedRow.
+type RowEditedMsg struct {
+	// ID of the table that sent the message
+	TableID int
+
+	// Metadata hash of the row before it was edited
+	OldHash uint64
+
+	// The row after the edit
+	Row Row
+}
+
+// RowCreatedMsg is sent when a row has been added from the form opened by AddRowDialog.
+type RowCreatedMsg struct {
+	// ID of the table that sent the message
+	TableID int
+
+	// The new row
+	Row Row
+}
+
+// rowFormState holds the state of the modal form used to edit or add a row.
+type rowFormState struct {
+	// The form, which is active while being displayed
+	form form.Model
+
+	// Whether the form creates a new row rather than editing an existing one
+	adding bool
+
+	// Hash of the row being edited
+	hash uint64
+}
+
+// EditSelectedRow opens a modal form over the table for editing the fields of the selected row's metadata.
+// When the form is submitted, the row's data and metadata are updated and RowEditedMsg is sent.
+// The edited row is checked by any Validator set with WithValidator before the form can be submitted.
+//
+// Nothing happens if the table was not created with WithStructData or there is no selected row.
+// While the form is open, the table's Update must receive all messages. Use ModalActive to determine this.
+func (m *Model) EditSelectedRow(opts ...form.Option) tea.Cmd {
+	if m.schema == nil || m.cursor < 0 || m.cursor >= len(m.rows) || m.rows[m.cursor].Metadata == nil {
+		return nil
+	}
+
+	meta := m.rows[m.cursor].Metadata
+
+	return m.openRowForm("Edit row", meta, rowFormState{hash: meta.GetHashCode()}, opts)
+}
+
+// AddRowDialog opens a modal form over the table for entering the fields of a new row's metadata,
+// starting from the zero value of the table's metadata type. When the form is submitted, the row
+// is appended to the table and selected, and RowCreatedMsg is sent. The new row is checked by any
+// Validator set with WithValidator before the form can be submitted.
+//
+// Nothing happens if the table was not created with WithStructData.
+// While the form is open, the table's Update must receive all messages. Use ModalActive to determine this.
+func (m *Model) AddRowDialog(opts ...form.Option) tea.Cmd {
+	if m.schema == nil {
+		return nil
+	}
+
+	return m.openRowForm("Add row", m.schema.zero(), rowFormState{adding: true}, opts)
+}
+
+// openRowForm opens the row form for the given metadata.
+func (m *Model) openRowForm(title string, meta interface{}, state rowFormState, opts []form.Option) tea.Cmd {
+	opts = append([]form.Option{form.WithValidator(m.formValidator())}, opts...)
+
+	f, err := form.New(title, meta, opts...)
+	if err != nil {
+		return nil
+	}
+
+	state.form = f
+	m.rowForm = state
+
+	return f.Init()
+}
+
+// formValidator returns a function that checks the value submitted by a row form
+// can be converted to a row that passes the table's Validator.
+func (m Model) formValidator() func(interface{}) error {
+	return func(v interface{}) error {
+		r, err := m.rowFromFormValue(v)
+		if err != nil {
+			return err
+		}
+
+		return m.ValidateRow(r)
+	}
+}
+
+// rowFromFormValue creates a row from the value submitted by a row form.
+func (m Model) rowFromFormValue(v interface{}) (Row, error) {
+	r, err := m.schema.rowFromValue(v)
+	if err != nil {
+		return Row{}, err
+	}
+
+	if m.rowNumbers {
+		r.Data = append([]string{""}, r.Data...)
+	}
+
+	return r, nil
+}
+
+// updateRowForm passes a message to the open row form.
+func (m *Model) updateRowForm(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+
+	m.rowForm.form, cmd = m.rowForm.form.Update(msg)
+	return cmd
+}
+
+// applyRowForm updates the edited row, or adds a new row, from the value submitted by the row form.
+func (m *Model) applyRowForm(msg form.SubmittedMsg) tea.Cmd {
+	if m.rowForm.adding {
+		return m.addFormRow(msg.Value)
+	}
+
+	ind := m.GetRowByHash(m.rowForm.hash)
+	if ind == -1 {
+		// Row removed while the form was open
+		return nil
+	}
+
+	r, err := m.rowFromFormValue(msg.Value)
+	if err != nil {
+		return nil
+	}
+
+	m.replaceRow(ind, r)
+
+	edited := RowEditedMsg{
+		TableID: m.id,
+		OldHash: m.rowForm.hash,
+		Row:     r,
+	}
+
+	return func() tea.Msg {
+		return edited
+	}
+}
+
+// addFormRow appends a new row from the value submitted by the row form.
+func (m *Model) addFormRow(v interface{}) tea.Cmd {
+	r, err := m.rowFromFormValue(v)
+	if err != nil {
+		return nil
+	}
+
+	m.appendRow(r)
+
+	created := RowCreatedMsg{
+		TableID: m.id,
+		Row:     r,
+	}
+
+	return func() tea.Msg {
+		return created
+	}
+}
@@ -0,0 +1,118 @@
+package xtable
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fireflycons/bubbles/form"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditSelectedRow(t *testing.T) {
+	table := newEditableTable()
+
+	table.EditSelectedRow()
+	require.True(t, table.ModalActive())
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyTab})
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	table = typeKeys(table, "12")
+	table, cmd := table.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.False(t, table.ModalActive())
+
+	msgs := collectMsgs(cmd)
+	require.Equal(t, 1, len(msgs))
+	table, cmd = table.Update(msgs[0])
+
+	require.Equal(t, []string{"Hobnobs", "12"}, table.SelectedRow().Data)
+	require.Equal(t, editableRowData{Name: "Hobnobs", PacketSize: 12}, table.SelectedRow().Metadata)
+
+	msgs = collectMsgs(cmd)
+	require.Equal(t, 1, len(msgs))
+	edited := msgs[0].(RowEditedMsg)
+	require.Equal(t, uint64(len("Hobnobs")), edited.OldHash)
+	require.Equal(t, "12", edited.Row.Data[1])
+}
+
+func TestEditSelectedRowInvalid(t *testing.T) {
+	table := newEditableTable(WithValidator(ValidatorFunc(func(r Row) error {
+		if r.Metadata.(editableRowData).PacketSize > 100 {
+			return errors.New("packet too large")
+		}
+
+		return nil
+	})))
+
+	table.EditSelectedRow()
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyTab})
+	table = typeKeys(table, "x")
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.True(t, table.ModalActive())
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	table = typeKeys(table, "00")
+	table, cmd := table.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.True(t, table.ModalActive())
+	require.Nil(t, cmd)
+
+	table, cmd = table.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	require.False(t, table.ModalActive())
+	require.IsType(t, form.CancelledMsg{}, cmd())
+	require.Equal(t, "10", table.SelectedRow().Data[1])
+}
+
+func TestAddRowDialog(t *testing.T) {
+	table := newEditableTable(WithRowNumbers(), WithRowEvents())
+
+	table.AddRowDialog()
+	require.True(t, table.ModalActive())
+
+	table = typeKeys(table, "Tim Tams")
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyTab})
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	table = typeKeys(table, "8")
+	table, cmd := table.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.False(t, table.ModalActive())
+
+	msgs := collectMsgs(cmd)
+	require.Equal(t, 1, len(msgs))
+	table, cmd = table.Update(msgs[0])
+
+	require.Equal(t, 2, len(table.Rows()))
+	require.Equal(t, 1, table.Cursor())
+	require.Equal(t, []string{"2", "Tim Tams", "8"}, table.SelectedRow().Data)
+	require.Equal(t, editableRowData{Name: "Tim Tams", PacketSize: 8}, table.SelectedRow().Metadata)
+
+	msgs = collectMsgs(cmd)
+	require.Equal(t, 2, len(msgs))
+	require.IsType(t, RowCreatedMsg{}, msgs[0])
+	require.IsType(t, RowAddedMsg{}, msgs[1])
+}
+
+type pointerRowData struct {
+	Name string
+}
+
+func (r *pointerRowData) GetHashCode() uint64 {
+	return uint64(len(r.Name))
+}
+
+func TestAddRowDialogWithPointers(t *testing.T) {
+	table := New(WithFocused(true), WithStructData([]*pointerRowData{{Name: "Hobnobs"}}))
+
+	table.AddRowDialog()
+	require.True(t, table.ModalActive())
+
+	table = typeKeys(table, "Tim Tams")
+	table, cmd := table.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.False(t, table.ModalActive())
+
+	msgs := collectMsgs(cmd)
+	require.Equal(t, 1, len(msgs))
+	table, _ = table.Update(msgs[0])
+
+	require.Equal(t, []string{"Tim Tams"}, table.SelectedRow().Data)
+	require.Equal(t, &pointerRowData{Name: "Tim Tams"}, table.SelectedRow().Metadata)
+}
@@ -0,0 +1,545 @@
+package xtable
+
+import (
+	"bytes"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/text/collate"
+)
+
+// SortOrder defines the sort direction for the SortBy method.
+type SortOrder bool
+
+const (
+	SortAscending  SortOrder = false
+	SortDescending SortOrder = true
+	SortString               = ""
+	SortNumeric              = 0
+)
+
+// SortColumn is one column of a multi-column sort, in SortByMulti and SortState.
+type SortColumn struct {
+	// Index of the column
+	Index int
+
+	// Direction of the sort on this column
+	Order SortOrder
+
+	// Type hint for the column's data, as for SortBy
+	TypeHint interface{}
+}
+
+// sortValue is the value of one sort column of a row,
+// pre-parsed once so that comparisons don't repeatedly parse cell data.
+type sortValue struct {
+	str     string
+	num     float64
+	numeric bool
+	tm      time.Time
+	timed   bool
+
+	// Parsed version, if sorted by SortSemver
+	ver       semver
+	versioned bool
+
+	// Parsed address and prefix length, if sorted by SortIP
+	ip        netip.Addr
+	bits      int
+	addressed bool
+
+	// Collation key of the string, if sorted with a collator
+	key      []byte
+	collated bool
+}
+
+// compare compares two sort values, returning -1, 0 or 1. Numeric or time comparison
+// is used only when both values could be parsed as numbers or times.
+func (v *sortValue) compare(other *sortValue) int {
+	switch {
+	case v.numeric && other.numeric:
+		if v.num < other.num {
+			return -1
+		}
+		if v.num > other.num {
+			return 1
+		}
+	case v.timed && other.timed:
+		if v.tm.Before(other.tm) {
+			return -1
+		}
+		if v.tm.After(other.tm) {
+			return 1
+		}
+	case v.collated && other.collated:
+		if c := bytes.Compare(v.key, other.key); c != 0 {
+			return c
+		}
+
+		// Equal by collation, e.g. differing only in ignored characters
+		return strings.Compare(v.str, other.str)
+	case v.str < other.str:
+		return -1
+	case v.str > other.str:
+		return 1
+	}
+
+	return 0
+}
+
+// sortKey is a row paired with the values of its sort columns.
+type sortKey struct {
+	row    Row
+	pos    int
+	values []sortValue
+}
+
+// sortKeys implements sort.Interface. Rows are compared on each column in turn, and remaining
+// ties are broken on original position, making the (unstable, but fast) sort.Sort stable.
+type sortKeys struct {
+	keys   []sortKey
+	orders []SortOrder
+
+	// Comparator of each sort column, or nil to compare parsed values
+	cmp []func(a, b *sortValue) int
+}
+
+func (s sortKeys) Len() int {
+	return len(s.keys)
+}
+
+func (s sortKeys) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
+
+func (s sortKeys) Less(i, j int) bool {
+	for c, order := range s.orders {
+		var cmp int
+
+		if compare := s.cmp[c]; compare != nil {
+			cmp = compare(&s.keys[i].values[c], &s.keys[j].values[c])
+		} else {
+			cmp = s.keys[i].values[c].compare(&s.keys[j].values[c])
+		}
+
+		if cmp == 0 {
+			continue
+		}
+
+		if order == SortDescending {
+			return cmp > 0
+		}
+
+		return cmp < 0
+	}
+
+	return s.keys[i].pos < s.keys[j].pos
+}
+
+// compareLess returns a function comparing two values as strings with a comparator, returning -1, 0 or 1.
+func compareLess(less func(a, b string) bool) func(a, b *sortValue) int {
+	return func(a, b *sortValue) int {
+		switch {
+		case less(a.str, b.str):
+			return -1
+		case less(b.str, a.str):
+			return 1
+		}
+
+		return 0
+	}
+}
+
+// SortBy sorts the table by column identified by 'index' and
+// in the given order. The selected row remains selected and is scrolled into view,
+// unless the cursor is index stable (see WithIndexStableCursor).
+//
+// The sort is stable: rows with equal values keep their existing relative order, including
+// rows hidden by a filter. The previous sort therefore acts as a tiebreaker, so sorting by A
+// and then by B orders the rows by B, then A, e.g. by pressing quick sort keys in sequence.
+//
+// typeHint hints what data type should be assumed for the column. Pass empty string
+// to string-sort, 0 to numerically sort (all numeric types). If the data cannot be cast
+// to a numeric type when requested, then it will string sort the displayed data.
+// Pass a SortKind, e.g. SortNatural, for a specialised ordering, or nil or SortAuto to choose
+// numeric, time or string comparison from the column's values.
+// If the column has a Less comparator, it is used instead and typeHint is ignored.
+// Nothing happens if the column is not sortable (see Column.NotSortable).
+func (m *Model) SortBy(index int, order SortOrder, typeHint interface{}) {
+	m.SortByMulti(SortColumn{Index: index, Order: order, TypeHint: typeHint})
+}
+
+// SortByMulti sorts the table by several columns, e.g. by Name then Age. Rows are ordered by the first
+// column, then rows with equal values in that column by the second, and so on, each column in its own
+// order and according to its type hint or comparator, as for SortBy. The sort is stable. The selected row remains
+// selected and is scrolled into view. If any column index is out of range, or any column is not sortable,
+// the table is not sorted. The rows of a row source are sorted by the source (see SortableRowSource).
+func (m *Model) SortByMulti(columns ...SortColumn) {
+	if len(columns) == 0 {
+		return
+	}
+
+	for _, c := range columns {
+		if !m.IsSortable(c.Index) {
+			return
+		}
+	}
+
+	if m.source != nil {
+		m.sortSource(columns)
+		return
+	}
+
+	// Rows without metadata are followed by position
+	hash, hasHash := m.selectedHash()
+	cursor, selected := m.cursor, m.allIndex(m.cursor)
+
+	rows := m.AllRows()
+	m.saveUnsorted(rows)
+	columns = resolveSortHints(rows, columns)
+	keys := makeSortKeys(rows, columns, m.collator)
+
+	orders := make([]SortOrder, len(columns))
+	cmp := make([]func(a, b *sortValue) int, len(columns))
+
+	for i, c := range columns {
+		orders[i] = c.Order
+
+		if less := m.cols[c.Index].Less; less != nil {
+			cmp[i] = compareLess(less)
+		} else {
+			cmp[i] = kindCompare(c.TypeHint)
+		}
+	}
+
+	sort.Sort(sortKeys{keys: keys, orders: orders, cmp: cmp})
+
+	for i := range keys {
+		rows[i] = keys[i].row
+
+		if keys[i].pos == selected {
+			selected = i
+		}
+	}
+
+	m.sorted = sortState{columns: columns}
+	m.emitSortChanged()
+	m.refilter()
+	m.selectAfterSort(cursor, selected, hash, hasHash)
+}
+
+// sortSource delegates sorting to a row source implementing SortableRowSource. The cursor remains at the same index.
+func (m *Model) sortSource(columns []SortColumn) {
+	s, ok := m.source.(SortableRowSource)
+	if !ok {
+		return
+	}
+
+	s.Sort(columns)
+	m.sorted = sortState{columns: columns}
+	m.emitSortChanged()
+	m.UpdateViewport()
+}
+
+// WithIndexStableCursor keeps the cursor at the same index when the table is sorted, rather than
+// on the same row, which by default remains selected and is scrolled into view. A row followed by
+// FollowRow remains selected either way.
+func WithIndexStableCursor() Option {
+	return func(m *Model) {
+		m.indexStableCursor = true
+	}
+}
+
+// selectAfterSort moves the cursor after sorting to the same row, given its hash, or its index in the
+// complete set of rows if it has no metadata. If the cursor is index stable, it is returned to its index.
+func (m *Model) selectAfterSort(cursor, selected int, hash uint64, hasHash bool) {
+	switch {
+	case m.indexStableCursor:
+		m.cursor = cursor
+		hasHash = false
+	case !hasHash:
+		m.cursor = m.visibleIndex(selected)
+	}
+
+	m.restoreCursor(hash, hasHash)
+}
+
+// IsSortable returns true if the column at the given index exists and is not excluded from sorting by NotSortable.
+func (m Model) IsSortable(index int) bool {
+	return index >= 0 && index < len(m.cols) && !m.cols[index].NotSortable
+}
+
+// SortedBy returns the column and order of the last sort by SortBy, or the first column of the
+// last sort by SortByMulti. ok is false if the table has not been sorted.
+func (m Model) SortedBy() (index int, order SortOrder, ok bool) {
+	if len(m.sorted.columns) == 0 {
+		return 0, SortAscending, false
+	}
+
+	return m.sorted.columns[0].Index, m.sorted.columns[0].Order, true
+}
+
+// WithCollator sorts values compared as strings according to the collation rules of a language,
+// e.g. collate.New(language.German), so that accented characters and case order correctly. This applies
+// to string sorting, and to values that are not numbers or times when sorting numerically or by time.
+// For a collation per column, set Column.Less to compare with the column's own collator.
+func WithCollator(c *collate.Collator) Option {
+	return func(m *Model) {
+		m.collator = c
+	}
+}
+
+// SetCollator sets the collator for sorting values compared as strings, or removes it if nil.
+// See WithCollator.
+func (m *Model) SetCollator(c *collate.Collator) {
+	m.collator = c
+}
+
+// SortState returns the columns of the last sort, in priority order, or nil if the table has not been sorted.
+// Automatic type hints are replaced by the hint chosen for the column.
+func (m Model) SortState() []SortColumn {
+	return append([]SortColumn(nil), m.sorted.columns...)
+}
+
+// makeSortKeys extracts and parses the sort columns of each row. If a collator is given,
+// the collation keys of values compared as strings are computed.
+func makeSortKeys(rows []Row, columns []SortColumn, collator *collate.Collator) []sortKey {
+	keys := make([]sortKey, len(rows))
+
+	numeric := make([]bool, len(columns))
+	timed := make([]bool, len(columns))
+	layouts := make([]string, len(columns))
+	versioned := make([]bool, len(columns))
+	addressed := make([]bool, len(columns))
+	collated := make([]bool, len(columns))
+
+	for c, col := range columns {
+		numeric[c] = isNumericHint(col.TypeHint)
+		timed[c] = col.TypeHint == SortTime
+		versioned[c] = col.TypeHint == SortSemver
+		addressed[c] = col.TypeHint == SortIP
+		collated[c] = collator != nil && kindCompare(col.TypeHint) == nil
+
+		if layout, ok := col.TypeHint.(TimeLayout); ok {
+			layouts[c] = string(layout)
+		}
+	}
+
+	// One allocation for the values of all rows
+	values := make([]sortValue, len(rows)*len(columns))
+
+	// Storage for collation keys, which remain valid until the buffer is reset
+	var buf collate.Buffer
+
+	for i, r := range rows {
+		keys[i] = sortKey{
+			row:    r,
+			pos:    i,
+			values: values[i*len(columns) : (i+1)*len(columns)],
+		}
+
+		for c, col := range columns {
+			v := &keys[i].values[c]
+			v.str = r.Data[col.Index]
+
+			switch {
+			case numeric[c]:
+				if f, err := strconv.ParseFloat(v.str, 64); err == nil {
+					v.num = f
+					v.numeric = true
+				}
+			case timed[c]:
+				v.tm, v.timed = parseSortTime(v.str)
+			case layouts[c] != "":
+				if t, err := time.Parse(layouts[c], v.str); err == nil {
+					v.tm, v.timed = t, true
+				}
+			case versioned[c]:
+				v.ver, v.versioned = parseSemver(v.str)
+			case addressed[c]:
+				v.ip, v.bits, v.addressed = parseIP(v.str)
+			}
+
+			if collated[c] && !v.numeric && !v.timed {
+				v.key = collator.KeyFromString(&buf, v.str)
+				v.collated = true
+			}
+		}
+	}
+
+	return keys
+}
+
+// isNumericHint returns true if the type hint passed to SortBy is any numeric type.
+func isNumericHint(typeHint interface{}) bool {
+	switch typeHint.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return true
+	}
+
+	return false
+}
+
+// sortState records the last sort, shown by indicators in the column headers.
+type sortState struct {
+	// Sort columns in priority order, or nil if not sorted
+	columns []SortColumn
+}
+
+// priority returns the position of a column in the last sort, or -1 if it is not a sort column.
+func (s sortState) priority(index int) int {
+	for i, c := range s.columns {
+		if c.Index == index {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// IndicatorPlacement sets which side of the column title the sort indicator is placed.
+type IndicatorPlacement int
+
+const (
+	IndicatorAfter IndicatorPlacement = iota
+	IndicatorBefore
+)
+
+// SortIndicators defines how the sort column is marked in the table header.
+type SortIndicators struct {
+	// Glyphs marking ascending and descending order. If both are empty, no indicator is shown.
+	Ascending  string
+	Descending string
+
+	// Side of the title on which the indicator is placed, separated from it by a space
+	Placement IndicatorPlacement
+
+	// Style applied to the indicator, independent of the header style
+	Style lipgloss.Style
+}
+
+// DefaultSortIndicators returns the default sort indicators, which are triangles after the title.
+func DefaultSortIndicators() SortIndicators {
+	return SortIndicators{
+		Ascending:  "▲",
+		Descending: "▼",
+		Placement:  IndicatorAfter,
+	}
+}
+
+// ASCIISortIndicators returns sort indicators for terminals or themes limited to ASCII output.
+func ASCIISortIndicators() SortIndicators {
+	return SortIndicators{
+		Ascending:  "^",
+		Descending: "v",
+		Placement:  IndicatorAfter,
+	}
+}
+
+// WithSortIndicators sets the glyphs, placement and style of the indicator marking the sort column.
+func WithSortIndicators(s SortIndicators) Option {
+	return func(m *Model) {
+		m.sortIndicators = s
+	}
+}
+
+// SetSortIndicators sets the glyphs, placement and style of the indicator marking the sort column.
+func (m *Model) SetSortIndicators(s SortIndicators) {
+	m.sortIndicators = s
+}
+
+// headerTitle returns the title of a column, truncated to its width, with the sort indicator if it is a sort column.
+// When sorted by several columns, the indicator is followed by the column's priority, numbered from 1.
+func (m Model) headerTitle(index int) string {
+	col := m.cols[index]
+	priority := m.sorted.priority(index)
+
+	if priority < 0 {
+		return runewidth.Truncate(col.Title, col.Width, "…")
+	}
+
+	glyph := m.sortIndicators.Ascending
+	if m.sorted.columns[priority].Order == SortDescending {
+		glyph = m.sortIndicators.Descending
+	}
+
+	if glyph == "" {
+		return runewidth.Truncate(col.Title, col.Width, "…")
+	}
+
+	if len(m.sorted.columns) > 1 {
+		glyph += strconv.Itoa(priority + 1)
+	}
+
+	// The indicator takes priority over the title when space is short
+	title := runewidth.Truncate(col.Title, max(col.Width-runewidth.StringWidth(glyph)-1, 0), "…")
+	glyph = m.sortIndicators.Style.Render(glyph)
+
+	switch {
+	case title == "":
+		return glyph
+	case m.sortIndicators.Placement == IndicatorBefore:
+		return glyph + " " + title
+	default:
+		return title + " " + glyph
+	}
+}
+
+// WithQuickSortKeys enables sorting by column number from the keyboard. When the table is focused,
+// the keys bound to KeyMap.SortAscending (by default 1 to 9, then 0 for the tenth column) sort
+// by that column in ascending order, and KeyMap.SortDescending (the same with alt) in descending order.
+// Columns are numbered from 1, not counting the row number column. Each column is sorted according
+// to its SortHint, by default numerically where its values are numbers.
+func WithQuickSortKeys() Option {
+	return func(m *Model) {
+		m.quickSort = true
+	}
+}
+
+// setQuickSortEnabled enables or disables the key bindings for sorting by column number.
+func (km *KeyMap) setQuickSortEnabled(enabled bool) {
+	km.SortAscending.SetEnabled(enabled)
+	km.SortDescending.SetEnabled(enabled)
+}
+
+// sortByKey sorts by the column whose number was pressed.
+func (m *Model) sortByKey(msg tea.KeyMsg, order SortOrder) {
+	if len(msg.Runes) == 0 {
+		return
+	}
+
+	index := quickSortColumn(msg.Runes[0])
+	if index < 0 {
+		return
+	}
+
+	if m.rowNumbers {
+		index++
+	}
+
+	if index >= len(m.cols) {
+		return
+	}
+
+	m.SortBy(index, order, m.columnSortHint(index))
+}
+
+// quickSortColumn converts a digit key to a zero based column index, with 0 being the tenth column.
+// It returns -1 if the key is not a digit.
+func quickSortColumn(r rune) int {
+	if r < '0' || r > '9' {
+		return -1
+	}
+
+	if r == '0' {
+		return 9
+	}
+
+	return int(r - '1')
+}
@@ -0,0 +1,346 @@
+package xtable
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+func TestSortByIsStable(t *testing.T) {
+	table := New(
+		WithColumns([]Column{
+			{Title: "Name", Width: 10},
+			{Title: "Size", Width: 10},
+		}),
+		WithRows([]Row{
+			{Data: []string{"a", "2"}},
+			{Data: []string{"b", "1"}},
+			{Data: []string{"c", "2"}},
+			{Data: []string{"d", "1"}},
+		}),
+	)
+
+	table.SortBy(1, SortAscending, SortNumeric)
+	require.Equal(t, []string{"b", "d", "a", "c"}, columnValues(table.Rows(), 0))
+
+	table.SortBy(1, SortDescending, SortNumeric)
+	require.Equal(t, []string{"a", "c", "b", "d"}, columnValues(table.Rows(), 0))
+}
+
+func TestSortByPreservesPreviousOrder(t *testing.T) {
+	table := New(
+		WithColumns([]Column{
+			{Title: "Name", Width: 10},
+			{Title: "Team", Width: 10},
+		}),
+		WithRows([]Row{
+			{Data: []string{"dave", "red"}},
+			{Data: []string{"carol", "blue"}},
+			{Data: []string{"bob", "red"}},
+			{Data: []string{"erin", "green"}},
+			{Data: []string{"alice", "blue"}},
+		}),
+		WithFocused(true),
+		WithQuickSortKeys(),
+		WithFilterDebounce(time.Millisecond),
+	)
+
+	// Sort by name, then by team using the quick sort keys. Names remain ordered within each team.
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")})
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+	require.Equal(t, []string{"alice", "carol", "erin", "bob", "dave"}, columnValues(table.Rows(), 0))
+
+	// Rows hidden by a filter keep their relative order too
+	table = runFilter(t, table, table.SetFilterText("red"))
+	table.SortBy(1, SortDescending, SortString)
+
+	table.SetFilterText("")
+	require.Equal(t, []string{"bob", "dave", "erin", "alice", "carol"}, columnValues(table.Rows(), 0))
+}
+
+func columnValues(rows []Row, col int) []string {
+	values := make([]string, len(rows))
+	for i, r := range rows {
+		values[i] = r.Data[col]
+	}
+
+	return values
+}
+
+func makeBenchmarkRows(n int) []Row {
+	r := rand.New(rand.NewSource(42)) //nolint:gosec
+
+	rows := make([]Row, n)
+	for i := range rows {
+		rows[i] = Row{
+			Data: []string{
+				strconv.FormatInt(r.Int63(), 36),
+				strconv.Itoa(r.Intn(1000000)),
+				strconv.FormatFloat(r.Float64()*1000, 'f', 3, 64),
+			},
+		}
+	}
+
+	return rows
+}
+
+func benchmarkSortBy(b *testing.B, index int, typeHint interface{}) {
+	source := makeBenchmarkRows(100000)
+	rows := make([]Row, len(source))
+
+	table := New(
+		WithColumns([]Column{
+			{Title: "Strings", Width: 10},
+			{Title: "Ints", Width: 10},
+			{Title: "Floats", Width: 10},
+		}),
+	)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		copy(rows, source)
+		table.rows = rows
+		b.StartTimer()
+
+		table.SortBy(index, SortAscending, typeHint)
+	}
+}
+
+func BenchmarkSortByString100k(b *testing.B) {
+	benchmarkSortBy(b, 0, SortString)
+}
+
+func BenchmarkSortByInt100k(b *testing.B) {
+	benchmarkSortBy(b, 1, SortNumeric)
+}
+
+func BenchmarkSortByFloat100k(b *testing.B) {
+	benchmarkSortBy(b, 2, SortNumeric)
+}
+
+func TestQuickSortKeys(t *testing.T) {
+	data := []rowData{
+		newRowData("Hobnobs", 10),
+		newRowData("Tim Tams", 8),
+		newRowData("Chocolate Digestives", 12),
+	}
+
+	table := New(WithStructData(data), WithRowNumbers(), WithFocused(true), WithQuickSortKeys())
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	require.Equal(t, []string{"Chocolate Digestives", "Hobnobs", "Tim Tams"}, columnValues(table.Rows(), 1))
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}, Alt: true})
+	require.Equal(t, []string{"12", "10", "8"}, columnValues(table.Rows(), 2))
+}
+
+func TestQuickSortKeysDisabled(t *testing.T) {
+	data := []rowData{
+		newRowData("Hobnobs", 10),
+		newRowData("Tim Tams", 8),
+	}
+
+	table := New(WithStructData(data), WithFocused(true))
+	require.False(t, table.KeyMap.SortAscending.Enabled())
+
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	require.Equal(t, []string{"10", "8"}, columnValues(table.Rows(), 1))
+}
+
+func TestSortByKeepsSelection(t *testing.T) {
+	data := make([]rowData, 50)
+	for i := range data {
+		data[i] = newRowData("Biscuit "+strconv.Itoa(i), i)
+	}
+
+	table := New(WithStructData(data), WithHeight(10))
+	table.SetCursor(2)
+
+	table.SortBy(1, SortDescending, SortNumeric)
+	require.Equal(t, "2", table.SelectedRow().Data[1])
+	require.Equal(t, 47, table.Cursor())
+	require.True(t, table.SelectedRowYOffset() >= 0 && table.SelectedRowYOffset() < table.viewport.Height)
+}
+
+func TestSortByKeepsSelectionWithoutMetadata(t *testing.T) {
+	table := New(
+		WithColumns([]Column{{Title: "Name", Width: 10}}),
+		WithRows([]Row{{Data: []string{"c"}}, {Data: []string{"a"}}, {Data: []string{"b"}}}),
+	)
+
+	table.SortBy(0, SortAscending, SortString)
+	require.Equal(t, "c", table.SelectedRow().Data[0])
+	require.Equal(t, 2, table.Cursor())
+}
+
+func TestSortByIndexStableCursor(t *testing.T) {
+	data := make([]rowData, 50)
+	for i := range data {
+		data[i] = newRowData("Biscuit "+strconv.Itoa(i), i)
+	}
+
+	table := New(WithStructData(data), WithHeight(10), WithIndexStableCursor())
+	table.SetCursor(2)
+
+	table.SortBy(1, SortDescending, SortNumeric)
+	require.Equal(t, 2, table.Cursor())
+	require.Equal(t, "47", table.SelectedRow().Data[1])
+
+	table.ClearSort()
+	require.Equal(t, 2, table.Cursor())
+	require.Equal(t, "2", table.SelectedRow().Data[1])
+
+	// A followed row remains selected
+	table.FollowRow(data[5].GetHashCode())
+	table.SortBy(1, SortDescending, SortNumeric)
+	require.Equal(t, "5", table.SelectedRow().Data[1])
+}
+
+func TestSortIndicators(t *testing.T) {
+	newTable := func(opts ...Option) Model {
+		return New(append([]Option{
+			WithColumns([]Column{{Title: "Name", Width: 8}, {Title: "Quantity", Width: 8}}),
+			WithRows([]Row{{Data: []string{"a", "1"}}, {Data: []string{"b", "2"}}}),
+			WithStyles(Styles{}),
+		}, opts...)...)
+	}
+
+	table := newTable()
+	require.Equal(t, "Name    Quantity", table.headersView())
+
+	table.SortBy(0, SortAscending, SortString)
+	require.Equal(t, "Name ▲  Quantity", table.headersView())
+
+	table.SortBy(1, SortDescending, SortNumeric)
+	require.Equal(t, "Name    Quant… ▼", table.headersView())
+
+	index, order, ok := table.SortedBy()
+	require.True(t, ok)
+	require.Equal(t, 1, index)
+	require.Equal(t, SortDescending, order)
+
+	indicators := ASCIISortIndicators()
+	indicators.Placement = IndicatorBefore
+	table = newTable(WithSortIndicators(indicators))
+	table.SortBy(0, SortDescending, SortString)
+	require.Equal(t, "v Name  Quantity", table.headersView())
+
+	table = newTable(WithSortIndicators(SortIndicators{}))
+	table.SortBy(0, SortDescending, SortString)
+	require.Equal(t, "Name    Quantity", table.headersView())
+}
+
+func TestSortByMulti(t *testing.T) {
+	table := New(
+		WithColumns([]Column{{Title: "Name", Width: 8}, {Title: "Age", Width: 8}}),
+		WithRows([]Row{
+			{Data: []string{"Bob", "30"}},
+			{Data: []string{"Alice", "9"}},
+			{Data: []string{"Bob", "4"}},
+			{Data: []string{"Alice", "25"}},
+		}),
+		WithStyles(Styles{}),
+	)
+
+	table.SortByMulti(
+		SortColumn{Index: 0, Order: SortAscending, TypeHint: SortString},
+		SortColumn{Index: 1, Order: SortDescending, TypeHint: SortNumeric},
+	)
+
+	require.Equal(t, []string{"Alice", "Alice", "Bob", "Bob"}, columnValues(table.Rows(), 0))
+	require.Equal(t, []string{"25", "9", "30", "4"}, columnValues(table.Rows(), 1))
+	require.Equal(t, "Name ▲1 Age ▼2  ", table.headersView())
+
+	state := table.SortState()
+	require.Len(t, state, 2)
+	require.Equal(t, 1, state[1].Index)
+	require.Equal(t, SortDescending, state[1].Order)
+
+	index, order, ok := table.SortedBy()
+	require.True(t, ok)
+	require.Equal(t, 0, index)
+	require.Equal(t, SortAscending, order)
+
+	// A column out of range leaves the table unchanged
+	table.SortByMulti(SortColumn{Index: 1}, SortColumn{Index: 2})
+	require.Len(t, table.SortState(), 2)
+}
+
+func TestSortByColumnComparator(t *testing.T) {
+	priority := map[string]int{"low": 0, "medium": 1, "high": 2}
+
+	table := New(
+		WithColumns([]Column{
+			{Title: "Task", Width: 8},
+			{Title: "Priority", Width: 8, Less: func(a, b string) bool { return priority[a] < priority[b] }},
+		}),
+		WithRows([]Row{
+			{Data: []string{"a", "medium"}},
+			{Data: []string{"b", "high"}},
+			{Data: []string{"c", "low"}},
+			{Data: []string{"d", "high"}},
+		}),
+	)
+
+	// The hint is ignored in favour of the comparator
+	table.SortBy(1, SortAscending, SortString)
+	require.Equal(t, []string{"low", "medium", "high", "high"}, columnValues(table.Rows(), 1))
+
+	// Ties remain in their original order
+	table.SortBy(1, SortDescending, SortString)
+	require.Equal(t, []string{"b", "d", "a", "c"}, columnValues(table.Rows(), 0))
+}
+
+func TestSortWithCollator(t *testing.T) {
+	rows := []Row{
+		{Data: []string{"Zebra"}},
+		{Data: []string{"Äpfel"}},
+		{Data: []string{"apfel"}},
+		{Data: []string{"Birne"}},
+	}
+
+	table := New(WithColumns([]Column{{Title: "Name", Width: 10}}), WithRows(rows))
+	table.SortBy(0, SortAscending, SortString)
+	require.Equal(t, []string{"Birne", "Zebra", "apfel", "Äpfel"}, columnValues(table.Rows(), 0))
+
+	table = New(
+		WithColumns([]Column{{Title: "Name", Width: 10}}),
+		WithRows(rows),
+		WithCollator(collate.New(language.German)),
+	)
+	table.SortBy(0, SortAscending, SortString)
+	require.Equal(t, []string{"apfel", "Äpfel", "Birne", "Zebra"}, columnValues(table.Rows(), 0))
+
+	table.SortBy(0, SortDescending, SortString)
+	require.Equal(t, []string{"Zebra", "Birne", "Äpfel", "apfel"}, columnValues(table.Rows(), 0))
+}
+
+func TestSortNotSortable(t *testing.T) {
+	table := New(
+		WithColumns([]Column{{Title: "Name", Width: 10}, {Title: "Actions", Width: 10, NotSortable: true}}),
+		WithRows([]Row{{Data: []string{"b", "edit"}}, {Data: []string{"a", "view"}}}),
+		WithRowNumbers(),
+	)
+
+	require.False(t, table.IsSortable(0))
+	require.True(t, table.IsSortable(1))
+	require.False(t, table.IsSortable(2))
+	require.False(t, table.IsSortable(3))
+
+	table.SortBy(2, SortDescending, SortString)
+	table.ToggleSort(0)
+	table.SortByMulti(SortColumn{Index: 1}, SortColumn{Index: 2})
+	require.Empty(t, table.SortState())
+	require.Equal(t, []string{"b", "a"}, columnValues(table.Rows(), 1))
+
+	table.ToggleSort(1)
+	require.Equal(t, []string{"a", "b"}, columnValues(table.Rows(), 1))
+}
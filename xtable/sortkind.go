@@ -0,0 +1,369 @@
+package xtable
+
+import (
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// SortKind is a type hint for SortBy selecting a specialised ordering of a column's values.
+type SortKind int
+
+const (
+	// SortNatural orders runs of digits within values numerically, and the rest as strings,
+	// so that "file2" sorts before "file10". Suitable for filenames, hostnames and versioned identifiers.
+	SortNatural SortKind = iota + 1
+
+	// SortSemver orders semantic versions such as v1.10.0 and 1.9.3-rc.1 by precedence, with or without
+	// a leading v and with missing minor or patch numbers taken as zero. Values that are not versions
+	// sort after all versions, as strings.
+	SortSemver
+
+	// SortIP orders IPv4 and IPv6 addresses numerically, so that 10.0.0.2 sorts before 10.0.0.10,
+	// with IPv4 before IPv6 addresses. Values in CIDR notation are ordered by address, then prefix length.
+	// Values that are not addresses sort after all addresses, as strings.
+	SortIP
+
+	// SortTime orders timestamps chronologically, in RFC 3339 format, "2006-01-02 15:04:05",
+	// "2006-01-02", or the format of time.Time.String. As for numeric sorting,
+	// values that are not timestamps are compared as strings.
+	SortTime
+
+	// SortAuto chooses numeric, time or string comparison from the column's values when sorting:
+	// numeric if all non-empty values are numbers, otherwise time if all are timestamps, otherwise string.
+	// A nil type hint is equivalent.
+	SortAuto
+)
+
+// sortTimeLayouts are the timestamp formats recognised by SortTime and SortAuto.
+var sortTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+}
+
+// TimeLayout is a type hint for SortBy ordering timestamps in the given layout chronologically,
+// e.g. TimeLayout(time.Kitchen). As for SortTime, values that are not timestamps in the layout
+// are compared as strings.
+type TimeLayout string
+
+// kindCompare returns the comparison function for a SortKind type hint, or nil for other hints.
+func kindCompare(typeHint interface{}) func(a, b *sortValue) int {
+	kind, ok := typeHint.(SortKind)
+	if !ok {
+		return nil
+	}
+
+	switch kind {
+	case SortNatural:
+		return func(a, b *sortValue) int {
+			return naturalCompare(a.str, b.str)
+		}
+	case SortSemver:
+		return semverCompare
+	case SortIP:
+		return ipCompare
+	}
+
+	return nil
+}
+
+// resolveSortHints returns the sort columns with nil and SortAuto type hints replaced by
+// the hint detected from the values of the column.
+func resolveSortHints(rows []Row, columns []SortColumn) []SortColumn {
+	resolved := make([]SortColumn, len(columns))
+
+	for i, c := range columns {
+		resolved[i] = c
+
+		if c.TypeHint == nil || c.TypeHint == SortAuto {
+			resolved[i].TypeHint = detectSortHint(rows, c.Index)
+		}
+	}
+
+	return resolved
+}
+
+// detectSortHint returns SortNumeric if all non-empty values of a column are numbers, otherwise SortTime
+// if all are timestamps, otherwise SortString.
+func detectSortHint(rows []Row, index int) interface{} {
+	numeric, timed, found := true, true, false
+
+	for _, r := range rows {
+		value := strings.TrimSpace(r.Data[index])
+		if value == "" {
+			continue
+		}
+
+		found = true
+
+		if numeric {
+			_, err := strconv.ParseFloat(value, 64)
+			numeric = err == nil
+		}
+
+		if timed {
+			_, timed = parseSortTime(value)
+		}
+
+		if !numeric && !timed {
+			break
+		}
+	}
+
+	switch {
+	case !found:
+		return SortString
+	case numeric:
+		return SortNumeric
+	case timed:
+		return SortTime
+	}
+
+	return SortString
+}
+
+// parseSortTime parses a timestamp in any of the layouts recognised by SortTime.
+func parseSortTime(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+
+	// Remove the monotonic clock reading included when formatting a time.Time from time.Now
+	if i := strings.Index(value, " m="); i >= 0 {
+		value = value[:i]
+	}
+
+	for _, layout := range sortTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// naturalCompare compares two strings in natural order, returning -1, 0 or 1. Runs of digits are compared
+// by numeric value, and other characters one by one. Strings that differ only in leading zeros are ordered
+// as strings, so that the order is total.
+func naturalCompare(a, b string) int {
+	i, j := 0, 0
+
+	for i < len(a) && j < len(b) {
+		if isDigit(a[i]) && isDigit(b[j]) {
+			na, nb := digitRun(a[i:]), digitRun(b[j:])
+
+			if c := compareDigits(na, nb); c != 0 {
+				return c
+			}
+
+			i += len(na)
+			j += len(nb)
+
+			continue
+		}
+
+		ra, sa := utf8.DecodeRuneInString(a[i:])
+		rb, sb := utf8.DecodeRuneInString(b[j:])
+
+		if ra != rb {
+			if ra < rb {
+				return -1
+			}
+
+			return 1
+		}
+
+		i += sa
+		j += sb
+	}
+
+	switch {
+	case len(a)-i < len(b)-j:
+		return -1
+	case len(a)-i > len(b)-j:
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
+
+// digitRun returns the leading run of ASCII digits of s.
+func digitRun(s string) string {
+	n := 0
+	for n < len(s) && isDigit(s[n]) {
+		n++
+	}
+
+	return s[:n]
+}
+
+// compareDigits compares two runs of digits by numeric value, without limit on their length.
+func compareDigits(a, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
+
+// isDigit returns true for the ASCII digits.
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// semver is a parsed semantic version. Build metadata is discarded, as it does not affect precedence.
+type semver struct {
+	release    [3]uint64
+	prerelease []string
+}
+
+// parseSemver parses a semantic version, with optional leading v and optional minor and patch numbers.
+func parseSemver(s string) (semver, bool) {
+	var v semver
+
+	s = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(s), "v"), "V")
+
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		v.prerelease = strings.Split(s[i+1:], ".")
+		s = s[:i]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > len(v.release) {
+		return v, false
+	}
+
+	for i, p := range parts {
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return v, false
+		}
+
+		v.release[i] = n
+	}
+
+	return v, true
+}
+
+// semverCompare compares two values parsed as semantic versions by precedence, returning -1, 0 or 1.
+// Values that are not versions sort after versions, and are compared as strings.
+func semverCompare(a, b *sortValue) int {
+	switch {
+	case !a.versioned && !b.versioned:
+		return strings.Compare(a.str, b.str)
+	case !a.versioned:
+		return 1
+	case !b.versioned:
+		return -1
+	}
+
+	for i := range a.ver.release {
+		switch {
+		case a.ver.release[i] < b.ver.release[i]:
+			return -1
+		case a.ver.release[i] > b.ver.release[i]:
+			return 1
+		}
+	}
+
+	return comparePrerelease(a.ver.prerelease, b.ver.prerelease)
+}
+
+// comparePrerelease compares the pre-release identifiers of two versions with equal release numbers.
+// A version without pre-release identifiers has higher precedence. Numeric identifiers are compared
+// numerically and have lower precedence than others, which are compared as strings.
+func comparePrerelease(a, b []string) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		na, errA := strconv.ParseUint(a[i], 10, 64)
+		nb, errB := strconv.ParseUint(b[i], 10, 64)
+
+		switch {
+		case errA == nil && errB == nil:
+			if na != nb {
+				if na < nb {
+					return -1
+				}
+
+				return 1
+			}
+		case errA == nil:
+			return -1
+		case errB == nil:
+			return 1
+		default:
+			if c := strings.Compare(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+	}
+
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+
+	return 0
+}
+
+// parseIP parses an IP address, or a prefix in CIDR notation. The prefix length of an address is -1.
+func parseIP(s string) (netip.Addr, int, bool) {
+	s = strings.TrimSpace(s)
+
+	if strings.Contains(s, "/") {
+		p, err := netip.ParsePrefix(s)
+		return p.Addr(), p.Bits(), err == nil
+	}
+
+	a, err := netip.ParseAddr(s)
+
+	return a, -1, err == nil
+}
+
+// ipCompare compares two values parsed as IP addresses or CIDR prefixes numerically, returning -1, 0 or 1.
+// Values that are not addresses sort after addresses, and are compared as strings.
+func ipCompare(a, b *sortValue) int {
+	switch {
+	case !a.addressed && !b.addressed:
+		return strings.Compare(a.str, b.str)
+	case !a.addressed:
+		return 1
+	case !b.addressed:
+		return -1
+	}
+
+	if c := a.ip.Compare(b.ip); c != 0 {
+		return c
+	}
+
+	switch {
+	case a.bits < b.bits:
+		return -1
+	case a.bits > b.bits:
+		return 1
+	}
+
+	return 0
+}
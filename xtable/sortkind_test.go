@@ -0,0 +1,99 @@
+package xtable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func kindTable(values ...string) Model {
+	rows := make([]Row, len(values))
+	for i, v := range values {
+		rows[i] = Row{Data: []string{v}}
+	}
+
+	return New(WithColumns([]Column{{Title: "Value", Width: 20}}), WithRows(rows))
+}
+
+func TestSortNatural(t *testing.T) {
+	table := kindTable("file10", "file2", "File1", "file02", "file1.txt", "host-a10", "host-a9", "file")
+
+	table.SortBy(0, SortAscending, SortNatural)
+	require.Equal(t,
+		[]string{"File1", "file", "file1.txt", "file02", "file2", "file10", "host-a9", "host-a10"},
+		columnValues(table.Rows(), 0),
+	)
+
+	table.SortBy(0, SortDescending, SortNatural)
+	require.Equal(t, "host-a10", table.Rows()[0].Data[0])
+}
+
+func TestNaturalCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"a2", "a10", -1},
+		{"a10", "a2", 1},
+		{"a10", "a10", 0},
+		{"a010", "a10", -1},
+		{"v1.9.3", "v1.10.0", -1},
+		{"123456789012345678901234567890", "99", 1},
+		{"a", "a1", -1},
+		{"", "0", -1},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, naturalCompare(tt.a, tt.b), "%q vs %q", tt.a, tt.b)
+	}
+}
+
+func TestSortSemver(t *testing.T) {
+	table := kindTable("v1.10.0", "v1.9.3", "1.9.3-rc.1", "unreleased", "v2", "1.9.3-beta.11", "1.9.3-beta.2", "1.9.3-beta", "v1.9.3+build.5")
+
+	table.SortBy(0, SortAscending, SortSemver)
+	require.Equal(t,
+		[]string{"1.9.3-beta", "1.9.3-beta.2", "1.9.3-beta.11", "1.9.3-rc.1", "v1.9.3", "v1.9.3+build.5", "v1.10.0", "v2", "unreleased"},
+		columnValues(table.Rows(), 0),
+	)
+}
+
+func TestSortIP(t *testing.T) {
+	table := kindTable("10.0.0.10", "::1", "10.0.0.2", "unknown", "10.0.0.0/8", "192.168.1.1", "10.0.0.0/16", "fe80::1", "2.255.255.255")
+
+	table.SortBy(0, SortAscending, SortIP)
+	require.Equal(t,
+		[]string{"2.255.255.255", "10.0.0.0/8", "10.0.0.0/16", "10.0.0.2", "10.0.0.10", "192.168.1.1", "::1", "fe80::1", "unknown"},
+		columnValues(table.Rows(), 0),
+	)
+}
+
+func TestSortAuto(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		hint   interface{}
+		want   []string
+	}{
+		{"numeric", []string{"10", "9", "", "-1.5"}, nil, []string{"", "-1.5", "9", "10"}},
+		{"time", []string{"2024-03-01", "2023-12-31T23:00:00Z", "2024-01-15 08:00:00"}, SortAuto, []string{"2023-12-31T23:00:00Z", "2024-01-15 08:00:00", "2024-03-01"}},
+		{"string", []string{"10", "9", "b", "a"}, nil, []string{"10", "9", "a", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := kindTable(tt.values...)
+			table.SortBy(0, SortAscending, tt.hint)
+			require.Equal(t, tt.want, columnValues(table.Rows(), 0))
+		})
+	}
+}
+
+func TestSortAutoState(t *testing.T) {
+	table := kindTable("2024-03-01", "2023-12-31")
+	table.SortBy(0, SortDescending, nil)
+
+	state := table.SortState()
+	require.Len(t, state, 1)
+	require.Equal(t, SortTime, state[0].TypeHint)
+}
@@ -0,0 +1,78 @@
+package xtable
+
+// RowSource provides the rows of a table on demand, e.g. from a database or file with too many rows to load
+// into memory. The table requests only the rows it renders, so RowAt should be fast for the rows around the
+// cursor, e.g. by caching a page of rows at a time.
+type RowSource interface {
+	// Len returns the number of rows.
+	Len() int
+
+	// RowAt returns the row at index i, where 0 <= i < Len(). The row's data should not include the row number
+	// cell, which is added by the table if row numbers are enabled.
+	RowAt(i int) Row
+}
+
+// SortableRowSource is a RowSource that sorts its own rows, e.g. by the ORDER BY clause of a query.
+// When the table is sorted, Sort is called instead of sorting rows held by the table.
+type SortableRowSource interface {
+	RowSource
+
+	// Sort orders the rows by the given columns, in priority order, or restores their original order if
+	// columns is empty. Column indexes are those of the table, including any row number column, and type hints
+	// are as passed to SortByMulti.
+	Sort(columns []SortColumn)
+}
+
+// WithRowSource displays the rows of a RowSource instead of rows held by the table, so that tables of millions
+// of rows can be displayed. See SetRowSource.
+func WithRowSource(src RowSource) Option {
+	return func(m *Model) {
+		m.source = src
+		m.rows = nil
+	}
+}
+
+// SetRowSource displays the rows of a RowSource instead of rows held by the table, or if src is nil,
+// returns to the rows held by the table. The cursor is kept at the same index, if in range, and moving
+// the cursor, rendering, Find, FindInColumn and FindAll operate on the rows of the source, requesting rows as
+// required. FindAll requests every row of the source, so may be slow for large sources.
+// Sorting is delegated to the source if it implements SortableRowSource, otherwise it is not available.
+//
+// Operations on the rows held by the table, such as filtering, marking, editing, adding and removing rows,
+// and Rows and AllRows, do not apply to the rows of a source. Call SetRowSource again after the number
+// of rows of the source changes.
+func (m *Model) SetRowSource(src RowSource) {
+	m.source = src
+	m.cursor = clamp(m.cursor, 0, m.rowCount()-1)
+	m.UpdateViewport()
+	m.scrollToCursor()
+}
+
+// RowSource returns the source of the table's rows set by SetRowSource, or nil if the table holds its own rows.
+func (m Model) RowSource() RowSource {
+	return m.source
+}
+
+// rowCount returns the number of rows shown, by the row source if set.
+func (m Model) rowCount() int {
+	if m.source != nil {
+		return m.source.Len()
+	}
+
+	return len(m.rows)
+}
+
+// rowAt returns the row shown at index i, requested from the row source if set,
+// with the row number cell added if row numbers are enabled.
+func (m Model) rowAt(i int) Row {
+	if m.source == nil {
+		return m.rows[i]
+	}
+
+	r := m.source.RowAt(i)
+	if m.rowNumbers {
+		r.Data = append([]string{pad(digits(m.source.Len()), i+1)}, r.Data...)
+	}
+
+	return r
+}
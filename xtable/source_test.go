@@ -0,0 +1,95 @@
+package xtable
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// countingSource generates rows on demand, counting the rows requested.
+type countingSource struct {
+	n         int
+	requested int
+	desc      bool
+	sorts     int
+}
+
+func (s *countingSource) Len() int {
+	return s.n
+}
+
+func (s *countingSource) RowAt(i int) Row {
+	s.requested++
+
+	if s.desc {
+		i = s.n - 1 - i
+	}
+
+	return Row{Data: []string{"row" + strconv.Itoa(i), strconv.Itoa(i * 2)}}
+}
+
+func (s *countingSource) Sort(columns []SortColumn) {
+	s.sorts++
+	s.desc = len(columns) > 0 && columns[0].Order == SortDescending
+}
+
+func TestRowSource(t *testing.T) {
+	src := &countingSource{n: 1000000}
+	table := New(
+		WithColumns([]Column{{Title: "Name", Width: 10}, {Title: "Value", Width: 10}}),
+		WithRowSource(src),
+		WithHeight(6),
+	)
+
+	require.Less(t, src.requested, 20, "only rows around the cursor are requested")
+	require.Contains(t, table.View(), "row0")
+	require.Nil(t, table.Rows())
+
+	table.GotoBottom()
+	require.Equal(t, 999999, table.Cursor())
+	require.Equal(t, "row999999", table.SelectedRow().Data[0])
+	require.Contains(t, table.View(), "row999999")
+
+	table.SetCursor(500)
+	require.True(t, table.FindInColumn(1, "1004", 500))
+	require.Equal(t, "row502", table.SelectedRow().Data[0])
+	require.Less(t, src.requested, 100)
+
+	table.SortBy(0, SortDescending, SortString)
+	require.Equal(t, 1, src.sorts)
+	require.Equal(t, "row999497", table.SelectedRow().Data[0], "cursor remains at the same index")
+
+	table.ClearSort()
+	require.Equal(t, 2, src.sorts)
+	require.False(t, src.desc)
+
+	table.SetRowSource(nil)
+	require.Nil(t, table.RowSource())
+	require.Empty(t, table.SelectedRow().Data)
+}
+
+func TestRowSourceRowNumbers(t *testing.T) {
+	src := &countingSource{n: 1000}
+	table := New(
+		WithColumns([]Column{{Title: "Name", Width: 10}, {Title: "Value", Width: 10}}),
+		WithRowSource(src),
+		WithRowNumbers(),
+	)
+
+	require.Equal(t, 5, table.Columns()[0].Width)
+	table.SetCursor(41)
+	require.Equal(t, []string{"  42", "row41", "82"}, table.SelectedRow().Data)
+}
+
+func TestRowSourceFindAll(t *testing.T) {
+	src := &countingSource{n: 100}
+	table := New(
+		WithColumns([]Column{{Title: "Name", Width: 10}, {Title: "Value", Width: 10}}),
+		WithRowSource(src),
+		WithRowNumbers(),
+	)
+
+	require.Equal(t, []int{99}, table.FindAll("row99"))
+	require.Equal(t, []int{31, 62, 81}, table.FindAll("62"), "row numbers are not matched")
+}
@@ -0,0 +1,174 @@
+package xtable
+
+import (
+	"fmt"
+	"reflect"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// channelBatchSize is the maximum number of rows read from a channel before they are added to the table.
+const channelBatchSize = 256
+
+// ChannelClosedMsg is sent when a channel passed to AppendFromChannel or WatchRowEvents is closed and all its
+// rows or events have been applied.
+type ChannelClosedMsg struct {
+	// ID of the table that sent the message
+	TableID int
+}
+
+// channelRowsMsg carries rows read from a channel back to the table.
+type channelRowsMsg struct {
+	id     int
+	ch     <-chan Row
+	rows   []Row
+	closed bool
+}
+
+// WithRowIter sets the table rows from an iterator, such as a generator function.
+// The iterator has the same signature as a Go 1.23 iter.Seq[Row], so it can be used with
+// functions that return one, without the caller collecting the rows into a slice first.
+func WithRowIter(iter func(yield func(Row) bool)) Option {
+	return func(m *Model) {
+		m.rows = nil
+
+		iter(func(r Row) bool {
+			m.rows = append(m.rows, r)
+			return true
+		})
+	}
+}
+
+// WithStructSeq creates a table from an iterator of structs, or pointers to structs, implementing the Metadata
+// interface, as WithStructData does from a slice. The iterator has the same signature as a Go 1.23 iter.Seq[T],
+// so large or generated data sets can be fed into the table without collecting them into a slice first.
+// Unlike WithStructData, the iterator may yield no elements, as the columns are derived from T.
+//
+// Panics if T is not a struct or pointer to struct, or any field in fields is not found.
+func WithStructSeq[T Metadata](seq func(yield func(T) bool), fields ...string) Option {
+	return func(m *Model) {
+		schema, err := newStructSchema(typeOf[T](), fields, m.structOptions())
+		if err != nil {
+			panic(fmt.Sprintf("Cannot render table: %s", err.Error()))
+		}
+
+		m.cols = schema.columns
+		m.rows = nil
+		m.schema = schema
+
+		seq(func(elem T) bool {
+			v := reflect.ValueOf(elem)
+			if v.Kind() == reflect.Ptr && v.IsNil() {
+				return true
+			}
+
+			r := schema.row(v)
+			for j, valStr := range r.Data {
+				m.cols[j].Width = max(m.cols[j].Width, len(valStr))
+			}
+
+			m.rows = append(m.rows, r)
+
+			return true
+		})
+	}
+}
+
+// AppendFromChannel returns a command that streams rows from a channel into the table, e.g. from
+// a pipeline producing rows in the background. Rows are appended in batches, as they become available,
+// without changing the selected row. When the channel is closed, ChannelClosedMsg is sent.
+//
+// The table's Update must receive all messages for the rows to be added.
+func (m Model) AppendFromChannel(ch <-chan Row) tea.Cmd {
+	id := m.id
+
+	return func() tea.Msg {
+		return readChannel(id, ch)
+	}
+}
+
+// readChannel waits for a row from the channel, then reads any further rows that are immediately available.
+func readChannel(id int, ch <-chan Row) channelRowsMsg {
+	rows, closed := readBatch(ch)
+
+	return channelRowsMsg{id: id, ch: ch, rows: rows, closed: closed}
+}
+
+// readBatch waits for a value from the channel, then reads up to channelBatchSize values in total that are
+// immediately available. closed is true if the channel has been closed.
+func readBatch[T any](ch <-chan T) (batch []T, closed bool) {
+	v, ok := <-ch
+	if !ok {
+		return nil, true
+	}
+
+	batch = append(batch, v)
+
+	for len(batch) < channelBatchSize {
+		select {
+		case v, ok := <-ch:
+			if !ok {
+				return batch, true
+			}
+
+			batch = append(batch, v)
+		default:
+			return batch, false
+		}
+	}
+
+	return batch, false
+}
+
+// applyChannelRows adds rows read from a channel, and continues reading unless the channel is closed.
+func (m *Model) applyChannelRows(msg channelRowsMsg) tea.Cmd {
+	if len(msg.rows) > 0 {
+		m.appendRows(msg.rows)
+	}
+
+	if msg.closed {
+		id := m.id
+
+		return func() tea.Msg {
+			return ChannelClosedMsg{TableID: id}
+		}
+	}
+
+	return m.AppendFromChannel(msg.ch)
+}
+
+// appendRows adds rows to the end of the table without changing the selected row, unless following the tail.
+// The row number cell is added to the rows if row numbers are enabled.
+func (m *Model) appendRows(rows []Row) {
+	atTail := m.cursor >= len(m.rows)-1
+	rows = m.numberRows(rows)
+
+	for _, r := range rows {
+		m.emitRowAdded(r)
+	}
+
+	if m.filter.match != nil {
+		m.filter.all = append(m.filter.all, rows...)
+	} else {
+		m.rows = append(m.rows, rows...)
+	}
+
+	// Rows are added after the existing rows, so the cursor still indexes the selected row
+	m.refilter()
+	m.RenumberRows()
+
+	if m.follow.active {
+		m.restoreCursor(0, false)
+		return
+	}
+
+	if m.followTail && atTail {
+		m.cursor = max(len(m.rows)-1, 0)
+		m.UpdateViewport()
+		m.scrollToCursor()
+
+		return
+	}
+
+	m.UpdateViewport()
+}
@@ -0,0 +1,90 @@
+package xtable
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRowIter(t *testing.T) {
+	count := func(n int) func(yield func(Row) bool) {
+		return func(yield func(Row) bool) {
+			for i := 0; i < n; i++ {
+				if !yield(Row{Data: []string{strconv.Itoa(i)}}) {
+					return
+				}
+			}
+		}
+	}
+
+	table := New(WithColumns([]Column{{Title: "N", Width: 5}}), WithRowIter(count(5)))
+
+	require.Equal(t, []string{"0", "1", "2", "3", "4"}, columnValues(table.Rows(), 0))
+}
+
+func TestAppendFromChannel(t *testing.T) {
+	table := New(
+		WithColumns([]Column{{Title: "N", Width: 5}}),
+		WithRows([]Row{{Data: []string{"a"}}, {Data: []string{"b"}}}),
+	)
+	table.SetCursor(1)
+
+	ch := make(chan Row, channelBatchSize+10)
+	for i := 0; i < channelBatchSize+10; i++ {
+		ch <- Row{Data: []string{strconv.Itoa(i)}}
+	}
+	close(ch)
+
+	cmd := table.AppendFromChannel(ch)
+	batches := 0
+
+	for {
+		msg := cmd()
+		if closed, ok := msg.(ChannelClosedMsg); ok {
+			require.Equal(t, table.ID(), closed.TableID)
+			break
+		}
+
+		batches++
+		table, cmd = table.Update(msg)
+		require.NotNil(t, cmd)
+	}
+
+	require.Equal(t, 2, batches)
+	require.Len(t, table.Rows(), channelBatchSize+12)
+	require.Equal(t, "b", table.SelectedRow().Data[0], "selection should not move")
+}
+
+func TestAppendFromChannelIgnoresOtherTables(t *testing.T) {
+	table := New(WithColumns([]Column{{Title: "N", Width: 5}}))
+	other := New(WithColumns([]Column{{Title: "N", Width: 5}}))
+
+	ch := make(chan Row, 1)
+	ch <- Row{Data: []string{"x"}}
+	close(ch)
+
+	table, _ = table.Update(other.AppendFromChannel(ch)())
+	require.Empty(t, table.Rows())
+}
+
+func TestWithStructSeq(t *testing.T) {
+	biscuits := func(yield func(*rowData) bool) {
+		for i, name := range []string{"Hobnobs", "Tim Tams", "Chocolate Digestives"} {
+			r := newRowData(name, i+8)
+			if !yield(&r) || !yield(nil) {
+				return
+			}
+		}
+	}
+
+	table := New(WithStructSeq(biscuits))
+	require.Equal(t, 3, len(table.rows), "nil pointers are skipped")
+	require.Equal(t, []string{"Tim Tams", "9"}, table.rows[1].Data)
+	require.Equal(t, len("Chocolate Digestives"), table.cols[0].Width)
+	require.IsType(t, &rowData{}, table.rows[0].Metadata)
+
+	empty := New(WithStructSeq(func(yield func(rowData) bool) {}, "PacketSize"))
+	require.Equal(t, 0, len(empty.rows))
+	require.Equal(t, "PacketSize", empty.cols[0].Title)
+}
@@ -0,0 +1,562 @@
+package xtable
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// fieldTag is the parsed value of an "xtable" struct tag. The tag is a comma separated list
+// of which the first element is the column title, and the remainder are key=value options, e.g.
+//
+//	`xtable:"Progress,kind=progress"`
+type fieldTag struct {
+	title   string
+	options map[string]string
+}
+
+// parseTag parses the "xtable" tag of a struct field.
+func parseTag(field reflect.StructField) fieldTag {
+	parts := strings.Split(field.Tag.Get("xtable"), ",")
+	tag := fieldTag{
+		title:   strings.TrimSpace(parts[0]),
+		options: map[string]string{},
+	}
+
+	for _, opt := range parts[1:] {
+		k, v, _ := strings.Cut(opt, "=")
+		tag.options[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+
+	return tag
+}
+
+// DefaultFieldSeparator joins the titles of nested struct fields and the fields containing them in
+// column titles, e.g. "Address.City", unless set otherwise by WithFieldSeparator.
+const DefaultFieldSeparator = "."
+
+// WithFieldSeparator sets the separator joining the titles of nested struct fields and the fields containing
+// them in the column titles of tables created by WithStructData or BindStructData, e.g. " " for "Address City".
+// It must precede those options, and an empty separator selects DefaultFieldSeparator. Field names passed to
+// them are always joined by ".", e.g. "Address.City".
+func WithFieldSeparator(sep string) Option {
+	return func(m *Model) {
+		m.fieldSeparator = sep
+	}
+}
+
+// DefaultTimeLayout is the layout of time.Time fields without a "format" tag option, unless set otherwise
+// by WithTimeLayout.
+const DefaultTimeLayout = "2006-01-02 15:04:05"
+
+// WithTimeLayout sets the layout of time.Time and *time.Time fields without a "format" tag option in tables
+// created by WithStructData or BindStructData, e.g. time.Kitchen. It must precede those options, and an empty
+// layout selects DefaultTimeLayout. The columns of time fields are sorted chronologically in any layout.
+// Columns of KindAge are formatted in RFC 3339, which records the time zone for computing the time elapsed.
+func WithTimeLayout(layout string) Option {
+	return func(m *Model) {
+		m.timeLayout = layout
+	}
+}
+
+// structOptions are the options for creating a table from struct data.
+type structOptions struct {
+	// Separator of nested struct field titles
+	separator string
+
+	// Layout of time fields without a format
+	timeLayout string
+
+	// Finds the formatter for a field type
+	formatter func(reflect.Type) (formatter, bool)
+}
+
+// structOptions returns the options for creating a table from struct data.
+func (m Model) structOptions() structOptions {
+	opts := structOptions{
+		separator:  m.fieldSeparator,
+		timeLayout: m.timeLayout,
+		formatter:  m.formatterFor,
+	}
+
+	if opts.separator == "" {
+		opts.separator = DefaultFieldSeparator
+	}
+
+	if opts.timeLayout == "" {
+		opts.timeLayout = DefaultTimeLayout
+	}
+
+	return opts
+}
+
+// structSchema maps the fields of a struct type to table columns.
+type structSchema struct {
+	// The type of the slice elements, a struct or pointer to struct
+	elemType reflect.Type
+
+	// Columns, with widths set to fit the titles
+	columns []Column
+
+	// Index path to the struct field of each column
+	fieldIndices [][]int
+
+	// Format of each column set by the "format" tag option, or the layout of time fields, or empty for the default
+	formats []string
+
+	// Formatter of each column's field type, or nil if none is registered or a format is set
+	formatters []*fieldFormatter
+}
+
+// newStructSchema creates a schema for the given struct or pointer to struct type, which must implement Metadata.
+// If fields is empty, all exported fields are included. Nested struct fields are flattened, with titles joined
+// by the separator.
+func newStructSchema(elemType reflect.Type, fields []string, opts structOptions) (*structSchema, error) {
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+
+	if structType.Kind() != reflect.Struct {
+		return nil, errors.New("data slice must contain structs or pointers to structs")
+	}
+
+	// Check if the elements implement the Metadata interface
+	var metadataInterfaceType = reflect.TypeOf((*Metadata)(nil)).Elem()
+	if !elemType.Implements(metadataInterfaceType) {
+		return nil, errors.New("elements in the data slice must implement the Metadata interface")
+	}
+
+	all := structFields(structType, nil, nil, opts.separator, map[reflect.Type]bool{structType: true})
+
+	// Include all struct fields if fields are not provided, ordered by the "order" tag option
+	selected := all
+	if len(fields) == 0 {
+		var err error
+		if selected, err = orderFields(all); err != nil {
+			return nil, err
+		}
+	} else {
+		selected = make([]structField, len(fields))
+
+		for i, name := range fields {
+			found := false
+
+			for _, f := range all {
+				if f.name == name {
+					selected[i], found = f, true
+					break
+				}
+			}
+
+			if !found {
+				return nil, fmt.Errorf("field %s not found in struct", name)
+			}
+		}
+	}
+
+	schema := &structSchema{
+		elemType:     elemType,
+		columns:      make([]Column, len(selected)),
+		fieldIndices: make([][]int, len(selected)),
+		formats:      make([]string, len(selected)),
+		formatters:   make([]*fieldFormatter, len(selected)),
+	}
+
+	// Prepare columns
+	for i, f := range selected {
+		schema.fieldIndices[i] = f.indices
+		schema.formats[i] = f.tag.options["format"]
+
+		if schema.formats[i] == "" && opts.formatter != nil {
+			schema.formatters[i], _ = resolveFormatter(opts.formatter, f.field.Type)
+		}
+
+		isTime := isTimeType(f.field.Type)
+		switch {
+		case !isTime || schema.formats[i] != "" || schema.formatters[i] != nil:
+		case f.tag.options["kind"] == KindAge:
+			// Keeps the time zone, for computing the time elapsed
+			schema.formats[i] = time.RFC3339Nano
+		default:
+			schema.formats[i] = opts.timeLayout
+		}
+
+		_, editable := f.tag.options["editable"]
+		schema.columns[i] = Column{Title: f.title, Width: len(f.title), Kind: f.tag.options["kind"], Editable: editable}
+		schema.columns[i].Align, schema.columns[i].SortHint = fieldPresentation(f.field.Type, f.tag)
+
+		switch {
+		case schema.formatters[i] != nil:
+			schema.columns[i].Less = schema.formatters[i].less
+		case isTime && schema.columns[i].SortHint == SortTime && !isSortTimeLayout(schema.formats[i]):
+			schema.columns[i].SortHint = TimeLayout(schema.formats[i])
+		}
+	}
+
+	return schema, nil
+}
+
+// structField is a field of a struct, or of a struct nested within it, presented as a column.
+type structField struct {
+	// Titles of the field and the fields containing it, joined by "." to select the field,
+	// and by the field separator for the column title
+	name  string
+	title string
+
+	// Index path to the field
+	indices []int
+
+	field reflect.StructField
+	tag   fieldTag
+}
+
+// structFields returns the exported fields of a struct type not tagged `xtable:"-"`, including those of embedded structs, and of nested
+// structs flattened with their titles prefixed by that of the field containing them. parent and titles are the
+// index path and titles of the field containing the struct, and seen the struct types containing it, which are not
+// flattened again, so that recursive types terminate.
+func structFields(t reflect.Type, parent []int, titles []string, sep string, seen map[reflect.Type]bool) []structField {
+	var result []structField
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		// Skip unexported fields, and those excluded by the tag "-"
+		if !field.IsExported() || field.Tag.Get("xtable") == "-" {
+			continue
+		}
+
+		indices := append(append([]int(nil), parent...), i)
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if field.Anonymous && fieldType.Kind() == reflect.Struct {
+			result = append(result, structFields(fieldType, indices, titles, sep, seen)...)
+			continue
+		}
+
+		tag := parseTag(field)
+		title := field.Name
+		if tag.title != "" {
+			title = tag.title // Use the struct tag's value
+		}
+
+		path := append(append([]string(nil), titles...), title)
+
+		if isNestedStruct(field.Type) && !seen[fieldType] {
+			seen[fieldType] = true
+			result = append(result, structFields(fieldType, indices, path, sep, seen)...)
+			delete(seen, fieldType)
+
+			continue
+		}
+
+		result = append(result, structField{
+			name:    strings.Join(path, "."),
+			title:   strings.Join(path, sep),
+			indices: indices,
+			field:   field,
+			tag:     tag,
+		})
+	}
+
+	return result
+}
+
+// orderFields orders fields by the "order" tag option, e.g. `xtable:"Name,order=2"`. Fields with an order
+// precede those without, which remain in declaration order.
+func orderFields(fields []structField) ([]structField, error) {
+	orders := make([]int, len(fields))
+
+	for i, f := range fields {
+		value, ok := f.tag.options["order"]
+		if !ok {
+			orders[i] = math.MaxInt
+			continue
+		}
+
+		order, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid order %q for field %s", value, f.name)
+		}
+
+		orders[i] = order
+	}
+
+	index := make([]int, len(fields))
+	for i := range index {
+		index[i] = i
+	}
+
+	sort.SliceStable(index, func(a, b int) bool {
+		return orders[index[a]] < orders[index[b]]
+	})
+
+	ordered := make([]structField, len(fields))
+	for i, j := range index {
+		ordered[i] = fields[j]
+	}
+
+	return ordered, nil
+}
+
+// isNestedStruct returns true if a field of the given type is a struct, or pointer to struct, whose fields are
+// presented as columns in place of the field. Structs that format themselves, such as time.Time, are not.
+func isNestedStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+
+	for _, iface := range []reflect.Type{
+		reflect.TypeOf((*fmt.Stringer)(nil)).Elem(),
+		reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem(),
+		reflect.TypeOf((*error)(nil)).Elem(),
+	} {
+		if t.Implements(iface) || reflect.PtrTo(t).Implements(iface) {
+			return false
+		}
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isTimeType returns true for time.Time and *time.Time.
+func isTimeType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t == reflect.TypeOf(time.Time{})
+}
+
+// isSortTimeLayout returns true if the layout is recognised by SortTime.
+func isSortTimeLayout(layout string) bool {
+	for _, l := range sortTimeLayouts {
+		if l == layout {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fieldPresentation returns the alignment and sort hint for a column of the given field type.
+// Numeric fields are right aligned and sorted numerically, time fields sorted chronologically, and all
+// others left aligned and sorted as strings, unless overridden by the "align" (left, center or right)
+// and "sort" (string, numeric, time, natural, semver or ip) tag options, e.g.
+//
+//	`xtable:"Code,align=left,sort=string"`
+func fieldPresentation(t reflect.Type, tag fieldTag) (lipgloss.Position, interface{}) {
+	align, hint := lipgloss.Left, interface{}(SortString)
+
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if isNumericKind(t.Kind()) {
+		align, hint = lipgloss.Right, SortNumeric
+	}
+
+	if isTimeType(t) {
+		hint = SortTime
+	}
+
+	switch tag.options["align"] {
+	case "left":
+		align = lipgloss.Left
+	case "center":
+		align = lipgloss.Center
+	case "right":
+		align = lipgloss.Right
+	}
+
+	switch tag.options["sort"] {
+	case "string":
+		hint = SortString
+	case "numeric":
+		hint = SortNumeric
+	case "time":
+		hint = SortTime
+	case "natural":
+		hint = SortNatural
+	case "semver":
+		hint = SortSemver
+	case "ip":
+		hint = SortIP
+	}
+
+	return align, hint
+}
+
+// isNumericKind returns true for the integer and floating point kinds.
+func isNumericKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
+// row creates a table row from a struct value, or pointer to struct, of the schema's type.
+func (s *structSchema) row(elem reflect.Value) Row {
+	rdata := make([]string, len(s.fieldIndices))
+	for j, indices := range s.fieldIndices {
+		val := getNestedFieldValue(elem, indices)
+
+		valStr := ""
+		if f := s.formatters[j]; f != nil && val.IsValid() {
+			valStr = f.apply(val)
+		} else if str, ok := stringerValue(val); ok && s.formats[j] == "" {
+			valStr = str
+		} else {
+			if val.IsValid() && val.Kind() == reflect.Ptr {
+				val = val.Elem() // Show the value pointed to, or nothing if nil
+			}
+
+			if val.IsValid() {
+				valStr = formatField(val.Interface(), s.formats[j])
+			}
+		}
+		rdata[j] = valStr
+	}
+
+	x := elem.Interface().(Metadata) // Assert metadata interface
+	return Row{Data: rdata, Metadata: x}
+}
+
+// stringerValue returns the result of the String method of a field's value, if the value or a pointer to it
+// implements fmt.Stringer, so that custom types such as enumerations and IDs are shown in readable form
+// however the method is declared. Nil pointers have no string.
+func stringerValue(val reflect.Value) (string, bool) {
+	if !val.IsValid() || !val.CanInterface() || (val.Kind() == reflect.Ptr && val.IsNil()) {
+		return "", false
+	}
+
+	if s, ok := val.Interface().(fmt.Stringer); ok {
+		return s.String(), true
+	}
+
+	// The String method may have a pointer receiver
+	p := reflect.New(val.Type())
+	p.Elem().Set(val)
+
+	if s, ok := p.Interface().(fmt.Stringer); ok {
+		return s.String(), true
+	}
+
+	return "", false
+}
+
+// formatField converts the value of a struct field to a cell string. If format is set by the "format" tag option,
+// values with a Format method, such as time.Time, are formatted with it as the layout, and other values with
+// it as a fmt verb, e.g.
+//
+//	`xtable:"Price,format=%.2f"`
+//	`xtable:"Created,format=2006-01-02"`
+//
+// As options are separated by commas, the format cannot contain a comma.
+func formatField(v interface{}, format string) string {
+	if format == "" {
+		return fmt.Sprintf("%v", v)
+	}
+
+	if f, ok := v.(interface{ Format(string) string }); ok {
+		return f.Format(format)
+	}
+
+	return fmt.Sprintf(format, v)
+}
+
+// rowFromValue creates a table row from metadata of the schema's type, or if the schema's type is a pointer
+// to struct, the struct value as submitted by a row form.
+func (s *structSchema) rowFromValue(value interface{}) (Row, error) {
+	v := reflect.ValueOf(value)
+	if s.elemType.Kind() == reflect.Ptr && v.Type() == s.elemType.Elem() {
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		v = p
+	}
+
+	if v.Type() != s.elemType {
+		return Row{}, fmt.Errorf("metadata type %s does not match table data type %s", v.Type(), s.elemType)
+	}
+
+	return s.row(v), nil
+}
+
+// zero returns the zero value of the schema's struct type, as a pointer if the schema's type is a pointer,
+// for entering a new row in a row form.
+func (s *structSchema) zero() interface{} {
+	if s.elemType.Kind() == reflect.Ptr {
+		return reflect.New(s.elemType.Elem()).Interface()
+	}
+
+	return reflect.New(s.elemType).Elem().Interface()
+}
+
+// renderTable builds a table from a slice of structs or pointers to structs.
+// The slice elements must be all the same type. Nil pointers are skipped.
+func renderTable(data interface{}, fields []string, opts structOptions) (*structSchema, []Row, error) {
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice || v.Len() == 0 {
+		return nil, nil, errors.New("invalid or empty data slice")
+	}
+
+	schema, err := newStructSchema(v.Type().Elem(), fields, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Prepare rows and determine max width for each column
+	rows := schema.rows(v)
+	for i := range rows {
+		for j, valStr := range rows[i].Data {
+			if len(valStr) > schema.columns[j].Width {
+				schema.columns[j].Width = len(valStr)
+			}
+		}
+	}
+
+	return schema, rows, nil
+}
+
+// getNestedFieldValue safely gets the value of a nested field
+func getNestedFieldValue(v reflect.Value, indices []int) (result reflect.Value) {
+	defer func() {
+		if r := recover(); r != nil {
+			// Ignore unexported or inaccessible fields
+			result = reflect.Value{}
+		}
+	}()
+
+	for _, index := range indices {
+		if v.Kind() == reflect.Ptr && !v.IsNil() {
+			v = v.Elem() // Dereference pointer
+		}
+		v = v.Field(index)
+	}
+	return v
+}
@@ -0,0 +1,123 @@
+package xtable
+
+import (
+	"reflect"
+	"sort"
+)
+
+// rowIdentity identifies a row in the order saved before sorting: by metadata hash,
+// or for rows without metadata by their data.
+type rowIdentity struct {
+	hash uint64
+	data uintptr
+}
+
+// identify returns the identity of a row.
+func identify(r Row) rowIdentity {
+	if r.Metadata != nil {
+		return rowIdentity{hash: r.Metadata.GetHashCode()}
+	}
+
+	return rowIdentity{data: reflect.ValueOf(r.Data).Pointer()}
+}
+
+// locateRow returns the index of the visible row with the given identity, looking first at the index it was at
+// when identified, or -1 if the row is no longer shown.
+func (m Model) locateRow(id rowIdentity, index int) int {
+	if index >= 0 && index < len(m.rows) && identify(m.rows[index]) == id {
+		return index
+	}
+
+	for i, r := range m.rows {
+		if identify(r) == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// ToggleSort cycles the sort of a column on repeated calls: ascending, then descending, then unsorted,
+// restoring the order of the rows before they were first sorted. The column is sorted according to
+// its SortHint, as for the quick sort keys. If the table is sorted by other columns, the column
+// is sorted in ascending order.
+func (m *Model) ToggleSort(index int) {
+	if !m.IsSortable(index) {
+		return
+	}
+
+	current := m.sorted.columns
+
+	switch {
+	case len(current) != 1 || current[0].Index != index:
+		m.SortBy(index, SortAscending, m.columnSortHint(index))
+	case current[0].Order == SortAscending:
+		m.SortBy(index, SortDescending, m.columnSortHint(index))
+	default:
+		m.ClearSort()
+	}
+}
+
+// ClearSort restores the order of the rows before they were first sorted, and removes the sort indicators.
+// Rows added since are placed after the others, in their current order. The selected row remains selected.
+func (m *Model) ClearSort() {
+	if len(m.sorted.columns) == 0 {
+		return
+	}
+
+	m.sorted = sortState{}
+	m.emitSortChanged()
+
+	if s, ok := m.source.(SortableRowSource); ok {
+		s.Sort(nil)
+	}
+
+	if m.unsorted == nil {
+		m.UpdateViewport()
+		return
+	}
+
+	hash, hasHash := m.selectedHash()
+	cursor, selected := m.cursor, m.allIndex(m.cursor)
+
+	origin := make(map[rowIdentity]int, len(m.unsorted))
+	for i := len(m.unsorted) - 1; i >= 0; i-- {
+		origin[identify(m.unsorted[i])] = i
+	}
+
+	// Sort on the original position, with new rows after all others
+	rows := m.AllRows()
+	keys := make([]sortKey, len(rows))
+	values := make([]sortValue, len(rows))
+
+	for i, r := range rows {
+		pos, ok := origin[identify(r)]
+		if !ok {
+			pos = len(m.unsorted) + i
+		}
+
+		values[i] = sortValue{num: float64(pos), numeric: true}
+		keys[i] = sortKey{row: r, pos: i, values: values[i : i+1]}
+	}
+
+	sort.Sort(sortKeys{keys: keys, orders: []SortOrder{SortAscending}, cmp: make([]func(a, b *sortValue) int, 1)})
+
+	for i := range keys {
+		rows[i] = keys[i].row
+
+		if keys[i].pos == selected {
+			selected = i
+		}
+	}
+
+	m.unsorted = nil
+	m.refilter()
+	m.selectAfterSort(cursor, selected, hash, hasHash)
+}
+
+// saveUnsorted saves the order of the rows before they are first sorted, for ClearSort.
+func (m *Model) saveUnsorted(rows []Row) {
+	if m.unsorted == nil {
+		m.unsorted = append([]Row{}, rows...)
+	}
+}
@@ -0,0 +1,56 @@
+package xtable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToggleSort(t *testing.T) {
+	table := New(
+		WithStructData([]rowData{
+			newRowData("Tim Tams", 8),
+			newRowData("Hobnobs", 10),
+			newRowData("Penguins", 9),
+		}),
+	)
+	table.SetCursor(1)
+
+	table.ToggleSort(1)
+	require.Equal(t, []string{"Tim Tams", "Penguins", "Hobnobs"}, columnValues(table.Rows(), 0))
+
+	table.ToggleSort(1)
+	require.Equal(t, []string{"Hobnobs", "Penguins", "Tim Tams"}, columnValues(table.Rows(), 0))
+
+	_, order, ok := table.SortedBy()
+	require.True(t, ok)
+	require.Equal(t, SortDescending, order)
+
+	// Rows added while sorted follow the original rows
+	table.LoadRows([]Row{{Data: []string{"Arnott's", "7"}, Metadata: newRowData("Arnott's", 7)}}, ImportAppend)
+
+	table.ToggleSort(1)
+	require.Equal(t, []string{"Tim Tams", "Hobnobs", "Penguins", "Arnott's"}, columnValues(table.Rows(), 0))
+	require.Equal(t, "Hobnobs", table.SelectedRow().Data[0])
+
+	_, _, ok = table.SortedBy()
+	require.False(t, ok)
+}
+
+func TestClearSortWithoutMetadata(t *testing.T) {
+	table := New(
+		WithColumns([]Column{{Title: "Name", Width: 10}}),
+		WithRows([]Row{{Data: []string{"b"}}, {Data: []string{"c"}}, {Data: []string{"a"}}}),
+	)
+
+	// A descending sort by SortBy is cleared by the next toggle
+	table.SortBy(0, SortDescending, SortString)
+	table.ToggleSort(0)
+	require.Equal(t, []string{"b", "c", "a"}, columnValues(table.Rows(), 0))
+	require.Empty(t, table.SortState())
+
+	table.SortBy(0, SortAscending, SortString)
+	table.SortBy(0, SortDescending, SortString)
+	table.ClearSort()
+	require.Equal(t, []string{"b", "c", "a"}, columnValues(table.Rows(), 0))
+}
@@ -0,0 +1,82 @@
+package xtable
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TypedModel is a table whose rows carry metadata of a single concrete type T.
+// Metadata is returned as T, removing the need for type assertions
+// (and the risk of a runtime panic) when acting on rows.
+//
+// All methods of Model are available. Those below operate on T in place of Metadata.
+type TypedModel[T Metadata] struct {
+	Model
+}
+
+// TypedRow is a Row whose metadata is of type T.
+type TypedRow[T Metadata] struct {
+	Data     []string
+	Metadata T
+}
+
+// NewTyped creates a new table whose row metadata is of type T. For example
+//
+//	table := NewTyped[User](WithStructData(users))
+func NewTyped[T Metadata](opts ...Option) TypedModel[T] {
+	return TypedModel[T]{
+		Model: New(opts...),
+	}
+}
+
+// Update is the Bubble Tea update loop.
+func (m TypedModel[T]) Update(msg tea.Msg) (TypedModel[T], tea.Cmd) {
+	var cmd tea.Cmd
+
+	m.Model, cmd = m.Model.Update(msg)
+	return m, cmd
+}
+
+// SelectedRow returns the selected row. If there is no selected row, or its metadata is
+// not of type T, then the row's metadata is the zero value of T.
+func (m TypedModel[T]) SelectedRow() TypedRow[T] {
+	return toTypedRow[T](m.Model.SelectedRow())
+}
+
+// SelectedMetadata returns the metadata of the selected row.
+// The boolean result is false if there is no selected row or its metadata is not of type T.
+func (m TypedModel[T]) SelectedMetadata() (T, bool) {
+	meta, ok := m.Model.SelectedRow().Metadata.(T)
+	return meta, ok
+}
+
+// RowByHash returns the row identified by the metadata hash value.
+// The boolean result is false if the row is not found.
+func (m TypedModel[T]) RowByHash(hashCode uint64) (TypedRow[T], bool) {
+	ind := m.GetRowByHash(hashCode)
+	if ind == -1 {
+		return TypedRow[T]{}, false
+	}
+
+	return toTypedRow[T](m.rows[ind]), true
+}
+
+// GetRow returns the index of the row containing the given object as metadata.
+// If the row is not found, -1 is returned.
+func (m TypedModel[T]) GetRow(obj T) int {
+	return m.Model.GetRow(obj)
+}
+
+// RemoveRow removes the row containing the given object as metadata. If no rows remain, this returns false.
+func (m *TypedModel[T]) RemoveRow(obj T) bool {
+	return m.Model.RemoveRow(obj)
+}
+
+// toTypedRow converts a Row to a TypedRow.
+func toTypedRow[T Metadata](r Row) TypedRow[T] {
+	meta, _ := r.Metadata.(T)
+
+	return TypedRow[T]{
+		Data:     r.Data,
+		Metadata: meta,
+	}
+}
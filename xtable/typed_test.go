@@ -0,0 +1,47 @@
+package xtable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypedModel(t *testing.T) {
+	hobnobs := newRowData("Hobnobs", 10)
+	data := []rowData{
+		newRowData("Chocolate Digestives", 12),
+		newRowData("Tim Tams", 8),
+		hobnobs,
+	}
+
+	table := NewTyped[rowData](WithStructData(data))
+	table.SetCursor(1)
+
+	row := table.SelectedRow()
+	require.Equal(t, "Tim Tams", row.Metadata.Name)
+	require.Equal(t, 8, row.Metadata.PacketSize)
+
+	meta, ok := table.SelectedMetadata()
+	require.True(t, ok)
+	require.Equal(t, "Tim Tams", meta.Name)
+
+	found, ok := table.RowByHash(hobnobs.GetHashCode())
+	require.True(t, ok)
+	require.Equal(t, hobnobs, found.Metadata)
+
+	_, ok = table.RowByHash(0)
+	require.False(t, ok)
+
+	require.True(t, table.RemoveRow(hobnobs))
+	require.Equal(t, -1, table.GetRow(hobnobs))
+}
+
+func TestTypedModelWrongMetadataType(t *testing.T) {
+	table := NewTyped[taggedRowData](WithStructData([]rowData{
+		newRowData("Chocolate Digestives", 12),
+	}))
+
+	_, ok := table.SelectedMetadata()
+	require.False(t, ok)
+	require.Equal(t, taggedRowData{}, table.SelectedRow().Metadata)
+}
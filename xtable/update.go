@@ -0,0 +1,63 @@
+package xtable
+
+// UpdateRowByHash replaces the data and metadata of the row identified by the metadata hash, e.g. to refresh
+// a single row of a live dashboard, keeping its position in the table, the selected row and the scroll position.
+// If newMeta is nil, the row keeps its existing metadata. The row number cell should not be included in newData,
+// as the row keeps its number if row numbers are enabled. Rows hidden by a filter are also updated, and the filter
+// is re-applied.
+// If no row has the hash, false is returned.
+func (m *Model) UpdateRowByHash(hash uint64, newData []string, newMeta Metadata) bool {
+	i := m.allIndexByHash(hash)
+	if i == -1 {
+		return false
+	}
+
+	all := m.AllRows()
+
+	if newMeta == nil {
+		newMeta = all[i].Metadata
+	}
+
+	selected, hasSelected := m.selectedHash()
+	if hasSelected && selected == hash {
+		// The selected row remains selected, even if its hash changes
+		selected = newMeta.GetHashCode()
+	}
+
+	if m.rowNumbers {
+		newData = append([]string{all[i].Data[0]}, newData...)
+	}
+
+	all[i] = Row{Data: newData, Metadata: newMeta}
+
+	m.refilter()
+	m.restoreCursor(selected, hasSelected)
+
+	return true
+}
+
+// UpsertRow replaces the row with the same metadata hash as the given row, keeping its position in the table,
+// or if there is none, appends it, e.g. for tables fed by event streams where entities come and go.
+// A row without metadata is always appended. The selected row remains selected. As with AppendRows, the row
+// number cell should not be included in the row's data. It returns true if an existing row was replaced.
+func (m *Model) UpsertRow(r Row) bool {
+	if r.Metadata != nil && m.UpdateRowByHash(r.Metadata.GetHashCode(), r.Data, r.Metadata) {
+		return true
+	}
+
+	m.appendRows([]Row{r})
+
+	return false
+}
+
+// allIndexByHash returns the index in the complete set of rows of the row identified by the metadata hash,
+// or -1 if there is no such row.
+func (m Model) allIndexByHash(hash uint64) int {
+	for i, r := range m.AllRows() {
+		if r.Metadata != nil && r.Metadata.GetHashCode() == hash {
+			return i
+		}
+	}
+
+	return -1
+}
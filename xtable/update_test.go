@@ -0,0 +1,70 @@
+package xtable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateRowByHash(t *testing.T) {
+	table := importTable()
+	table.AppendRows(importRow("Penguins", "6", 3))
+	table.SetCursor(1)
+
+	require.True(t, table.UpdateRowByHash(3, []string{"Penguins", "7"}, nil))
+	require.Equal(t, []string{"Tim Tams", "Hobnobs", "Penguins"}, columnValues(table.Rows(), 0))
+	require.Equal(t, "7", table.Rows()[2].Data[1])
+	require.Equal(t, uint64(3), table.Rows()[2].Metadata.GetHashCode(), "metadata is kept")
+	require.Equal(t, 1, table.Cursor())
+
+	require.True(t, table.UpdateRowByHash(2, []string{"Hobnobs Dark", "10"}, rowData{Name: "Hobnobs Dark", hash: 4}))
+	require.Equal(t, "Hobnobs Dark", table.SelectedRow().Data[0], "selected row remains selected with a new hash")
+	require.Equal(t, uint64(4), table.SelectedRow().Metadata.GetHashCode())
+
+	require.False(t, table.UpdateRowByHash(2, []string{"Hobnobs", "10"}, nil))
+}
+
+func TestUpdateRowByHashRowNumbers(t *testing.T) {
+	table := New(
+		WithStructData([]rowData{{Name: "Tim Tams", PacketSize: 8, hash: 1}, {Name: "Hobnobs", PacketSize: 10, hash: 2}}),
+		WithRowNumbers(),
+	)
+
+	require.True(t, table.UpdateRowByHash(2, []string{"Hobnobs", "12"}, nil))
+	require.Equal(t, []string{"2", "Hobnobs", "12"}, table.Rows()[1].Data)
+}
+
+func TestUpdateRowByHashFiltered(t *testing.T) {
+	table := importTable()
+	table.filter.match = table.textMatcher("Tim")
+	table.filter.all = table.rows
+	table.refilter()
+
+	require.True(t, table.UpdateRowByHash(2, []string{"Tim Tams Dark", "10"}, nil))
+	require.Equal(t, []string{"Tim Tams", "Tim Tams Dark"}, columnValues(table.Rows(), 0), "filter is re-applied")
+}
+
+func TestUpsertRow(t *testing.T) {
+	table := importTable()
+	table.SetCursor(1)
+
+	require.True(t, table.UpsertRow(importRow("Tim Tams", "9", 1)))
+	require.False(t, table.UpsertRow(importRow("Penguins", "6", 3)))
+	require.False(t, table.UpsertRow(Row{Data: []string{"Bourbons", "16"}}))
+
+	require.Equal(t, []string{"Tim Tams", "Hobnobs", "Penguins", "Bourbons"}, columnValues(table.Rows(), 0))
+	require.Equal(t, "9", table.Rows()[0].Data[1])
+	require.Equal(t, "Hobnobs", table.SelectedRow().Data[0])
+}
+
+func TestUpsertRowRowNumbers(t *testing.T) {
+	table := New(
+		WithStructData([]rowData{{Name: "Tim Tams", PacketSize: 8, hash: 1}, {Name: "Hobnobs", PacketSize: 10, hash: 2}}),
+		WithRowNumbers(),
+	)
+
+	require.True(t, table.UpsertRow(importRow("Tim Tams", "9", 1)))
+	require.False(t, table.UpsertRow(importRow("Penguins", "6", 3)))
+	require.Equal(t, []string{"1", "Tim Tams", "9"}, table.Rows()[0].Data)
+	require.Equal(t, []string{"3", "Penguins", "6"}, table.Rows()[2].Data)
+}
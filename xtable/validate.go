@@ -0,0 +1,88 @@
+package xtable
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldError describes why the value of a single cell is invalid.
+type FieldError struct {
+	// Index of the column containing the invalid value
+	Column int
+
+	// Reason the value is invalid
+	Err error
+}
+
+// Error implements the error interface.
+func (e FieldError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Column, e.Err.Error())
+}
+
+// Unwrap returns the underlying error.
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors is returned by a Validator when one or more cells of a row are invalid.
+type ValidationErrors []FieldError
+
+// Error implements the error interface.
+func (v ValidationErrors) Error() string {
+	msgs := make([]string, len(v))
+	for i, e := range v {
+		msgs[i] = e.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// ForColumn returns the error for the given column, or nil if the column is valid.
+func (v ValidationErrors) ForColumn(col int) error {
+	for _, e := range v {
+		if e.Column == col {
+			return e.Err
+		}
+	}
+
+	return nil
+}
+
+// Validator validates a row before an edit to it, or its insertion, is committed to the table.
+//
+// Validate should return nil if the row is valid. To report problems with individual
+// cells, return ValidationErrors; any other error applies to the row as a whole.
+type Validator interface {
+	Validate(row Row) error
+}
+
+// ValidatorFunc adapts a function to the Validator interface.
+type ValidatorFunc func(row Row) error
+
+// Validate implements the Validator interface.
+func (f ValidatorFunc) Validate(row Row) error {
+	return f(row)
+}
+
+// WithValidator sets the validator for rows which are edited or inserted.
+func WithValidator(v Validator) Option {
+	return func(m *Model) {
+		m.validator = v
+	}
+}
+
+// SetValidator sets the validator for rows which are edited or inserted.
+func (m *Model) SetValidator(v Validator) {
+	m.validator = v
+}
+
+// ValidateRow checks a row with the table's validator, returning nil if the row is valid
+// or no validator is set. Field-level problems can be retrieved from the returned error
+// with errors.As and a ValidationErrors target.
+func (m Model) ValidateRow(row Row) error {
+	if m.validator == nil {
+		return nil
+	}
+
+	return m.validator.Validate(row)
+}
@@ -0,0 +1,50 @@
+package xtable
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRow(t *testing.T) {
+	errNotNumber := errors.New("must be a number")
+
+	table := New(
+		WithValidator(ValidatorFunc(func(r Row) error {
+			var errs ValidationErrors
+
+			if r.Data[0] == "" {
+				errs = append(errs, FieldError{Column: 0, Err: errors.New("required")})
+			}
+
+			if _, err := strconv.Atoi(r.Data[1]); err != nil {
+				errs = append(errs, FieldError{Column: 1, Err: errNotNumber})
+			}
+
+			if len(errs) > 0 {
+				return errs
+			}
+
+			return nil
+		})),
+	)
+
+	require.NoError(t, table.ValidateRow(Row{Data: []string{"Hobnobs", "10"}}))
+
+	err := table.ValidateRow(Row{Data: []string{"", "ten"}})
+	require.Error(t, err)
+	require.ErrorIs(t, err.(ValidationErrors)[1], errNotNumber)
+
+	var fe ValidationErrors
+	require.True(t, errors.As(err, &fe))
+	require.Equal(t, 2, len(fe))
+	require.EqualError(t, fe.ForColumn(0), "required")
+	require.Nil(t, fe.ForColumn(2))
+	require.Equal(t, "column 0: required; column 1: must be a number", err.Error())
+}
+
+func TestValidateRowNoValidator(t *testing.T) {
+	require.NoError(t, New().ValidateRow(Row{}))
+}
@@ -0,0 +1,233 @@
+package xtable
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+type valuesOptions struct {
+	quotes     bool
+	escape     rune
+	trim       bool
+	skipEmpty  bool
+	maxColumns int
+	mode       ImportMode
+}
+
+// ValuesOption is used to set options in FromValuesWithOptions.
+type ValuesOption func(*valuesOptions)
+
+// WithQuotedFields permits fields to be enclosed in double quotes, in which case they may contain
+// the separator and line breaks. A double quote within a quoted field is written as two double quotes.
+func WithQuotedFields() ValuesOption {
+	return func(o *valuesOptions) {
+		o.quotes = true
+	}
+}
+
+// WithEscapeChar sets a character that causes the character following it, such as the separator,
+// a double quote or a line break, to be taken literally. For example '\\'.
+func WithEscapeChar(r rune) ValuesOption {
+	return func(o *valuesOptions) {
+		o.escape = r
+	}
+}
+
+// WithTrimSpace removes leading and trailing white space from fields. White space within quotes is kept.
+func WithTrimSpace() ValuesOption {
+	return func(o *valuesOptions) {
+		o.trim = true
+	}
+}
+
+// WithSkipEmptyLines ignores empty lines, or lines containing only white space when WithTrimSpace is also set.
+func WithSkipEmptyLines() ValuesOption {
+	return func(o *valuesOptions) {
+		o.skipEmpty = true
+	}
+}
+
+// WithMaxColumns causes FromValuesWithOptions to fail if any line has more than n fields.
+// By default, this is the number of columns of the table, excluding any row number column. Fields beyond the last column are not displayed.
+// Pass 0 for no limit.
+func WithMaxColumns(n int) ValuesOption {
+	return func(o *valuesOptions) {
+		o.maxColumns = n
+	}
+}
+
+// WithValuesImportMode sets how the parsed rows are combined with the existing rows of the table.
+// The default is ImportReplace. See LoadRows.
+func WithValuesImportMode(mode ImportMode) ValuesOption {
+	return func(o *valuesOptions) {
+		o.mode = mode
+	}
+}
+
+// FromValuesWithOptions creates the table rows from delimited text, like FromValues, with options for
+// handling real-world data: quoted fields, escaped separators, white space trimming and empty lines.
+// If the text cannot be parsed, an error is returned and the rows of the table are unchanged.
+func (m *Model) FromValuesWithOptions(value, separator string, opts ...ValuesOption) error {
+	o := &valuesOptions{
+		maxColumns: len(m.dataColumns()),
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if separator == "" {
+		return errors.New("separator must not be empty")
+	}
+
+	rows, err := parseValues(value, separator, o)
+	if err != nil {
+		return err
+	}
+
+	m.LoadRows(rows, o.mode)
+
+	return nil
+}
+
+// valuesParser accumulates the fields and rows of delimited text.
+type valuesParser struct {
+	opts   *valuesOptions
+	rows   []Row
+	fields []string
+	field  strings.Builder
+	line   int
+
+	// Whether the current field was quoted, and the length of its content when the quotes closed
+	quoted   bool
+	closedAt int
+}
+
+// parseValues splits delimited text into rows.
+func parseValues(value, separator string, opts *valuesOptions) ([]Row, error) {
+	p := &valuesParser{opts: opts, line: 1}
+	inQuotes := false
+	startLine := 0
+
+	for i := 0; i < len(value); {
+		r, size := utf8.DecodeRuneInString(value[i:])
+
+		switch {
+		case opts.escape != 0 && r == opts.escape && i+size < len(value):
+			next, nextSize := utf8.DecodeRuneInString(value[i+size:])
+			p.field.WriteRune(next)
+			i += size + nextSize
+
+			if next == '\n' {
+				p.line++
+			}
+
+			continue
+
+		case inQuotes:
+			if r == '"' {
+				if strings.HasPrefix(value[i+1:], `"`) {
+					p.field.WriteByte('"')
+					i += 2
+
+					continue
+				}
+
+				inQuotes = false
+				p.closedAt = p.field.Len()
+			} else {
+				p.field.WriteRune(r)
+
+				if r == '\n' {
+					p.line++
+				}
+			}
+
+		case opts.quotes && r == '"' && !p.quoted && p.leadingSpaceOnly():
+			inQuotes = true
+			p.quoted = true
+			startLine = p.line
+			p.field.Reset()
+
+		case strings.HasPrefix(value[i:], separator):
+			p.endField()
+			i += len(separator)
+
+			continue
+
+		case r == '\n':
+			if err := p.endLine(); err != nil {
+				return nil, err
+			}
+
+		case r == '\r' && strings.HasPrefix(value[i+1:], "\n"):
+			// Line ending is CRLF
+
+		default:
+			p.field.WriteRune(r)
+		}
+
+		i += size
+	}
+
+	if inQuotes {
+		return nil, fmt.Errorf("line %d: unterminated quoted field", startLine)
+	}
+
+	if err := p.endLine(); err != nil {
+		return nil, err
+	}
+
+	return p.rows, nil
+}
+
+// leadingSpaceOnly returns true if a quote at this point opens a quoted field.
+func (p *valuesParser) leadingSpaceOnly() bool {
+	if p.opts.trim {
+		return strings.TrimSpace(p.field.String()) == ""
+	}
+
+	return p.field.Len() == 0
+}
+
+// endField completes the current field.
+func (p *valuesParser) endField() {
+	s := p.field.String()
+
+	switch {
+	case p.quoted && p.opts.trim:
+		s = s[:p.closedAt] + strings.TrimSpace(s[p.closedAt:])
+	case p.opts.trim:
+		s = strings.TrimSpace(s)
+	}
+
+	p.fields = append(p.fields, s)
+	p.field.Reset()
+	p.quoted = false
+	p.closedAt = 0
+}
+
+// endLine completes the current row.
+func (p *valuesParser) endLine() error {
+	empty := len(p.fields) == 0 && !p.quoted
+	p.endField()
+
+	defer func() {
+		p.fields = nil
+		p.line++
+	}()
+
+	if empty && p.opts.skipEmpty && p.fields[0] == "" {
+		return nil
+	}
+
+	if p.opts.maxColumns > 0 && len(p.fields) > p.opts.maxColumns {
+		return fmt.Errorf("line %d: %d fields exceeds the maximum of %d", p.line, len(p.fields), p.opts.maxColumns)
+	}
+
+	p.rows = append(p.rows, Row{Data: p.fields})
+
+	return nil
+}
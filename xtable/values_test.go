@@ -0,0 +1,127 @@
+package xtable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func valuesTable() Model {
+	return New(WithColumns([]Column{{Title: "Foo", Width: 10}, {Title: "Bar", Width: 10}}))
+}
+
+func TestFromValuesWithOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		separator string
+		opts      []ValuesOption
+		expect    [][]string
+	}{
+		{
+			name:      "plain",
+			input:     "foo1,bar1\nfoo2,bar2",
+			separator: ",",
+			expect:    [][]string{{"foo1", "bar1"}, {"foo2", "bar2"}},
+		},
+		{
+			name:      "quoted fields",
+			input:     "\"foo,bar,baz\"\t\"bar \"\"2\"\"\"\n\"multi\nline\"\tx",
+			separator: "\t",
+			opts:      []ValuesOption{WithQuotedFields()},
+			expect:    [][]string{{"foo,bar,baz", `bar "2"`}, {"multi\nline", "x"}},
+		},
+		{
+			name:      "quotes are literal without option",
+			input:     `"a",b`,
+			separator: ",",
+			expect:    [][]string{{`"a"`, "b"}},
+		},
+		{
+			name:      "escaped separator",
+			input:     `foo\,bar,baz\\`,
+			separator: ",",
+			opts:      []ValuesOption{WithEscapeChar('\\')},
+			expect:    [][]string{{"foo,bar", `baz\`}},
+		},
+		{
+			name:      "trimming",
+			input:     "  foo ,  \" bar \"  \r\n",
+			separator: ",",
+			opts:      []ValuesOption{WithQuotedFields(), WithTrimSpace(), WithSkipEmptyLines()},
+			expect:    [][]string{{"foo", " bar "}},
+		},
+		{
+			name:      "skip empty lines",
+			input:     "\nfoo,bar\n\n\nbaz,qux\n",
+			separator: ",",
+			opts:      []ValuesOption{WithSkipEmptyLines()},
+			expect:    [][]string{{"foo", "bar"}, {"baz", "qux"}},
+		},
+		{
+			name:      "multi character separator",
+			input:     "foo::bar:baz",
+			separator: "::",
+			expect:    [][]string{{"foo", "bar:baz"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := valuesTable()
+			require.NoError(t, table.FromValuesWithOptions(tt.input, tt.separator, tt.opts...))
+
+			rows := table.Rows()
+			require.Len(t, rows, len(tt.expect))
+
+			for i, r := range rows {
+				require.Equal(t, tt.expect[i], r.Data)
+			}
+		})
+	}
+}
+
+func TestFromValuesWithOptionsErrors(t *testing.T) {
+	table := valuesTable()
+	table.SetRows([]Row{{Data: []string{"keep", "me"}}})
+
+	err := table.FromValuesWithOptions("a,b\nc,d,e", ",")
+	require.EqualError(t, err, "line 2: 3 fields exceeds the maximum of 2")
+
+	err = table.FromValuesWithOptions("a,b\n\"c,d", ",", WithQuotedFields())
+	require.EqualError(t, err, "line 2: unterminated quoted field")
+
+	require.Error(t, table.FromValuesWithOptions("a,b", ""))
+	require.Equal(t, []string{"keep", "me"}, table.Rows()[0].Data)
+
+	require.NoError(t, table.FromValuesWithOptions("a,b,c", ",", WithMaxColumns(3)))
+}
+
+func TestFromValuesQuotedFields(t *testing.T) {
+	table := valuesTable()
+	table.FromValues("\"foo, bar\",\"say \"\"hi\"\"\"\nbaz,qux", ",")
+	require.Equal(t, []string{"foo, bar", `say "hi"`}, table.rows[0].Data)
+	require.Equal(t, []string{"baz", "qux"}, table.rows[1].Data)
+
+	table.FromValues("\"foo,bar", ",")
+	require.Equal(t, []string{`"foo`, "bar"}, table.rows[0].Data, "unterminated quotes are literal")
+
+	table.FromValues("a\"b,\"c\"", ",")
+	require.Equal(t, []string{`a"b`, "c"}, table.rows[0].Data, "quotes within a field are literal")
+}
+
+func TestFromValuesUnterminatedQuotes(t *testing.T) {
+	input := "\"foo, bar\",baz\nqux,\"quux"
+
+	table := valuesTable()
+	table.FromValues(input, ",")
+	require.Equal(t, []Row{
+		{Data: []string{`"foo`, ` bar"`, "baz"}},
+		{Data: []string{"qux", `"quux`}},
+	}, table.rows, "all quotes are literal if any quoted field is unterminated")
+
+	require.Error(t, table.FromValuesWithOptions(input, ",", WithQuotedFields()), "reported with WithQuotedFields")
+
+	require.NoError(t, table.FromValuesWithOptions("\"foo\",bar", ",", WithMaxColumns(0)))
+	require.Equal(t, []string{`"foo"`, "bar"}, table.rows[0].Data, "quotes are kept without WithQuotedFields")
+}
@@ -0,0 +1,95 @@
+package xtable
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// RowEventKind is the kind of change to a row described by a RowEvent.
+type RowEventKind int
+
+const (
+	// RowEventAdd adds the event's row, or replaces the row with the same metadata hash if already present,
+	// as UpsertRow.
+	RowEventAdd RowEventKind = iota
+
+	// RowEventUpdate replaces the row identified by the event's hash with the event's row, keeping its position,
+	// as UpdateRowByHash. If there is no such row, the event's row is added.
+	RowEventUpdate
+
+	// RowEventRemove removes the row identified by the event's hash, as RemoveRowsByHashes.
+	RowEventRemove
+)
+
+// RowEvent describes a change to a row of a table, e.g. from a log tail or a watch of Kubernetes resources,
+// sent on a channel passed to WatchRowEvents.
+type RowEvent struct {
+	// Kind of change
+	Kind RowEventKind
+
+	// Metadata hash of the row to update or remove. If zero, the hash of Row.Metadata is used.
+	Hash uint64
+
+	// The row to add, or the new content of the row to update. Not used when removing a row.
+	// As with AppendRows, the row number cell should not be included in the row's data.
+	Row Row
+}
+
+// rowEventsMsg carries row events read from a channel back to the table.
+type rowEventsMsg struct {
+	id     int
+	ch     <-chan RowEvent
+	events []RowEvent
+	closed bool
+}
+
+// WatchRowEvents returns a command that applies row events from a channel to the table as they arrive,
+// so that streaming data can drive the table without glue code in the application. Events are applied in
+// batches, in the order sent, and the selected row remains selected while it is present. When the channel
+// is closed, ChannelClosedMsg is sent.
+//
+// The table's Update must receive all messages for the events to be applied.
+func (m Model) WatchRowEvents(ch <-chan RowEvent) tea.Cmd {
+	id := m.id
+
+	return func() tea.Msg {
+		events, closed := readBatch(ch)
+
+		return rowEventsMsg{id: id, ch: ch, events: events, closed: closed}
+	}
+}
+
+// applyRowEvents applies row events read from a channel, and continues reading unless the channel is closed.
+func (m *Model) applyRowEvents(msg rowEventsMsg) tea.Cmd {
+	for _, e := range msg.events {
+		m.applyRowEvent(e)
+	}
+
+	if msg.closed {
+		id := m.id
+
+		return func() tea.Msg {
+			return ChannelClosedMsg{TableID: id}
+		}
+	}
+
+	return m.WatchRowEvents(msg.ch)
+}
+
+// applyRowEvent applies a single row event.
+func (m *Model) applyRowEvent(e RowEvent) {
+	hash := e.Hash
+	if hash == 0 && e.Row.Metadata != nil {
+		hash = e.Row.Metadata.GetHashCode()
+	}
+
+	switch e.Kind {
+	case RowEventAdd:
+		m.UpsertRow(e.Row)
+	case RowEventUpdate:
+		if !m.UpdateRowByHash(hash, e.Row.Data, e.Row.Metadata) {
+			m.appendRows([]Row{e.Row})
+		}
+	case RowEventRemove:
+		m.RemoveRowsByHashes([]uint64{hash})
+	}
+}
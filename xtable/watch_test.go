@@ -0,0 +1,67 @@
+package xtable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchRowEvents(t *testing.T) {
+	table := New(WithStructData([]rowData{
+		{Name: "Tim Tams", PacketSize: 8, hash: 1},
+		{Name: "Hobnobs", PacketSize: 10, hash: 2},
+	}))
+	table.SetCursor(1)
+
+	ch := make(chan RowEvent, 10)
+	ch <- RowEvent{Kind: RowEventAdd, Row: importRow("Penguins", "6", 3)}
+	ch <- RowEvent{Kind: RowEventAdd, Row: importRow("Tim Tams", "9", 1)}
+	ch <- RowEvent{Kind: RowEventUpdate, Hash: 3, Row: importRow("Penguins Mint", "6", 4)}
+	ch <- RowEvent{Kind: RowEventUpdate, Row: importRow("Bourbons", "16", 5)}
+	ch <- RowEvent{Kind: RowEventRemove, Hash: 1}
+	close(ch)
+
+	cmd := table.WatchRowEvents(ch)
+
+	for {
+		msg := cmd()
+		if closed, ok := msg.(ChannelClosedMsg); ok {
+			require.Equal(t, table.ID(), closed.TableID)
+			break
+		}
+
+		table, cmd = table.Update(msg)
+		require.NotNil(t, cmd)
+	}
+
+	require.Equal(t, []string{"Hobnobs", "Penguins Mint", "Bourbons"}, columnValues(table.Rows(), 0))
+	require.Equal(t, "Hobnobs", table.SelectedRow().Data[0], "selected row remains selected")
+}
+
+func TestWatchRowEventsRowNumbers(t *testing.T) {
+	table := New(WithStructData([]rowData{{Name: "Tim Tams", PacketSize: 8, hash: 1}}), WithRowNumbers())
+
+	ch := make(chan RowEvent, 10)
+	ch <- RowEvent{Kind: RowEventAdd, Row: importRow("Penguins", "6", 2)}
+	ch <- RowEvent{Kind: RowEventUpdate, Row: importRow("Tim Tams", "9", 1)}
+	ch <- RowEvent{Kind: RowEventUpdate, Row: importRow("Bourbons", "16", 3)}
+	close(ch)
+
+	table, _ = table.Update(table.WatchRowEvents(ch)())
+
+	require.Equal(t, []string{"1", "Tim Tams", "9"}, table.Rows()[0].Data)
+	require.Equal(t, []string{"2", "Penguins", "6"}, table.Rows()[1].Data)
+	require.Equal(t, []string{"3", "Bourbons", "16"}, table.Rows()[2].Data)
+}
+
+func TestWatchRowEventsIgnoresOtherTables(t *testing.T) {
+	table := importTable()
+	other := importTable()
+
+	ch := make(chan RowEvent, 1)
+	ch <- RowEvent{Kind: RowEventRemove, Hash: 1}
+	close(ch)
+
+	table, _ = table.Update(other.WatchRowEvents(ch)())
+	require.Len(t, table.Rows(), 2)
+}
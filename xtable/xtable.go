@@ -7,20 +7,24 @@ package xtable
 // -	Additional methods
 
 import (
-	"errors"
 	"fmt"
 	"math"
 	"reflect"
-	"sort"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/fireflycons/bubbles/form"
+	"github.com/fireflycons/bubbles/messagebox"
 	"github.com/mattn/go-runewidth"
+	"golang.org/x/text/collate"
 )
 
 // Metadata must be implemented by any metadata associated with a table row,
@@ -33,6 +37,14 @@ type Metadata interface {
 	GetHashCode() uint64
 }
 
+// Colorer may be implemented by row metadata to set the foreground colour of the row,
+// for example to reflect the state of the source data. The colour applies to every cell
+// of the row, except when the row is selected.
+type Colorer interface {
+	// RowColor returns the colour of the row, or nil for the default colour.
+	RowColor() lipgloss.TerminalColor
+}
+
 // Row represents one line in the table.
 type Row struct {
 	Data     []string
@@ -43,6 +55,30 @@ type Row struct {
 type Column struct {
 	Title string
 	Width int
+
+	// Kind selects a registered CellRenderer to render the column's cells.
+	// If empty, the cell value is rendered as is.
+	Kind string
+
+	// Editable permits the column's cells to be edited when editing
+	// is enabled with WithCellEditing.
+	Editable bool
+
+	// Align is the horizontal alignment of the column's title and cells. The default is left aligned.
+	Align lipgloss.Position
+
+	// SortHint is the type hint passed to SortBy when the table is sorted by the column's quick sort key.
+	// If nil, SortNumeric is used.
+	SortHint interface{}
+
+	// Less, if set, orders the column's values when sorting, reporting whether a sorts before b
+	// in ascending order. It takes precedence over the type hint passed to SortBy.
+	Less func(a, b string) bool
+
+	// NotSortable excludes the column from sorting, e.g. a column of actions. SortBy, SortByMulti
+	// and ToggleSort do nothing when asked to sort by it, and the column menu offers no sort.
+	// The row number column is not sortable.
+	NotSortable bool
 }
 
 // Model defines a state for the table widget.
@@ -60,6 +96,156 @@ type Model struct {
 	viewport viewport.Model
 	start    int
 	end      int
+
+	// Unique ID used to route internal messages to this table
+	id int
+
+	// Periodic row refresh, set by WithRefresh
+	refreshInterval time.Duration
+	refreshFetch    func() ([]Row, error)
+
+	// Row filtering state
+	filter filterState
+
+	// Whether to release unused capacity after removing rows
+	compactRows bool
+
+	// Cell renderers registered with this table, by column kind
+	renderers map[string]CellRenderer
+
+	// Consumer supplied extension hooks
+	hooks Hooks
+
+	// Row lifecycle messages waiting to be sent, if enabled by WithRowEvents
+	rowEvents bool
+	events    []tea.Msg
+
+	// Whether SortChangedMsg is queued, set by WithSortEvents
+	sortEvents bool
+
+	// Validates edited or inserted rows
+	validator Validator
+
+	// Inline cell editing state
+	edit editState
+
+	// Whether number keys sort by column, set by WithQuickSortKeys
+	quickSort bool
+
+	// Last sort, and how it is marked in the header
+	sorted         sortState
+	sortIndicators SortIndicators
+
+	// Collation of string values when sorting, set by WithCollator
+	collator *collate.Collator
+
+	// Rows in their order before the first sort, restored by ClearSort
+	unsorted []Row
+
+	// Whether the cursor stays at the same index when sorting, set by WithIndexStableCursor
+	indexStableCursor bool
+
+	// Row pinned by FollowRow
+	follow followState
+
+	// Whether the last row remains selected as rows are appended
+	followTail bool
+
+	// Source of the rows shown instead of rows, set by SetRowSource
+	source RowSource
+
+	// Mapping of struct fields to columns, when created by WithStructData
+	schema *structSchema
+
+	// Pointer to the caller's slice, set by BindStructData
+	binding reflect.Value
+
+	// Columns computed from row metadata, added by WithComputedColumn
+	computed []computedColumn
+
+	// Interval at which columns of KindAge are re-rendered
+	ageInterval time.Duration
+
+	// Transient cell highlights set by HighlightCell
+	highlights   map[highlightKey]highlight
+	highlightSeq int
+
+	// Hashes of rows pinned to the top by PinRow, and the number of them present
+	pins   []uint64
+	pinned int
+
+	// Hashes of rows marked when multi-select is enabled by WithMultiSelect
+	multiSelect bool
+	marks       map[uint64]bool
+
+	// Named filter definitions saved by SaveFilter, ordered by name
+	presets []FilterPreset
+
+	// Text of the last Find, whether it has been promoted to a filter,
+	// and whether the key toggling this is enabled by WithFindFilterToggle
+	findText     string
+	findRegexp   *regexp.Regexp
+	findPromoted bool
+	findFilter   bool
+
+	// Matcher of the last Find, repeated by RepeatFind, and the pattern highlighting its matches in the view
+	findMatch   func(cell string) bool
+	findPattern *regexp.Regexp
+
+	// Whether the last search is restricted to findColumn, set by FindInColumn
+	findInColumn bool
+	findColumn   int
+
+	// Formatters of struct field types for this table, set by WithFormatter
+	formatters map[reflect.Type]formatter
+
+	// Separator of nested struct field titles, set by WithFieldSeparator,
+	// and layout of time fields, set by WithTimeLayout
+	fieldSeparator string
+	timeLayout     string
+
+	// Search terms, oldest first, and the number kept
+	searchHistory      []string
+	searchHistoryLimit int
+
+	// Whether Find ignores case, set by WithCaseInsensitiveFind,
+	// and whether searches wrap around the ends of the table, set by WithFindWrap
+	findIgnoreCase bool
+	findWrap       bool
+
+	// Modal form for editing a row, opened by EditSelectedRow
+	rowForm rowFormState
+
+	// Deletion of rows with confirmation, enabled by WithDeleteConfirmation
+	deleteConfirm deleteConfirmState
+
+	// Actions on the selected row, registered by WithActions
+	actions []Action
+
+	// Actions on the marked rows, registered by WithBulkActions
+	bulkActions []BulkAction
+
+	// Message box confirming an operation on the selected row
+	confirm confirmState
+
+	// Menu of actions on a column, opened by OpenColumnMenu
+	columnMenu columnMenuState
+
+	// Columns hidden by HideColumn
+	hidden []hiddenColumn
+
+	// Menu of actions on the selected row, enabled by WithContextMenu
+	contextMenu contextMenuState
+}
+
+// compactMinCapacity is the smallest row storage capacity that will be compacted.
+const compactMinCapacity = 64
+
+var lastID int64
+
+// nextID returns the next unique ID for a table.
+func nextID() int {
+	return int(atomic.AddInt64(&lastID, 1))
 }
 
 // KeyMap defines keybindings. It satisfies to the help.KeyMap interface, which
@@ -73,6 +259,30 @@ type KeyMap struct {
 	HalfPageDown key.Binding
 	GotoTop      key.Binding
 	GotoBottom   key.Binding
+
+	// Cell editing. Enabled by WithCellEditing
+	Edit       key.Binding
+	AcceptEdit key.Binding
+	CancelEdit key.Binding
+
+	// Delete the selected row. Enabled by WithDeleteConfirmation
+	Delete key.Binding
+
+	// Sort by column number. Enabled by WithQuickSortKeys
+	SortAscending  key.Binding
+	SortDescending key.Binding
+
+	// Toggle filtering by the last Find. Enabled by WithFindFilterToggle
+	FindFilter key.Binding
+
+	// Mark or unmark the selected row. Enabled by WithMultiSelect
+	ToggleMark key.Binding
+
+	// Open the menu of actions on a column. Enabled by WithColumnMenu
+	ColumnMenu key.Binding
+
+	// Open the menu of actions on the selected row. Enabled by WithContextMenu
+	ContextMenu key.Binding
 }
 
 // ShortHelp implements the KeyMap interface.
@@ -85,6 +295,8 @@ func (km KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{km.LineUp, km.LineDown, km.GotoTop, km.GotoBottom},
 		{km.PageUp, km.PageDown, km.HalfPageUp, km.HalfPageDown},
+		{km.Edit, km.AcceptEdit, km.CancelEdit, km.Delete},
+		{km.SortAscending, km.SortDescending, km.FindFilter, km.ToggleMark, km.ColumnMenu, km.ContextMenu},
 	}
 }
 
@@ -124,6 +336,46 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("end", "G"),
 			key.WithHelp("G/end", "go to end"),
 		),
+		Edit: key.NewBinding(
+			key.WithKeys("f2", "enter"),
+			key.WithHelp("f2/enter", "edit"),
+		),
+		AcceptEdit: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "save edit"),
+		),
+		CancelEdit: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel edit"),
+		),
+		Delete: key.NewBinding(
+			key.WithKeys("delete"),
+			key.WithHelp("del", "delete"),
+		),
+		SortAscending: key.NewBinding(
+			key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9", "0"),
+			key.WithHelp("1..0", "sort col"),
+		),
+		SortDescending: key.NewBinding(
+			key.WithKeys("alt+1", "alt+2", "alt+3", "alt+4", "alt+5", "alt+6", "alt+7", "alt+8", "alt+9", "alt+0"),
+			key.WithHelp("M-1..0", "sort col desc"),
+		),
+		FindFilter: key.NewBinding(
+			key.WithKeys("&"),
+			key.WithHelp("&", "filter matches"),
+		),
+		ToggleMark: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "mark"),
+		),
+		ColumnMenu: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "column menu"),
+		),
+		ContextMenu: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "row menu"),
+		),
 	}
 }
 
@@ -133,6 +385,18 @@ type Styles struct {
 	Header   lipgloss.Style
 	Cell     lipgloss.Style
 	Selected lipgloss.Style
+
+	// Applied to a cell being edited when its value fails validation
+	Invalid lipgloss.Style
+
+	// Applied to the last row pinned by PinRow, separating the pinned rows from the others
+	Pinned lipgloss.Style
+
+	// Applied to rows marked when multi-select is enabled, unless selected
+	Marked lipgloss.Style
+
+	// Applied to the text within cells matching the last Find or FindRegexp
+	Match lipgloss.Style
 }
 
 // DefaultStyles returns a set of default style definitions for this table.
@@ -141,6 +405,10 @@ func DefaultStyles() Styles {
 		Selected: lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212")),
 		Header:   lipgloss.NewStyle().Bold(true).Padding(0, 1),
 		Cell:     lipgloss.NewStyle().Padding(0, 1),
+		Invalid:  lipgloss.NewStyle().Foreground(lipgloss.Color("196")),
+		Pinned:   lipgloss.NewStyle().Underline(true),
+		Marked:   lipgloss.NewStyle().Foreground(lipgloss.Color("214")),
+		Match:    lipgloss.NewStyle().Reverse(true),
 	}
 }
 
@@ -158,12 +426,14 @@ type Option func(*Model)
 // New creates a new model for the table widget.
 func New(opts ...Option) Model {
 	m := Model{
+		id:       nextID(),
 		cursor:   0,
 		viewport: viewport.New(0, 20), //nolint:mnd
 
-		KeyMap: DefaultKeyMap(),
-		Help:   help.New(),
-		styles: DefaultStyles(),
+		KeyMap:         DefaultKeyMap(),
+		Help:           help.New(),
+		styles:         DefaultStyles(),
+		sortIndicators: DefaultSortIndicators(),
 	}
 
 	for _, opt := range opts {
@@ -174,6 +444,15 @@ func New(opts ...Option) Model {
 		m.addRowNumbers()
 	}
 
+	m.addComputedColumns()
+
+	m.KeyMap.setEditingEnabled(m.edit.enabled)
+	m.KeyMap.setQuickSortEnabled(m.quickSort)
+	m.KeyMap.Delete.SetEnabled(m.deleteConfirm.enabled)
+	m.KeyMap.FindFilter.SetEnabled(m.findFilter)
+	m.KeyMap.ToggleMark.SetEnabled(m.multiSelect)
+	m.KeyMap.ColumnMenu.SetEnabled(m.columnMenu.enabled)
+	m.KeyMap.ContextMenu.SetEnabled(m.contextMenu.items != nil)
 	m.UpdateViewport()
 
 	return m
@@ -193,23 +472,30 @@ func WithRows(rows []Row) Option {
 	}
 }
 
-// WithStructData creates a table by reflecting a slice of structs implementing the Metadata interface.
+// WithStructData creates a table by reflecting a slice of structs, or pointers to structs, implementing the Metadata interface.
 //
 //   - Column names are derived from struct field names or if present, the value of struct tag "xtable".
+//   - The fields of nested structs are flattened into columns titled with the field containing them, e.g. "Address.City".
+//     Structs that format themselves, such as time.Time, are not flattened. See WithFieldSeparator.
+//   - time.Time fields are formatted with the layout set by WithTimeLayout, and sorted chronologically.
 //   - Row data is converted to strings from the data in the slice.
 //   - Row Metadata field is set to the values in the slice.
-//   - All public struct fields are included, unless constrained by field names listed in `fields` argument.
+//   - All public struct fields are included, unless tagged `xtable:"-"` or constrained by field names listed in `fields` argument.
+//   - Columns are in the order of the `fields` argument, or of the "order" tag option, e.g. `xtable:"Name,order=1"`,
+//     followed by fields without an order in declaration order.
+//   - Nil pointers in the slice are skipped.
 //
 // Panics if there is any error parsing the data from the slice, such as
-//   - data is not a slice of structs
+//   - data is not a slice of structs or pointers to structs
 //   - slice element does not implement Metadata
 func WithStructData(data interface{}, fields ...string) Option {
 	return func(m *Model) {
-		if c, r, err := renderTable(data, fields); err != nil {
+		if schema, r, err := renderTable(data, fields, m.structOptions()); err != nil {
 			panic(fmt.Sprintf("Cannot render table: %s", err.Error()))
 		} else {
-			m.cols = c
+			m.cols = schema.columns
 			m.rows = r
+			m.schema = schema
 		}
 	}
 }
@@ -221,6 +507,15 @@ func WithRowNumbers() Option {
 	}
 }
 
+// WithRowCompaction causes the table to release the unused capacity of its row storage
+// once enough rows have been removed. This is useful in long-running programs which add and
+// remove many rows, as otherwise the storage remains as large as the most rows ever held.
+func WithRowCompaction() Option {
+	return func(m *Model) {
+		m.compactRows = true
+	}
+}
+
 // WithHeight sets the height of the table.
 func WithHeight(h int) Option {
 	return func(m *Model) {
@@ -256,14 +551,165 @@ func WithKeyMap(km KeyMap) Option {
 	}
 }
 
+// Init returns the initial command for the table. If a refresh was configured
+// with WithRefresh, this starts the refresh loop, and if the table has a column of
+// KindAge, the periodic re-rendering of that column, so it should be returned from
+// (or batched into) the owning model's Init method.
+func (m Model) Init() tea.Cmd {
+	return tea.Batch(m.refreshTick(), m.ageTick())
+}
+
 // Update is the Bubble Tea update loop.
+// Any row lifecycle messages queued since the last update are returned with the command.
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
-	if !m.focus {
-		return m, nil
+	m, cmd := m.update(msg)
+
+	if events := m.FlushEvents(); events != nil {
+		return m, tea.Batch(cmd, events)
 	}
 
+	return m, cmd
+}
+
+func (m Model) update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case refreshTickMsg:
+		if msg.id != m.id {
+			return m, nil
+		}
+
+		return m, m.refreshFetchCmd()
+
+	case refreshResultMsg:
+		if msg.id != m.id {
+			return m, nil
+		}
+
+		return m.applyRefresh(msg)
+
+	case filterDebounceMsg:
+		if msg.id != m.id {
+			return m, nil
+		}
+
+		cmd := m.filterCmd(msg.seq)
+		return m, cmd
+
+	case filterResultMsg:
+		if msg.id != m.id {
+			return m, nil
+		}
+
+		m.applyFilterResult(msg)
+		return m, nil
+
+	case highlightExpiredMsg:
+		if msg.id != m.id {
+			return m, nil
+		}
+
+		m.clearHighlight(msg)
+		return m, nil
+
+	case ageTickMsg:
+		if msg.id != m.id {
+			return m, nil
+		}
+
+		m.UpdateViewport()
+		return m, m.ageTick()
+
+	case channelRowsMsg:
+		if msg.id != m.id {
+			return m, nil
+		}
+
+		cmd := m.applyChannelRows(msg)
+		return m, cmd
+
+	case rowEventsMsg:
+		if msg.id != m.id {
+			return m, nil
+		}
+
+		cmd := m.applyRowEvents(msg)
+		return m, cmd
+
+	case commandMsg:
+		if msg.id != m.id {
+			return m, nil
+		}
+
+		msg.apply(&m)
+		return m, nil
+
+	case form.SubmittedMsg:
+		if msg.ID != m.rowForm.form.ID() {
+			return m, nil
+		}
+
+		cmd := m.applyRowForm(msg)
+		return m, cmd
+
+	case messagebox.SequenceDoneMsg:
+		switch {
+		case m.columnMenu.running && msg.ID == m.columnMenu.seq.ID():
+			cmd := m.applyColumnMenu(msg)
+			return m, cmd
+		case m.contextMenu.running && msg.ID == m.contextMenu.seq.ID():
+			cmd := m.applyContextMenu(msg)
+			return m, cmd
+		}
+
+		return m, nil
+
+	case messagebox.SequenceCancelledMsg:
+		switch {
+		case m.columnMenu.running && msg.ID == m.columnMenu.seq.ID():
+			m.columnMenu.running = false
+		case m.contextMenu.running && msg.ID == m.contextMenu.seq.ID():
+			m.contextMenu.running = false
+		}
+
+		return m, nil
+
+	case confirmMsg:
+		if msg.id != m.id {
+			return m, nil
+		}
+
+		cmd := m.applyConfirm(msg)
+		return m, cmd
+
 	case tea.KeyMsg:
+		if !m.focus {
+			return m, nil
+		}
+
+		if m.ModalActive() {
+			cmd := m.updateModal(msg)
+			return m, cmd
+		}
+
+		if m.edit.active {
+			cmd := m.updateEdit(msg)
+			return m, cmd
+		}
+
+		if m.hooks.OnKey != nil {
+			if handled, cmd := m.hooks.OnKey(&m, msg); handled {
+				return m, cmd
+			}
+		}
+
+		if handled, cmd := m.performBulkAction(msg); handled {
+			return m, cmd
+		}
+
+		if handled, cmd := m.performAction(msg); handled {
+			return m, cmd
+		}
+
 		switch {
 		case key.Matches(msg, m.KeyMap.LineUp):
 			m.MoveUp(1)
@@ -281,6 +727,37 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			m.GotoTop()
 		case key.Matches(msg, m.KeyMap.GotoBottom):
 			m.GotoBottom()
+		case key.Matches(msg, m.KeyMap.SortAscending) && m.quickSort:
+			m.sortByKey(msg, SortAscending)
+		case key.Matches(msg, m.KeyMap.SortDescending) && m.quickSort:
+			m.sortByKey(msg, SortDescending)
+		case key.Matches(msg, m.KeyMap.FindFilter) && m.findFilter:
+			cmd := m.toggleFindFilter()
+			return m, cmd
+		case key.Matches(msg, m.KeyMap.ToggleMark) && m.multiSelect:
+			m.ToggleMark()
+		case key.Matches(msg, m.KeyMap.ColumnMenu) && m.columnMenu.enabled:
+			m.OpenColumnMenu(-1)
+		case key.Matches(msg, m.KeyMap.ContextMenu) && m.contextMenu.items != nil:
+			m.OpenContextMenu()
+		case key.Matches(msg, m.KeyMap.Delete) && m.deleteConfirm.enabled:
+			m.confirmDelete()
+		case key.Matches(msg, m.KeyMap.Edit) && m.edit.enabled:
+			cmd := m.StartEdit(-1)
+			return m, cmd
+		}
+
+	default:
+		if m.ModalActive() {
+			cmd := m.updateModal(msg)
+			return m, cmd
+		}
+
+		if m.edit.active {
+			// e.g. cursor blink
+			var cmd tea.Cmd
+			m.edit.input, cmd = m.edit.input.Update(msg)
+			return m, cmd
 		}
 	}
 
@@ -307,20 +784,25 @@ func (m *Model) Blur() {
 
 // View renders the component.
 func (m Model) View() string {
-	return m.headersView() + "\n" + m.viewport.View()
+	if m.hooks.OnBeforeRender != nil {
+		m.hooks.OnBeforeRender(&m)
+		m.UpdateViewport()
+	}
+
+	return m.renderModal(m.headersView() + "\n" + m.viewport.View())
 }
 
 // HelpView is a helper method for rendering the help menu from the keymap.
 // Note that this view is not rendered by default and you must call it
 // manually in your application, where applicable.
 func (m Model) HelpView() string {
-	return m.Help.View(m.KeyMap)
+	return m.Help.View(m.HelpKeyMap())
 }
 
 // UpdateViewport updates the list content based on the previously defined
 // columns and rows.
 func (m *Model) UpdateViewport() {
-	renderedRows := make([]string, 0, len(m.rows))
+	renderedRows := make([]string, 0, 2*m.viewport.Height)
 
 	// Render only rows from: m.cursor-m.viewport.Height to: m.cursor+m.viewport.Height
 	// Constant runtime, independent of number of rows in a table.
@@ -330,7 +812,7 @@ func (m *Model) UpdateViewport() {
 	} else {
 		m.start = 0
 	}
-	m.end = clamp(m.cursor+m.viewport.Height, m.cursor, len(m.rows))
+	m.end = clamp(m.cursor+m.viewport.Height, m.cursor, m.rowCount())
 	for i := m.start; i < m.end; i++ {
 		renderedRows = append(renderedRows, m.renderRow(i))
 	}
@@ -343,32 +825,50 @@ func (m *Model) UpdateViewport() {
 // SelectedRow returns the selected row.
 // You can cast it to your own implementation.
 func (m Model) SelectedRow() Row {
-	if m.cursor < 0 || m.cursor >= len(m.rows) {
+	if m.cursor < 0 || m.cursor >= m.rowCount() {
 		return Row{}
 	}
 
-	return m.rows[m.cursor]
+	return m.rowAt(m.cursor)
 }
 
-// Rows returns the current rows.
+// Rows returns the current rows. If a filter is active, only the rows
+// matching the filter are returned.
 func (m Model) Rows() []Row {
 	return m.rows
 }
 
+// AllRows returns all rows, including any hidden by an active filter.
+func (m Model) AllRows() []Row {
+	if m.filter.match != nil {
+		return m.filter.all
+	}
+
+	return m.rows
+}
+
 // Columns returns the current columns.
 func (m Model) Columns() []Column {
 	return m.cols
 }
 
-// SetRows sets a new rows state.
+// SetRows sets a new rows state. If a filter is active, it is applied to the new rows.
+// If a row is being followed (see FollowRow), it is selected.
 func (m *Model) SetRows(r []Row) {
-	m.rows = r
+	m.setAllRows(r)
+
+	if m.follow.active {
+		m.restoreCursor(0, false)
+		return
+	}
+
 	m.UpdateViewport()
 }
 
 // SetColumns sets a new columns state.
 func (m *Model) SetColumns(c []Column) {
 	m.cols = c
+	m.hidden = nil
 	m.UpdateViewport()
 }
 
@@ -401,14 +901,14 @@ func (m Model) Cursor() int {
 
 // SetCursor sets the cursor position in the table.
 func (m *Model) SetCursor(n int) {
-	m.cursor = clamp(n, 0, len(m.rows)-1)
+	m.cursor = clamp(n, 0, m.rowCount()-1)
 	m.UpdateViewport()
 }
 
 // MoveUp moves the selection up by any number of rows.
 // It can not go above the first row.
 func (m *Model) MoveUp(n int) {
-	m.cursor = clamp(m.cursor-n, 0, len(m.rows)-1)
+	m.cursor = clamp(m.cursor-n, 0, m.rowCount()-1)
 	switch {
 	case m.start == 0:
 		m.viewport.SetYOffset(clamp(m.viewport.YOffset, 0, m.cursor))
@@ -423,11 +923,11 @@ func (m *Model) MoveUp(n int) {
 // MoveDown moves the selection down by any number of rows.
 // It can not go below the last row.
 func (m *Model) MoveDown(n int) {
-	m.cursor = clamp(m.cursor+n, 0, len(m.rows)-1)
+	m.cursor = clamp(m.cursor+n, 0, m.rowCount()-1)
 	m.UpdateViewport()
 
 	switch {
-	case m.end == len(m.rows) && m.viewport.YOffset > 0:
+	case m.end == m.rowCount() && m.viewport.YOffset > 0:
 		m.viewport.SetYOffset(clamp(m.viewport.YOffset-n, 1, m.viewport.Height))
 	case m.cursor > (m.end-m.start)/2 && m.viewport.YOffset > 0:
 		m.viewport.SetYOffset(clamp(m.viewport.YOffset-n, 1, m.cursor))
@@ -444,19 +944,34 @@ func (m *Model) GotoTop() {
 
 // GotoBottom moves the selection to the last row.
 func (m *Model) GotoBottom() {
-	m.MoveDown(len(m.rows))
+	m.MoveDown(m.rowCount())
 }
 
 // FromValues create the table rows from a simple string. It uses `\n` by
 // default for getting all the rows and the given separator for the fields on
-// each row.
+// each row. A field enclosed in double quotes is one cell, even if it contains the separator or
+// a line break, and a double quote within it is written as two double quotes.
+//
+// Earlier versions kept the quotes of quoted fields. If any quoted field is not terminated, the text is
+// split without quote handling, keeping all of its quotes, and no error is reported. To keep the quotes,
+// or for an escape character, irregular white space or errors in the data, use FromValuesWithOptions,
+// which handles quotes only if WithQuotedFields is given.
 func (m *Model) FromValues(value, separator string) {
-	rows := []Row{}
-	for _, line := range strings.Split(value, "\n") {
-		r := Row{
-			Data: strings.Split(line, separator),
+	if separator == "" {
+		// Each character is a field, as strings.Split
+		rows := []Row{}
+		for _, line := range strings.Split(value, "\n") {
+			rows = append(rows, Row{Data: strings.Split(line, separator)})
 		}
-		rows = append(rows, r)
+
+		m.SetRows(rows)
+
+		return
+	}
+
+	rows, err := parseValues(value, separator, &valuesOptions{quotes: true})
+	if err != nil {
+		rows, _ = parseValues(value, separator, &valuesOptions{})
 	}
 
 	m.SetRows(rows)
@@ -464,35 +979,79 @@ func (m *Model) FromValues(value, separator string) {
 
 func (m Model) headersView() string {
 	s := make([]string, 0, len(m.cols))
-	for _, col := range m.cols {
+	for i, col := range m.cols {
 		if col.Width <= 0 {
 			continue
 		}
-		style := lipgloss.NewStyle().Width(col.Width).MaxWidth(col.Width).Inline(true)
-		renderedCell := style.Render(runewidth.Truncate(col.Title, col.Width, "…"))
+		style := lipgloss.NewStyle().Width(col.Width).MaxWidth(col.Width).Inline(true).Align(col.Align)
+		renderedCell := style.Render(m.headerTitle(i))
 		s = append(s, m.styles.Header.Render(renderedCell))
 	}
 	return lipgloss.JoinHorizontal(lipgloss.Top, s...)
 }
 
 func (m *Model) renderRow(r int) string {
+	data := m.rowAt(r)
+	row := m.renderCells(r, data)
+
+	switch {
+	case r == m.cursor:
+		row = m.styles.Selected.Render(row)
+	case m.IsMarked(data):
+		row = m.styles.Marked.Render(row)
+	}
+
+	if r == m.pinned-1 {
+		row = m.styles.Pinned.Render(row)
+	}
+
+	return row
+}
+
+// renderCells renders the cells of a row. r is the index of the row in the visible rows,
+// which is used to show the cell editor, or -1 if not applicable.
+func (m Model) renderCells(r int, row Row) string {
+	var colour lipgloss.TerminalColor
+
+	if c, ok := row.Metadata.(Colorer); ok && r != m.cursor {
+		colour = c.RowColor()
+	}
+
 	s := make([]string, 0, len(m.cols))
-	for i, value := range m.rows[r].Data {
+	for i, value := range row.Data {
+		if i >= len(m.cols) {
+			// Fields without a column are not displayed
+			break
+		}
 		if m.cols[i].Width <= 0 {
 			continue
 		}
-		style := lipgloss.NewStyle().Width(m.cols[i].Width).MaxWidth(m.cols[i].Width).Inline(true)
-		renderedCell := m.styles.Cell.Render(style.Render(runewidth.Truncate(value, m.cols[i].Width, "…")))
-		s = append(s, renderedCell)
-	}
+		style := lipgloss.NewStyle().Width(m.cols[i].Width).MaxWidth(m.cols[i].Width).Inline(true).Align(m.cols[i].Align)
+
+		if colour != nil {
+			style = style.Foreground(colour)
+		}
+
+		if h, ok := m.cellHighlight(r, row, i); ok {
+			style = h.Inherit(style)
+		}
+
+		if m.isEditing(r, i) {
+			s = append(s, m.styles.Cell.Render(style.Render(m.editView())))
+			continue
+		}
+
+		value = runewidth.Truncate(m.renderCell(value, row.Metadata, m.cols[i]), m.cols[i].Width, "…")
 
-	row := lipgloss.JoinHorizontal(lipgloss.Top, s...)
+		if r >= 0 && (i > 0 || !m.rowNumbers) && (!m.findInColumn || i == m.findColumn) {
+			value = m.highlightMatches(value)
+		}
 
-	if r == m.cursor {
-		return m.styles.Selected.Render(row)
+		renderedCell := m.styles.Cell.Render(style.Render(value))
+		s = append(s, renderedCell)
 	}
 
-	return row
+	return lipgloss.JoinHorizontal(lipgloss.Top, s...)
 }
 
 func max(a, b int) int {
@@ -523,6 +1082,36 @@ func (m Model) SelectedRowYOffset() int {
 	return m.cursor - m.start - m.viewport.YOffset
 }
 
+// SelectedRowScreenY returns the line of the table's view on which the selected row is rendered,
+// i.e. SelectedRowYOffset plus the height of the headers. Add the line on which the table's view
+// is rendered within the parent view to position overlays, e.g. with messagebox.WithRowAnchor.
+func (m Model) SelectedRowScreenY() int {
+	return lipgloss.Height(m.headersView()) + m.SelectedRowYOffset()
+}
+
+// SelectedRowScreenRect returns the rectangle occupied by the selected row on screen, given the position
+// (originX, originY) at which the table's view is rendered within the parent view. The rectangle can be used
+// to position overlays, tooltips and context menus, e.g. with messagebox.WithRelativeTo.
+// The boolean result is false if there is no selected row or it is scrolled out of view.
+func (m Model) SelectedRowScreenRect(originX, originY int) (messagebox.Rect, bool) {
+	offset := m.SelectedRowYOffset()
+	if m.cursor < 0 || m.cursor >= m.rowCount() || offset < 0 || offset >= m.viewport.Height {
+		return messagebox.Rect{}, false
+	}
+
+	width := lipgloss.Width(m.renderRow(m.cursor))
+	if m.viewport.Width > 0 {
+		width = min(width, m.viewport.Width)
+	}
+
+	return messagebox.Rect{
+		X:      originX,
+		Y:      originY + m.SelectedRowScreenY(),
+		Width:  width,
+		Height: 1,
+	}, true
+}
+
 // RemoveSelectedRow removes the currently selected row. If no rows remain, this returns false.
 func (m *Model) RemoveSelectedRow() bool {
 
@@ -552,105 +1141,277 @@ func (m *Model) RemoveRowByIndex(index int) bool {
 		return true
 	}
 
-	switch {
-	case len(m.rows) <= 1:
+	m.emitRowRemoved(m.rows[index])
 
-		m.rows = []Row{}
+	if m.filter.match != nil {
+		// Remove from the complete row set. The visible rows are re-evaluated by refilter.
+		m.filter.all = m.compact(removeIndex(m.filter.all, m.filter.index[index]))
+	} else {
+		m.rows = m.compact(removeIndex(m.rows, index))
+	}
 
-	case index == 0:
+	m.refilter()
+	m.cursor = clamp(m.cursor, 0, len(m.rows)-1)
+	m.RenumberRows()
+	m.UpdateViewport()
+	return len(m.AllRows()) > 0
+}
 
-		m.rows = m.rows[1:]
+// RemoveRowsWhere removes all rows for which the predicate returns true, including any hidden by a filter,
+// and returns the number of rows removed. The selected row remains selected if it is not removed,
+// otherwise the nearest remaining row is selected.
+func (m *Model) RemoveRowsWhere(predicate func(Row) bool) int {
+	all := m.AllRows()
 
-	case index == len(m.rows)-1:
+	visible := make(map[int]bool, len(m.rows))
+	for i := range m.rows {
+		visible[m.allIndex(i)] = true
+	}
 
-		m.rows = m.rows[:len(m.rows)-1]
+	selected := m.allIndex(m.cursor)
+	kept := make([]Row, 0, len(all))
+	cursor, before := -1, -1
 
-	default:
+	for i, r := range all {
+		if predicate(r) {
+			m.emitRowRemoved(r)
+			continue
+		}
 
-		m.rows = removeIndex(m.rows, index)
+		// Select the selected row if kept, otherwise the next visible row after it, or the last before it
+		switch {
+		case !visible[i]:
+		case i < selected:
+			before = len(kept)
+		case cursor == -1:
+			cursor = len(kept)
+		}
+
+		kept = append(kept, r)
 	}
 
-	m.cursor = clamp(m.cursor, 0, len(m.rows)-1)
+	removed := len(all) - len(kept)
+	if removed == 0 {
+		return 0
+	}
+
+	if cursor == -1 {
+		cursor = before
+	}
+
+	if m.filter.match != nil {
+		m.filter.all = m.compact(kept)
+	} else {
+		m.rows = m.compact(kept)
+	}
+
+	m.refilter()
+	m.cursor = clamp(m.visibleIndex(cursor), 0, len(m.rows)-1)
 	m.RenumberRows()
 	m.UpdateViewport()
-	return len(m.rows) > 0
+	m.scrollToCursor()
+
+	return removed
 }
 
-// SortOrder defines the sort direction for the SortBy method.
-type SortOrder bool
+// RemoveRowsByHashes removes the rows identified by the metadata hashes in one operation, e.g. the rows marked
+// with multi-select, and returns the number of rows removed. As for RemoveRowsWhere, the selected row remains
+// selected if it is not removed, otherwise the nearest remaining row is selected. Removed rows are unmarked.
+func (m *Model) RemoveRowsByHashes(hashes []uint64) int {
+	remove := make(map[uint64]bool, len(hashes))
+	for _, h := range hashes {
+		remove[h] = true
+		delete(m.marks, h)
+	}
 
-const (
-	SortAscending  SortOrder = false
-	SortDescending SortOrder = true
-	SortString               = ""
-	SortNumeric              = 0
-)
+	return m.RemoveRowsWhere(func(r Row) bool {
+		return r.Metadata != nil && remove[r.Metadata.GetHashCode()]
+	})
+}
 
-// SortBy sorts the table by column identified by 'index' and
-// in the given order.
-//
-// typeHint hints what data type should be assumed for the column. Pass empty string
-// to string-sort, 0 to numerically sort (all numeric types). If the data cannot be cast
-// to a numeric type when requested, then it will string sort the displayed data.
-func (m *Model) SortBy(index int, order SortOrder, typeHint interface{}) {
-	if index < 0 || index >= len(m.Columns()) {
-		return
+// appendRow adds a row to the end of the table and selects it, if it is not hidden by a filter.
+func (m *Model) appendRow(r Row) {
+	m.emitRowAdded(r)
+
+	if m.filter.match != nil {
+		m.filter.all = append(m.filter.all, r)
+	} else {
+		m.rows = append(m.rows, r)
+	}
+
+	m.refilter()
+
+	visible := m.filter.match == nil ||
+		len(m.filter.index) > 0 && m.filter.index[len(m.filter.index)-1] == len(m.filter.all)-1
+
+	if visible {
+		m.cursor = len(m.rows) - 1
 	}
 
-	rows := m.Rows()
+	m.RenumberRows()
+	m.UpdateViewport()
+}
 
-	sort.Slice(rows, func(i, j int) bool {
-		iData, jData := rows[i].Data[index], rows[j].Data[index]
+// Find performs a free text search of the table data for the given string,
+// beginning from startRow+1 or cursor+1 whichever is sooner, to the end of the table. Cursor is moved to the
+// first match. If no match is found, false is returned. The text can be promoted to a filter by PromoteFind.
+// The search is case sensitive, unless set otherwise by WithCaseInsensitiveFind, and continues from the
+// start of the table if enabled by WithFindWrap. The row number column, if any, is not searched.
+func (m *Model) Find(text string, startRow int) bool {
+	m.setFindText(text)
 
-		var iNumeric, jNumeric float64
-		var err1, err2 error
-		compareNumeric := false
+	return m.find(startRow, false)
+}
 
-		switch typeHint.(type) {
-		case string:
-			break // early
-		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+// FindPrev searches backwards for the given string as Find does, beginning from startRow-1 or cursor-1
+// whichever is later, to the start of the table, or if enabled by WithFindWrap, continuing from the end.
+func (m *Model) FindPrev(text string, startRow int) bool {
+	m.setFindText(text)
 
-			iNumeric, err1 = strconv.ParseFloat(iData, 64)
-			jNumeric, err2 = strconv.ParseFloat(jData, 64)
-			if err1 == nil && err2 == nil {
-				compareNumeric = true
-			}
+	return m.find(startRow, true)
+}
+
+// RepeatFind repeats the last Find, FindPrev or FindRegexp from the cursor, forwards or backwards,
+// e.g. for vim-style n and N keys. If there has been no search, false is returned.
+func (m *Model) RepeatFind(backwards bool) bool {
+	if m.findMatch == nil {
+		return false
+	}
+
+	return m.find(m.cursor, backwards)
+}
+
+// FindAll returns the indices of all rows with a cell containing the given string, matched as by Find,
+// e.g. to show "match 3 of 17" or build a list of matches to jump to. The cursor is not moved, and the
+// last search repeated by RepeatFind is unchanged.
+func (m Model) FindAll(text string) []int {
+	match := m.findMatcher(text)
+	indices := []int{}
+
+	for i := 0; i < m.rowCount(); i++ {
+		if m.rowMatches(m.rowAt(i), match, false) {
+			indices = append(indices, i)
 		}
+	}
 
-		if order == SortAscending {
-			if compareNumeric {
-				return iNumeric < jNumeric
-			} else {
-				return iData < jData
-			}
+	return indices
+}
+
+// FindInColumn searches the column at the given index for the given string, as Find does in all columns,
+// e.g. to search only the Email column of a wide table. The search is repeated in the same column by
+// RepeatFind, and matches only in that column when promoted to a filter by PromoteFind.
+func (m *Model) FindInColumn(col int, text string, startRow int) bool {
+	m.setFindText(text)
+	m.findInColumn = true
+	m.findColumn = col
+
+	return m.find(startRow, false)
+}
+
+// setFindText sets the text searched for by Find and FindPrev.
+func (m *Model) setFindText(text string) {
+	m.findText = text
+	m.findRegexp = nil
+	m.findInColumn = false
+	m.AddSearchHistory(text)
+	m.findMatch = m.findMatcher(text)
+	m.findPattern = nil
+
+	if text != "" {
+		pattern := regexp.QuoteMeta(text)
+		if m.findIgnoreCase {
+			pattern = "(?i)" + pattern
 		}
 
-		if compareNumeric {
-			return iNumeric > jNumeric
-		} else {
-			return iData > jData
+		m.findPattern = regexp.MustCompile(pattern)
+	}
+}
+
+// findMatcher returns a function reporting whether a cell contains the text, ignoring case if set by
+// WithCaseInsensitiveFind.
+func (m Model) findMatcher(text string) func(cell string) bool {
+	if m.findIgnoreCase {
+		text = strings.ToLower(text)
+
+		return func(cell string) bool {
+			return strings.Contains(strings.ToLower(cell), text)
 		}
-	})
+	}
 
-	m.RenumberRows()
-	m.UpdateViewport()
+	return func(cell string) bool {
+		return strings.Contains(cell, text)
+	}
 }
 
-// Find performs a free text search of the table data for the given string,
-// beginning from startRow+1 or cursor+1 whichever is sooner, to the end of the table. Cursor is moved to the
-// first match. If no match is found, false is returned.
-func (m *Model) Find(text string, startRow int) bool {
+// FindRegexp searches the table data for a cell matching the regular expression, as Find does for text.
+// The expression can be promoted to a filter by PromoteFind, and FindText returns its source text.
+func (m *Model) FindRegexp(re *regexp.Regexp, startRow int) bool {
+	m.findText = re.String()
+	m.findRegexp = re
+	m.findMatch = re.MatchString
+	m.findPattern = re
+	m.findInColumn = false
+	m.AddSearchHistory(m.findText)
 
-	for i := clamp(min(startRow, m.Cursor())+1, 0, len(m.rows)-1); i < len(m.rows); i++ {
-		for _, col := range m.rows[i].Data {
-			if strings.Contains(col, text) {
-				m.SetCursor(i)
-				m.UpdateViewport()
-				return true
-			}
+	return m.find(startRow, false)
+}
+
+// findMatches returns true if a cell of the row matches the last search, in its column if set by FindInColumn.
+func (m Model) findMatches(r Row) bool {
+	return m.rowMatches(r, m.findMatch, m.findInColumn)
+}
+
+// rowMatches returns true if a cell of the row satisfies match, in the column set by FindInColumn if inColumn
+// is set. The row number cell is never matched, as it isn't highlighted.
+func (m Model) rowMatches(r Row, match func(cell string) bool, inColumn bool) bool {
+	for i, cell := range r.Data {
+		if (i > 0 || !m.rowNumbers) && (!inColumn || i == m.findColumn) && match(cell) {
+			return true
 		}
 	}
+
+	return false
+}
+
+// find moves the cursor to the first row after startRow or the cursor, whichever is sooner, or if backwards,
+// the first row before startRow or the cursor, whichever is later, with a cell matching the last search.
+// If no match is found, false is returned.
+func (m *Model) find(startRow int, backwards bool) bool {
+	n := m.rowCount()
+	if n == 0 {
+		return false
+	}
+
+	start, step, count := clamp(min(startRow, m.Cursor())+1, 0, n-1), 1, n
+	if m.findWrap && min(startRow, m.Cursor())+1 >= n {
+		start = 0
+	}
+
+	if backwards {
+		// Unlike searching forwards, the cursor row isn't searched when it is the first row
+		start, step = min(max(startRow, m.Cursor())-1, n-1), -1
+	}
+
+	if !m.findWrap {
+		count = n - start
+		if backwards {
+			count = start + 1
+		}
+	}
+
+	for k := 0; k < count; k++ {
+		i := (start + k*step + n) % n
+
+		if m.findMatches(m.rowAt(i)) {
+			m.SetCursor(i)
+			m.UpdateViewport()
+			return true
+		}
+	}
+
+	// Highlight any matches of a new search in the view, though the cursor hasn't moved
+	m.UpdateViewport()
+
 	return false
 }
 
@@ -689,26 +1450,71 @@ func (m Model) GetRow(obj Metadata) int {
 }
 
 func (m *Model) addRowNumbers() {
-
 	// Insert rowNumberColumn as column 0
-	colWidth := rowNumberColWidth(m.rows)
+	m.cols = append([]Column{rowNumberColumn(m.rowCount())}, m.cols...)
+	prependRowNumbers(m.rows)
+}
+
+// rowNumberColumn returns the column showing row numbers for the given number of rows.
+func rowNumberColumn(rows int) Column {
+	colWidth := digits(rows)
+
+	return Column{
+		Title:       pad(colWidth, "#"),
+		Width:       colWidth + 1,
+		NotSortable: true,
+	}
+}
 
-	rowNumberColumn := Column{
-		Title: pad(colWidth, "#"),
-		Width: colWidth + 1,
+// setDataColumns replaces the columns of the row data, keeping the row number column if row numbers are enabled.
+func (m *Model) setDataColumns(cols []Column) {
+	if m.rowNumbers {
+		cols = append([]Column{rowNumberColumn(len(m.AllRows()))}, cols...)
 	}
 
-	m.cols = append([]Column{rowNumberColumn}, m.cols...)
+	m.SetColumns(cols)
+}
 
-	for i, v := range m.rows {
-		m.rows[i].Data = append([]string{pad(colWidth, i+1)}, v.Data...)
+// dataColumns returns the columns of the row data, excluding the row number column if row numbers are enabled.
+func (m Model) dataColumns() []Column {
+	if m.rowNumbers && len(m.cols) > 0 {
+		return m.cols[1:]
 	}
+
+	return m.cols
+}
+
+// prependRowNumbers inserts the row number cell at the start of each row's data.
+func prependRowNumbers(rows []Row) {
+	colWidth := rowNumberColWidth(rows)
+
+	for i, v := range rows {
+		rows[i].Data = append([]string{pad(colWidth, i+1)}, v.Data...)
+	}
+}
+
+// numberRows returns the rows with the row number cell inserted at the start of each row's data if row numbers
+// are enabled, for rows added after construction. The given rows are unchanged.
+func (m Model) numberRows(rows []Row) []Row {
+	if !m.rowNumbers {
+		return rows
+	}
+
+	numbered := append([]Row(nil), rows...)
+	prependRowNumbers(numbered)
+
+	return numbered
 }
 
 // rowNumberColWidth calculates the width of the column for row numbers
 // based on the number of rows when the table is created.
 func rowNumberColWidth(rows []Row) int {
-	return int(math.Log10(float64(len(rows)))) + 1
+	return digits(len(rows))
+}
+
+// digits returns the number of decimal digits of n.
+func digits(n int) int {
+	return int(math.Log10(float64(n))) + 1
 }
 
 // pad is used for justifying the row numbers column
@@ -736,144 +1542,45 @@ func pad(width int, i interface{}) string {
 	return strings.Repeat(" ", max(width-len(value), 0)) + value
 }
 
-func removeIndex[T any](s []T, index int) []T {
-	return append(s[:index], s[index+1:]...)
-}
-
-// renderTable builds a table from a slice of struct.
-// The slice elements must be all the same type.
-func renderTable(data interface{}, fields []string) ([]Column, []Row, error) {
-	v := reflect.ValueOf(data)
-	if v.Kind() != reflect.Slice || v.Len() == 0 {
-		return nil, nil, errors.New("invalid or empty data slice")
-	}
-
-	elemType := v.Index(0).Type()
-	if elemType.Kind() != reflect.Struct {
-		return nil, nil, errors.New("data slice must contain structs")
-	}
-
-	// Check if the elements implement the Metadata interface
-	var metadataInterfaceType = reflect.TypeOf((*Metadata)(nil)).Elem()
-	if !elemType.Implements(metadataInterfaceType) {
-		return nil, nil, errors.New("elements in the data slice must implement the Metadata interface")
+// compact releases unused row storage capacity if row compaction is enabled.
+func (m *Model) compact(rows []Row) []Row {
+	if !m.compactRows {
+		return rows
 	}
 
-	// Recursive function to get all field names and struct tag values, including embedded structs
-	var getFieldNamesWithTags func(reflect.Type) []string
-	getFieldNamesWithTags = func(t reflect.Type) []string {
-		var result []string
-		for i := 0; i < t.NumField(); i++ {
-			field := t.Field(i)
-
-			// Skip unexported fields
-			if !field.IsExported() {
-				continue
-			}
-
-			if field.Anonymous {
-				embeddedFields := getFieldNamesWithTags(field.Type)
-				result = append(result, embeddedFields...)
-			} else {
-				if tag := field.Tag.Get("xtable"); tag != "" {
-					result = append(result, tag) // Use the struct tag's value
-				} else {
-					result = append(result, field.Name)
-				}
-			}
-		}
-		return result
-	}
-
-	// Recursive function to find the field index path (for nested structs)
-	var getFieldIndices func(reflect.Type, string) ([]int, bool)
-	getFieldIndices = func(t reflect.Type, fieldName string) ([]int, bool) {
-		for i := 0; i < t.NumField(); i++ {
-			field := t.Field(i)
-
-			// Skip unexported fields
-			if !field.IsExported() {
-				continue
-			}
-
-			if field.Anonymous {
-				indices, found := getFieldIndices(field.Type, fieldName)
-				if found {
-					return append([]int{i}, indices...), true
-				}
-			} else {
-				if tag := field.Tag.Get("xtable"); tag == fieldName || (tag == "" && field.Name == fieldName) {
-					return []int{i}, true
-				}
-			}
-		}
-		return nil, false
-	}
+	return compactSlice(rows)
+}
 
-	// Safely get the value of a nested field
-	getNestedFieldValue := func(v reflect.Value, indices []int) (result reflect.Value) {
-		defer func() {
-			if r := recover(); r != nil {
-				// Ignore unexported or inaccessible fields
-				result = reflect.Value{}
-			}
-		}()
+// removeIndex removes the element at index, shifting later elements down.
+// The vacated slot at the end of the backing array is zeroed so that
+// it doesn't keep the removed element (or its referents) alive.
+func removeIndex[T any](s []T, index int) []T {
+	copy(s[index:], s[index+1:])
 
-		for _, index := range indices {
-			if v.Kind() == reflect.Ptr && !v.IsNil() {
-				v = v.Elem() // Dereference pointer
-			}
-			v = v.Field(index)
-		}
-		return v
-	}
+	var zero T
+	s[len(s)-1] = zero
 
-	// Get all struct field names if fields are not provided
-	if len(fields) == 0 {
-		fields = getFieldNamesWithTags(elemType)
-	}
+	return s[:len(s)-1]
+}
 
-	// Prepare columns and find field indices
-	columns := make([]Column, len(fields))
-	fieldIndices := make([][]int, len(fields))
-	for i, field := range fields {
-		indices, found := getFieldIndices(elemType, field)
-		if !found {
-			return nil, nil, fmt.Errorf("field %s not found in struct", field)
-		}
-		fieldIndices[i] = indices
+func insertIndex[T any](s []T, index int, v T) []T {
+	var zero T
+	s = append(s, zero)
+	copy(s[index+1:], s[index:])
+	s[index] = v
 
-		// Determine column title
-		fieldStruct := elemType.FieldByIndex(indices)
-		columnTitle := fieldStruct.Name
-		if tag := fieldStruct.Tag.Get("xtable"); tag != "" {
-			columnTitle = tag
-		}
+	return s
+}
 
-		columns[i] = Column{Title: columnTitle, Width: len(columnTitle)}
+// compactSlice returns a copy of s in a right-sized backing array if its length
+// has fallen to a quarter of its capacity, otherwise it returns s.
+func compactSlice[T any](s []T) []T {
+	if cap(s) < compactMinCapacity || len(s) > cap(s)/4 {
+		return s
 	}
 
-	// Prepare rows and determine max width for each column
-	rows := make([]Row, v.Len())
-	for i := 0; i < v.Len(); i++ {
-		elem := v.Index(i)
-
-		rdata := make([]string, len(fields))
-		for j, indices := range fieldIndices {
-			val := getNestedFieldValue(elem, indices)
-			valStr := ""
-			if val.IsValid() {
-				valStr = fmt.Sprintf("%v", val.Interface())
-			}
-			rdata[j] = valStr
-			if len(valStr) > columns[j].Width {
-				columns[j].Width = len(valStr)
-			}
-		}
-
-		x := elem.Interface().(Metadata) // Assert metadata interface
-		rows[i] = Row{Data: rdata, Metadata: x}
-	}
+	compacted := make([]T, len(s))
+	copy(compacted, s)
 
-	return columns, rows, nil
+	return compacted
 }
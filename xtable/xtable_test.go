@@ -6,11 +6,14 @@ import (
 	"runtime"
 	"strconv"
 	"testing"
+	"time"
 	"unsafe"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/ansi"
 	"github.com/charmbracelet/x/exp/golden"
+	"github.com/muesli/termenv"
 	"github.com/stretchr/testify/require"
 )
 
@@ -125,6 +128,43 @@ func TestRenderRow(t *testing.T) {
 	}
 }
 
+type colouredRowData struct {
+	name   string
+	colour lipgloss.TerminalColor
+}
+
+func (c colouredRowData) GetHashCode() uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(c.name))
+	return h.Sum64()
+}
+
+func (c colouredRowData) RowColor() lipgloss.TerminalColor {
+	return c.colour
+}
+
+func TestRenderRowWithColorer(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI)
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+
+	red := colouredRowData{name: "red", colour: lipgloss.Color("1")}
+	plain := colouredRowData{name: "plain"}
+	table := &Model{
+		rows: []Row{
+			{Data: []string{"Selected", "", ""}, Metadata: red},
+			{Data: []string{"Red", "", ""}, Metadata: red},
+			{Data: []string{"Plain", "", ""}, Metadata: plain},
+		},
+		cols:   cols,
+		styles: Styles{Cell: lipgloss.NewStyle()},
+	}
+
+	require.NotContains(t, table.renderRow(0), "\x1b[31m", "selected row should not be coloured")
+	require.Contains(t, table.renderRow(1), "\x1b[31m")
+	require.Equal(t, "Plain                         ", table.renderRow(2))
+}
+
 func TestTableAlignment(t *testing.T) {
 	// Some issue with golden tests and EOL characters on Windows.
 	// Oddly works locally on Windows, but not in GH actions
@@ -710,6 +750,247 @@ func TestWithTaggedStructData(t *testing.T) {
 	}
 }
 
+type alignedRowData struct {
+	Name  string
+	Count int
+	Price float64 `xtable:"Price,align=center"`
+	Code  int     `xtable:"Code,align=left,sort=string"`
+}
+
+func (r alignedRowData) GetHashCode() uint64 {
+	return uint64(r.Count)
+}
+
+func TestStructDataNumericFields(t *testing.T) {
+	table := New(
+		WithStructData([]alignedRowData{{"a", 9, 1.5, 10}, {"b", 10, 2, 9}}),
+		WithQuickSortKeys(),
+		WithFocused(true),
+		WithStyles(Styles{}),
+	)
+
+	expect := []struct {
+		align lipgloss.Position
+		hint  interface{}
+	}{
+		{lipgloss.Left, SortString},
+		{lipgloss.Right, SortNumeric},
+		{lipgloss.Center, SortNumeric},
+		{lipgloss.Left, SortString},
+	}
+
+	for i, e := range expect {
+		require.Equal(t, e.align, table.cols[i].Align, "column %d", i)
+		require.Equal(t, e.hint, table.cols[i].SortHint, "column %d", i)
+	}
+
+	require.Equal(t, "b      10  2  9   ", table.renderRow(1))
+
+	// Count sorts numerically, Code as strings
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	require.Equal(t, []string{"9", "10"}, columnValues(table.Rows(), 1))
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'4'}})
+	require.Equal(t, []string{"10", "9"}, columnValues(table.Rows(), 3))
+}
+
+type address struct {
+	Street string
+	City   string `xtable:"Town"`
+}
+
+type nestedRowData struct {
+	Name    string
+	Home    address
+	Work    *address
+	Joined  time.Time
+	Manager *nestedRowData
+	Rating  *int
+}
+
+func (r *nestedRowData) GetHashCode() uint64 {
+	return uint64(len(r.Name))
+}
+
+func TestStructDataPointersAndNesting(t *testing.T) {
+	joined := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	rating := 5
+	data := []*nestedRowData{
+		{Name: "Ann", Home: address{"1 High St", "York"}, Work: &address{"2 Low Rd", "Leeds"}, Joined: joined, Rating: &rating},
+		nil,
+		{Name: "Bob", Home: address{"3 Mill Ln", "Hull"}},
+	}
+
+	table := New(WithStructData(data))
+
+	titles := []string{}
+	for _, c := range table.cols {
+		titles = append(titles, c.Title)
+	}
+
+	require.Equal(t, []string{
+		"Name", "Home.Street", "Home.Town", "Work.Street", "Work.Town", "Joined", "Manager", "Rating",
+	}, titles, "recursive types are not flattened")
+
+	require.Len(t, table.rows, 2, "nil pointers are skipped")
+	require.Equal(t, []string{"Ann", "1 High St", "York", "2 Low Rd", "Leeds", "2024-05-01 00:00:00", "", "5"}, table.rows[0].Data)
+	require.Equal(t, "", table.rows[1].Data[3], "nil nested pointers are empty")
+	require.Same(t, data[0], table.rows[0].Metadata)
+	require.Equal(t, lipgloss.Right, table.cols[len(titles)-1].Align, "pointers to numbers are numeric")
+
+	table = New(WithFieldSeparator(" "), WithStructData(data, "Name", "Home.Town"))
+	require.Equal(t, "Home Town", table.cols[1].Title)
+	require.Equal(t, []string{"Bob", "Hull"}, table.rows[1].Data)
+
+	require.Panics(t, func() { New(WithStructData(data, "Town")) })
+}
+
+type formattedRowData struct {
+	Price   float64   `xtable:"Price,format=%.2f"`
+	Created time.Time `xtable:"Created,format=2006-01-02"`
+	Code    *int      `xtable:"Code,format=%04d"`
+}
+
+func (r formattedRowData) GetHashCode() uint64 {
+	return uint64(r.Price)
+}
+
+func TestStructDataFormat(t *testing.T) {
+	code := 42
+	table := New(WithStructData([]formattedRowData{
+		{Price: 1.5, Created: time.Date(2024, 5, 1, 13, 0, 0, 0, time.UTC), Code: &code},
+		{Price: 10},
+	}))
+
+	require.Equal(t, []string{"1.50", "2024-05-01", "0042"}, table.rows[0].Data)
+	require.Equal(t, []string{"10.00", "0001-01-01", ""}, table.rows[1].Data)
+}
+
+type skippedRowData struct {
+	Name     string
+	Password string  `xtable:"-"`
+	Home     address `xtable:"-"`
+	Size     int
+}
+
+func (r skippedRowData) GetHashCode() uint64 {
+	return uint64(r.Size)
+}
+
+func TestStructDataSkippedFields(t *testing.T) {
+	data := []skippedRowData{{Name: "Ann", Password: "secret", Size: 3}}
+	table := New(WithStructData(data))
+
+	require.Equal(t, 2, len(table.cols))
+	require.Equal(t, "Size", table.cols[1].Title)
+	require.Equal(t, []string{"Ann", "3"}, table.rows[0].Data)
+
+	require.Panics(t, func() { New(WithStructData(data, "Password")) })
+}
+
+type orderedRowData struct {
+	ID   int `xtable:"ID,order=3"`
+	Note string
+	Name string  `xtable:"Name,order=1"`
+	Home address `xtable:"Home"`
+	Size int     `xtable:"Size,order=2"`
+}
+
+func (r orderedRowData) GetHashCode() uint64 {
+	return uint64(r.ID)
+}
+
+func TestStructDataOrder(t *testing.T) {
+	data := []orderedRowData{{ID: 1, Note: "n", Name: "Ann", Size: 3}}
+	table := New(WithStructData(data))
+
+	titles := []string{}
+	for _, c := range table.cols {
+		titles = append(titles, c.Title)
+	}
+
+	require.Equal(t, []string{"Name", "Size", "ID", "Note", "Home.Street", "Home.Town"}, titles)
+	require.Equal(t, []string{"Ann", "3", "1", "n", "", ""}, table.rows[0].Data)
+
+	table = New(WithStructData(data, "ID", "Name"))
+	require.Equal(t, "ID", table.cols[0].Title, "fields given are in the order given")
+}
+
+type badOrderRowData struct {
+	Name string `xtable:"Name,order=first"`
+}
+
+func (r badOrderRowData) GetHashCode() uint64 {
+	return 0
+}
+
+func TestStructDataInvalidOrder(t *testing.T) {
+	require.Panics(t, func() { New(WithStructData([]badOrderRowData{{}})) })
+}
+
+type timedRowData struct {
+	Name    string
+	Created time.Time
+	Updated *time.Time `xtable:"Updated,kind=age"`
+}
+
+func (r timedRowData) GetHashCode() uint64 {
+	return uint64(len(r.Name))
+}
+
+func TestStructDataTimeFields(t *testing.T) {
+	updated := time.Date(2024, 5, 1, 9, 30, 0, 0, time.FixedZone("", 3600))
+	data := []timedRowData{
+		{Name: "a", Created: time.Date(2024, 12, 25, 9, 0, 0, 0, time.UTC), Updated: &updated},
+		{Name: "bb", Created: time.Date(2024, 2, 1, 17, 45, 0, 0, time.UTC)},
+	}
+
+	table := New(WithStructData(data))
+	require.Equal(t, []string{"a", "2024-12-25 09:00:00", "2024-05-01T09:30:00+01:00"}, table.rows[0].Data)
+	require.Equal(t, "", table.rows[1].Data[2])
+	require.Equal(t, SortTime, table.cols[1].SortHint)
+	require.Equal(t, SortTime, table.cols[2].SortHint)
+
+	table = New(WithTimeLayout("Jan 2 15:04"), WithStructData(data))
+	require.Equal(t, "Dec 25 09:00", table.rows[0].Data[1])
+	require.Equal(t, TimeLayout("Jan 2 15:04"), table.cols[1].SortHint)
+	require.Nil(t, table.cols[1].Less)
+
+	table.SortBy(1, SortAscending, table.cols[1].SortHint)
+	require.Equal(t, []string{"Feb 1 17:45", "Dec 25 09:00"}, columnValues(table.Rows(), 1), "sorted chronologically")
+}
+
+type biscuitKind int
+
+func (k biscuitKind) String() string {
+	return [...]string{"plain", "chocolate"}[k]
+}
+
+type biscuitID string
+
+func (id *biscuitID) String() string {
+	return "#" + string(*id)
+}
+
+type stringerRowData struct {
+	ID    biscuitID
+	Kind  biscuitKind
+	Other *biscuitKind
+	Count biscuitKind `xtable:"Count,format=%d"`
+}
+
+func (r stringerRowData) GetHashCode() uint64 {
+	return uint64(len(r.ID))
+}
+
+func TestStructDataStringer(t *testing.T) {
+	kind := biscuitKind(1)
+	data := []stringerRowData{{ID: "b1", Kind: 1, Other: &kind, Count: 1}, {ID: "b2"}}
+
+	table := New(WithStructData(data))
+	require.Equal(t, []string{"#b1", "chocolate", "chocolate", "1"}, table.rows[0].Data)
+	require.Equal(t, []string{"#b2", "plain", "", "0"}, table.rows[1].Data)
+}
+
 func TestRemoveRowsByIndex(t *testing.T) {
 	data := []rowData{
 		newRowData("Chocolate Digestives", 12),
@@ -775,8 +1056,132 @@ func TestRemoveRow(t *testing.T) {
 	require.Equal(t, "Tim Tams", table.rows[0].Data[0])
 }
 
+func TestRemoveRowsWhere(t *testing.T) {
+	data := []rowData{
+		newRowData("Chocolate Digestives", 12),
+		newRowData("Tim Tams", 8),
+		newRowData("Hobnobs", 10),
+		newRowData("Peanut Butter Cookie", 8),
+		newRowData("Bourbons", 16),
+	}
+
+	eights := func(r Row) bool { return r.Data[1] == "8" }
+
+	table := New(WithStructData(data))
+	table.SetCursor(2)
+	require.Equal(t, 2, table.RemoveRowsWhere(eights))
+	require.Equal(t, []string{"Chocolate Digestives", "Hobnobs", "Bourbons"}, columnValues(table.rows, 0))
+	require.Equal(t, "Hobnobs", table.SelectedRow().Data[0], "selected row remains selected")
+	require.Equal(t, 0, table.RemoveRowsWhere(eights))
+
+	table = New(WithStructData(data))
+	table.SetCursor(3)
+	require.Equal(t, 2, table.RemoveRowsWhere(eights))
+	require.Equal(t, "Bourbons", table.SelectedRow().Data[0], "next row is selected")
+
+	table = New(WithStructData(data[:4]))
+	table.SetCursor(3)
+	require.Equal(t, 2, table.RemoveRowsWhere(eights))
+	require.Equal(t, "Hobnobs", table.SelectedRow().Data[0], "previous row is selected at the end")
+
+	require.Equal(t, 2, table.RemoveRowsWhere(func(Row) bool { return true }))
+	require.Empty(t, table.rows)
+}
+
+func TestRemoveRowsByHashes(t *testing.T) {
+	data := []rowData{
+		newRowData("Chocolate Digestives", 12),
+		newRowData("Tim Tams", 8),
+		newRowData("Hobnobs", 10),
+		newRowData("Peanut Butter Cookie", 8),
+	}
+
+	table := New(WithStructData(data), WithMultiSelect())
+	table.SetCursor(1)
+	require.True(t, table.ToggleMark())
+	table.SetCursor(2)
+	require.True(t, table.ToggleMark())
+
+	hashes := []uint64{}
+	for _, r := range table.MarkedRows() {
+		hashes = append(hashes, r.Metadata.GetHashCode())
+	}
+
+	require.Equal(t, 2, table.RemoveRowsByHashes(append(hashes, 42)))
+	require.Equal(t, []string{"Chocolate Digestives", "Peanut Butter Cookie"}, columnValues(table.rows, 0))
+	require.Equal(t, "Peanut Butter Cookie", table.SelectedRow().Data[0], "nearest remaining row is selected")
+	require.Empty(t, table.marks)
+	require.Equal(t, 0, table.RemoveRowsByHashes(nil))
+}
+
 func skipIfGithubOnWindows(t *testing.T) {
 	if _, github := os.LookupEnv("GITHUB_ACTION"); github && runtime.GOOS == "windows" {
 		t.Skip("Skipping for github incompatibility")
 	}
 }
+
+func TestRemoveIndexReleasesElement(t *testing.T) {
+	rows := []Row{
+		{Data: []string{"a"}},
+		{Data: []string{"b"}},
+		{Data: []string{"c"}},
+	}
+
+	remaining := removeIndex(rows, 0)
+	require.Equal(t, 2, len(remaining))
+	require.Equal(t, "b", remaining[0].Data[0])
+	require.Equal(t, "c", remaining[1].Data[0])
+
+	// Trailing slot of backing array is cleared
+	require.Nil(t, rows[2].Data)
+}
+
+func TestRowCompaction(t *testing.T) {
+	data := make([]rowData, 100)
+	for i := range data {
+		data[i] = newRowData("Biscuit", i)
+	}
+
+	table := New(WithStructData(data), WithRowCompaction())
+
+	for len(table.rows) > 25 {
+		table.RemoveRowByIndex(0)
+	}
+
+	require.Equal(t, 25, cap(table.rows))
+}
+
+func TestRowCompactionRemoveRowsWhere(t *testing.T) {
+	data := make([]rowData, 100)
+	for i := range data {
+		data[i] = newRowData("Biscuit", i)
+	}
+
+	table := New(WithStructData(data), WithRowCompaction())
+	require.Equal(t, 90, table.RemoveRowsWhere(func(r Row) bool { return r.Metadata.(rowData).PacketSize >= 10 }))
+	require.Equal(t, 10, cap(table.rows))
+
+	// Rows hidden by a filter are also compacted
+	table = New(WithStructData(data), WithRowCompaction())
+	table.Filter(func(r Row) bool { return r.Metadata.(rowData).PacketSize%2 == 0 })
+	table.RemoveRowsWhere(func(r Row) bool { return r.Metadata.(rowData).PacketSize >= 10 })
+	require.Equal(t, 10, cap(table.filter.all))
+	require.Len(t, table.Rows(), 5)
+}
+
+func TestSelectedRowScreenRect(t *testing.T) {
+	data := make([]rowData, 30)
+	for i := range data {
+		data[i] = newRowData("Biscuit "+strconv.Itoa(i), i)
+	}
+
+	table := New(WithStructData(data), WithHeight(10), WithWidth(40))
+	table.SetCursor(3)
+
+	rect, ok := table.SelectedRowScreenRect(2, 5)
+	require.True(t, ok)
+	require.Equal(t, 2, rect.X)
+	require.Equal(t, 5+1+3, rect.Y)
+	require.Equal(t, 1, rect.Height)
+	require.Equal(t, lipgloss.Width(table.headersView()), rect.Width)
+}